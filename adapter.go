@@ -0,0 +1,199 @@
+package boar
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// AdapterOption configures the http.Handler created by ToHTTPHandler. Options are applied
+// in order to a Router created with NewRouter
+type AdapterOption func(*Router) *Router
+
+// AdapterRouter makes ToHTTPHandler use the middlewares, ErrorHandler, OnError hooks, and
+// settings of rtr. It replaces the Router configured by earlier options so it should be
+// the first option
+func AdapterRouter(rtr *Router) AdapterOption {
+	return func(*Router) *Router {
+		return rtr
+	}
+}
+
+// AdapterErrorHandler makes ToHTTPHandler handle errors with h. See Router.WithErrorHandler
+func AdapterErrorHandler(h ErrorHandlerFunc) AdapterOption {
+	return func(rtr *Router) *Router {
+		return rtr.WithErrorHandler(h)
+	}
+}
+
+// AdapterMiddleware makes ToHTTPHandler execute mw before the handler. See Router.With
+func AdapterMiddleware(mw ...Middleware) AdapterOption {
+	return func(rtr *Router) *Router {
+		return rtr.With(mw...)
+	}
+}
+
+// ToHTTPHandler creates an http.Handler which serves requests with h just as a route of a
+// Router would: the response is buffered, returned errors are written by the
+// ErrorHandler, and OnError hooks and deferred funcs are run. When the handler is
+// mounted on an http.ServeMux the wildcards of the matched pattern are available with
+// Context.URLParams.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("GET /users/{id}", boar.ToHTTPHandler(getUser,
+//		boar.AdapterRouter(rtr),
+//		boar.AdapterMiddleware(middleware.Logger),
+//	))
+func ToHTTPHandler(h HandlerFunc, opts ...AdapterOption) http.Handler {
+	if h == nil {
+		panic("cannot adapt a nil HandlerFunc")
+	}
+	rtr := NewRouter()
+	for _, opt := range opts {
+		rtr = opt(rtr)
+	}
+	handler := rtr.newChain(requestParserMiddleware(func(Context) (Handler, error) {
+		return &simpleHandler{handle: h}, nil
+	}))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rtr.serveRoute(w, r, patternParams(r), patternPath(r.Pattern), handler)
+	})
+}
+
+// patternParams returns the values of the wildcards of the http.ServeMux pattern which
+// matched r as URL params
+func patternParams(r *http.Request) httprouter.Params {
+	var ps httprouter.Params
+	for p := r.Pattern; ; {
+		i := strings.IndexByte(p, '{')
+		if i < 0 {
+			return ps
+		}
+		j := strings.IndexByte(p[i:], '}')
+		if j < 0 {
+			return ps
+		}
+		name := p[i+1 : i+j]
+		p = p[i+j+1:]
+		if name == "" || name == "$" {
+			continue
+		}
+		// the values of catch-all params begin with a slash as they do with httprouter
+		if strings.HasSuffix(name, "...") {
+			name = strings.TrimSuffix(name, "...")
+			ps = append(ps, httprouter.Param{Key: name, Value: "/" + r.PathValue(name)})
+			continue
+		}
+		ps = append(ps, httprouter.Param{Key: name, Value: r.PathValue(name)})
+	}
+}
+
+// patternPath returns the path of an http.ServeMux pattern without its method
+func patternPath(pattern string) string {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		return strings.TrimLeft(pattern[i:], " \t")
+	}
+	return pattern
+}
+
+// FromHTTPHandler creates a HandlerFunc which serves requests with h. The URL params of the
+// route are available to h with Request.PathValue. When h responds with an error status
+// code the response is not sent. Instead an HTTPError with the body written by h as its
+// message is returned so that it is written by the ErrorHandler like the errors of any
+// other handler.
+//
+// Example:
+//
+//	rtr.MethodFunc(http.MethodGet, "/metrics", boar.FromHTTPHandler(promhttp.Handler()))
+func FromHTTPHandler(h http.Handler) HandlerFunc {
+	if h == nil {
+		panic("cannot adapt a nil http.Handler")
+	}
+	return func(c Context) error {
+		r := c.Request()
+		for _, p := range c.URLParams() {
+			r.SetPathValue(p.Key, p.Value)
+		}
+
+		w := &errorCapturingWriter{ResponseWriter: c.Response()}
+		h.ServeHTTP(w, r)
+		if w.status < http.StatusBadRequest {
+			return nil
+		}
+
+		// the headers describe the captured body rather than the error which replaces it
+		c.Response().Header().Del("content-type")
+		c.Response().Header().Del("content-length")
+		msg := strings.TrimSpace(w.body.String())
+		if msg == "" {
+			msg = http.StatusText(w.status)
+		}
+		return NewHTTPError(w.status, errors.New(msg))
+	}
+}
+
+var (
+	_ http.Flusher  = (*errorCapturingWriter)(nil)
+	_ http.Hijacker = (*errorCapturingWriter)(nil)
+)
+
+// errorCapturingWriter passes responses through to the ResponseWriter of a Context unless
+// their status code is an error, in which case the body is captured
+type errorCapturingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *errorCapturingWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	if status >= http.StatusOK {
+		w.status = status
+	}
+	if status < http.StatusBadRequest {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *errorCapturingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.status >= http.StatusBadRequest {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *errorCapturingWriter) Flush() {
+	if w.status >= http.StatusBadRequest {
+		return
+	}
+	switch f := w.ResponseWriter.(type) {
+	case ResponseWriter:
+		f.Flush()
+	case http.Flusher:
+		f.Flush()
+	}
+}
+
+func (w *errorCapturingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Unwrap returns the ResponseWriter of the Context for http.ResponseController
+func (w *errorCapturingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,172 @@
+//go:debug httpmuxgo121=0
+
+package boar
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTTPHandlerServesHandler(t *testing.T) {
+	h := ToHTTPHandler(func(c Context) error {
+		return c.WriteString(http.StatusCreated, "created")
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "created", rec.Body.String())
+}
+
+func TestToHTTPHandlerWritesErrorsWithErrorHandler(t *testing.T) {
+	var handled error
+	h := ToHTTPHandler(func(Context) error {
+		return ErrForbidden
+	}, AdapterErrorHandler(func(c Context, err error) {
+		handled = err
+		c.WriteString(http.StatusTeapot, "nope")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, ErrForbidden, handled)
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "nope", rec.Body.String())
+}
+
+func TestToHTTPHandlerUsesDefaultErrorHandler(t *testing.T) {
+	h := ToHTTPHandler(func(Context) error {
+		return ErrEntityNotFound
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error":"entity not found"}`, rec.Body.String())
+}
+
+func TestToHTTPHandlerUsesRouterMiddlewaresAndHooks(t *testing.T) {
+	rtr := NewRouter()
+	var calls []string
+	rtr.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			calls = append(calls, "router")
+			return next(c)
+		}
+	})
+	var reported error
+	rtr.OnError(func(_ Context, err error) { reported = err })
+
+	h := ToHTTPHandler(func(Context) error {
+		calls = append(calls, "handler")
+		return ErrGone
+	}, AdapterRouter(rtr), AdapterMiddleware(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			calls = append(calls, "adapter")
+			return next(c)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"router", "adapter", "handler"}, calls)
+	assert.Equal(t, ErrGone, reported)
+	assert.Equal(t, http.StatusGone, rec.Code)
+}
+
+func TestToHTTPHandlerProvidesServeMuxWildcards(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{id}/files/{path...}", ToHTTPHandler(func(c Context) error {
+		return c.WriteJSON(http.StatusOK, map[string]string{
+			"id":      c.URLParams().ByName("id"),
+			"path":    c.URLParams().ByName("path"),
+			"pattern": c.RoutePattern(),
+		})
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42/files/a/b.txt", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id":"42","path":"/a/b.txt","pattern":"/users/{id}/files/{path...}"}`, rec.Body.String())
+}
+
+func TestToHTTPHandlerPanicsForNilHandler(t *testing.T) {
+	assert.Panics(t, func() { ToHTTPHandler(nil) })
+}
+
+func TestPatternPath(t *testing.T) {
+	assert.Equal(t, "/users/{id}", patternPath("GET /users/{id}"))
+	assert.Equal(t, "/users/{id}", patternPath("/users/{id}"))
+	assert.Equal(t, "", patternPath(""))
+}
+
+func TestFromHTTPHandlerServesRequests(t *testing.T) {
+	rtr := NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", FromHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/plain")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("user " + r.PathValue("id")))
+	})))
+
+	rec := httptest.NewRecorder()
+	rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "text/plain", rec.Header().Get("content-type"))
+	assert.Equal(t, "user 42", rec.Body.String())
+}
+
+func TestFromHTTPHandlerReturnsErrorResponsesAsHTTPErrors(t *testing.T) {
+	h := FromHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such user", http.StatusNotFound)
+	}))
+	rec := httptest.NewRecorder()
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec, nil)
+
+	err := h(c)
+	require.Error(t, err)
+	herr, ok := err.(HTTPError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, herr.Status())
+	assert.Equal(t, "no such user", errors.Unwrap(herr).Error())
+	assert.Equal(t, 0, c.Response().Len())
+	assert.Empty(t, c.Response().Header().Get("content-type"))
+}
+
+func TestFromHTTPHandlerErrorsAreWrittenByErrorHandler(t *testing.T) {
+	rtr := NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/", FromHTTPHandler(http.NotFoundHandler()))
+
+	rec := httptest.NewRecorder()
+	rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("content-type"))
+	assert.JSONEq(t, `{"error":"404 page not found"}`, rec.Body.String())
+}
+
+func TestFromHTTPHandlerUsesStatusTextForEmptyErrors(t *testing.T) {
+	h := FromHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), httprouter.Params{})
+
+	err := h(c)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusText(http.StatusServiceUnavailable), errors.Unwrap(err).Error())
+}
+
+func TestFromHTTPHandlerPanicsForNilHandler(t *testing.T) {
+	assert.Panics(t, func() { FromHTTPHandler(nil) })
+}
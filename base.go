@@ -0,0 +1,83 @@
+package boar
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// basePopulator fills embedded structs of type typ with the func registered by PopulateBase
+type basePopulator struct {
+	typ reflect.Type
+	fn  reflect.Value
+}
+
+// PopulateBase registers populate, which must be a func(Context, *T) error where T is a
+// struct, to fill every T embedded in a handler before the request is bound. It allows
+// handlers to share the extraction of values such as the logger, current user, or tenant
+// rather than repeating it in each HandlerProviderFunc. Embedded *T fields are allocated
+// when they are nil. Binding and Handle are skipped when populate returns an error.
+//
+// Example:
+//
+//	type Base struct {
+//		Log  boar.Logger
+//		User *User
+//	}
+//
+//	rtr.PopulateBase(func(c boar.Context, b *Base) error {
+//		b.Log = c.Logger()
+//		b.User, _ = auth.User(c)
+//		return nil
+//	})
+//
+//	type GetProfileHandler struct {
+//		Base
+//	}
+func (rtr *Router) PopulateBase(populate interface{}) {
+	fn := reflect.ValueOf(populate)
+	typ := fn.Type()
+	if typ.Kind() != reflect.Func ||
+		typ.NumIn() != 2 || typ.In(0) != contextType ||
+		typ.In(1).Kind() != reflect.Ptr || typ.In(1).Elem().Kind() != reflect.Struct ||
+		typ.NumOut() != 1 || typ.Out(0) != errorType {
+		panic(fmt.Sprintf("cannot populate base with %s: must be a func(Context, *T) error", typ))
+	}
+	root := rtr.root()
+	root.bases = append(root.bases, basePopulator{typ: typ.In(1).Elem(), fn: fn})
+}
+
+// populateBases fills the embedded structs of handler with the populators of the Router
+// which handled the request
+func populateBases(handler reflect.Value, c Context) error {
+	rtr := routerOf(c)
+	if rtr == nil || len(rtr.bases) == 0 || handler.Kind() != reflect.Struct {
+		return nil
+	}
+
+	typ := handler.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.Anonymous {
+			continue
+		}
+		for _, base := range rtr.bases {
+			if sf.Type != base.typ && (sf.Type.Kind() != reflect.Ptr || sf.Type.Elem() != base.typ) {
+				continue
+			}
+			field := handler.Field(i)
+			if !field.CanSet() {
+				return &badFieldError{field: sf.Name, handler: handler, err: errNotSettable}
+			}
+			if sf.Type == base.typ {
+				field = field.Addr()
+			} else if field.IsNil() {
+				field.Set(reflect.New(base.typ))
+			}
+			out := base.fn.Call([]reflect.Value{reflect.ValueOf(&c).Elem(), field})
+			if err, _ := out[0].Interface().(error); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantBase struct {
+	Tenant string
+}
+
+type userBase struct {
+	User string
+}
+
+type baseHandler struct {
+	tenantBase
+}
+
+type exportedBaseHandler struct {
+	TenantBase
+	*UserBase
+}
+
+type TenantBase = tenantBase
+
+type UserBase = userBase
+
+func (h *exportedBaseHandler) Handle(c Context) error {
+	return c.WriteString(http.StatusOK, h.Tenant+" "+h.User)
+}
+
+func (h *baseHandler) Handle(Context) error { return nil }
+
+func TestPopulateBaseShouldFillEmbeddedStructs(t *testing.T) {
+	r := NewRouter()
+	r.PopulateBase(func(c Context, b *tenantBase) error {
+		b.Tenant = c.Request().Header.Get("X-Tenant")
+		return nil
+	})
+	r.PopulateBase(func(c Context, b *userBase) error {
+		b.User = "gopher"
+		return nil
+	})
+	r.Get("/", func(Context) (Handler, error) {
+		return &exportedBaseHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Tenant", "acme")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme gopher", rec.Body.String())
+}
+
+func TestPopulateBaseShouldReturnErrors(t *testing.T) {
+	r := NewRouter()
+	r.PopulateBase(func(c Context, b *tenantBase) error {
+		return ErrUnauthorized
+	})
+	r.Get("/", func(Context) (Handler, error) {
+		return &exportedBaseHandler{}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestPopulateBaseShouldRejectUnexportedEmbeddedStructs(t *testing.T) {
+	r := NewRouter()
+	r.Logger = NopLogger
+	r.PopulateBase(func(c Context, b *tenantBase) error {
+		return nil
+	})
+	r.Get("/", func(Context) (Handler, error) {
+		return &baseHandler{}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+type helper struct{}
+
+type unrelatedBaseHandler struct {
+	helper
+	TenantBase
+}
+
+func (h *unrelatedBaseHandler) Handle(c Context) error {
+	return c.WriteString(http.StatusOK, h.Tenant)
+}
+
+func TestPopulateBaseShouldIgnoreUnrelatedUnexportedEmbeddedStructs(t *testing.T) {
+	r := NewRouter()
+	r.PopulateBase(func(c Context, b *tenantBase) error {
+		b.Tenant = "acme"
+		return nil
+	})
+	r.Get("/", func(Context) (Handler, error) {
+		return &unrelatedBaseHandler{}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme", rec.Body.String())
+}
+
+func TestPopulateBaseShouldPanicWithInvalidFunc(t *testing.T) {
+	tests := []interface{}{
+		"not a func",
+		func(Context, tenantBase) error { return nil },
+		func(Context, *string) error { return nil },
+		func(*tenantBase) error { return nil },
+		func(Context, *tenantBase) {},
+	}
+	for _, fn := range tests {
+		assert.Panics(t, func() {
+			NewRouter().PopulateBase(fn)
+		})
+	}
+}
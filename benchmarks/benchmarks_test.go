@@ -0,0 +1,64 @@
+package benchmarks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+)
+
+// nopWriter is an http.ResponseWriter which discards everything so that the benchmarks
+// do not measure httptest.ResponseRecorder
+type nopWriter struct {
+	header http.Header
+}
+
+func newNopWriter() *nopWriter {
+	return &nopWriter{header: http.Header{}}
+}
+
+func (w *nopWriter) Header() http.Header         { return w.header }
+func (w *nopWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *nopWriter) WriteHeader(int)             {}
+
+// serve benchmarks h serving requests created by newRequest. newRequest is called for
+// every request when the request has a body since bodies can only be read once
+func serve(b *testing.B, h http.Handler, newRequest func() *http.Request) {
+	b.Helper()
+	w := newNopWriter()
+	r := newRequest()
+	reuse := r.Body == nil || r.Body == http.NoBody
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !reuse {
+			b.StopTimer()
+			r = newRequest()
+			b.StartTimer()
+		}
+		h.ServeHTTP(w, r)
+	}
+}
+
+func get(target string) func() *http.Request {
+	return func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, target, nil)
+	}
+}
+
+func post(target, contentType, body string) func() *http.Request {
+	return func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		return r
+	}
+}
+
+func newRouter() *boar.Router {
+	rtr := boar.NewRouter()
+	rtr.Logger = boar.NopLogger
+	return rtr
+}
@@ -0,0 +1,178 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/blockloop/boar/bind"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	queryTarget = "/users?page=2&sort=name&tag=a&tag=b&active=true"
+	jsonBody    = `{"name": "Brett", "age": 100, "charges": [19.99, 20.99, 103.12]}`
+)
+
+type usersQuery struct {
+	Page   int      `query:"page"`
+	Sort   string   `query:"sort"`
+	Tags   []string `query:"tag"`
+	Active bool     `query:"active"`
+}
+
+type queryHandler struct {
+	Query usersQuery
+}
+
+func (h *queryHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Query.Sort)
+}
+
+// generatedQueryHandler binds its Query field without reflection as binders generated by
+// cmd/boarbind do
+type generatedQueryHandler struct {
+	Query usersQuery
+}
+
+func (h *generatedQueryHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Query.Sort)
+}
+
+func (h *generatedQueryHandler) BindQuery(q url.Values) error {
+	if vals := q["page"]; len(vals) > 0 {
+		v, err := bind.ParseInt("page", strings.TrimSpace(vals[0]), reflect.Int)
+		if err != nil {
+			return err
+		}
+		h.Query.Page = int(v)
+	}
+	if vals := q["sort"]; len(vals) > 0 {
+		h.Query.Sort = strings.TrimSpace(vals[0])
+	}
+	for _, val := range q["tag"] {
+		h.Query.Tags = append(h.Query.Tags, strings.TrimSpace(val))
+	}
+	if vals := q["active"]; len(vals) > 0 {
+		v, err := bind.ParseBool("active", strings.TrimSpace(vals[0]))
+		if err != nil {
+			return err
+		}
+		h.Query.Active = v
+	}
+	return nil
+}
+
+func BenchmarkBindQueryBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.Get("/users", func(boar.Context) (boar.Handler, error) {
+		return &queryHandler{}, nil
+	})
+	serve(b, rtr, get(queryTarget))
+}
+
+func BenchmarkBindQueryBoarGenerated(b *testing.B) {
+	rtr := newRouter()
+	rtr.Get("/users", func(boar.Context) (boar.Handler, error) {
+		return &generatedQueryHandler{}, nil
+	})
+	serve(b, rtr, get(queryTarget))
+}
+
+func BenchmarkBindQueryHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/users", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		q := r.URL.Query()
+		var query usersQuery
+		var err error
+		if query.Page, err = strconv.Atoi(q.Get("page")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.Sort = q.Get("sort")
+		query.Tags = q["tag"]
+		if query.Active, err = strconv.ParseBool(q.Get("active")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(query.Sort))
+	})
+	serve(b, rtr, get(queryTarget))
+}
+
+type paramsHandler struct {
+	URLParams struct {
+		ID   int `url:"id"`
+		Post int `url:"post"`
+	}
+}
+
+func (h *paramsHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, strconv.Itoa(h.URLParams.ID+h.URLParams.Post))
+}
+
+func BenchmarkBindParamsBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.Get("/users/:id/posts/:post", func(boar.Context) (boar.Handler, error) {
+		return &paramsHandler{}, nil
+	})
+	serve(b, rtr, get("/users/42/posts/7"))
+}
+
+func BenchmarkBindParamsHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		post, err := strconv.Atoi(ps.ByName("post"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(strconv.Itoa(id + post)))
+	})
+	serve(b, rtr, get("/users/42/posts/7"))
+}
+
+type user struct {
+	Name    string    `json:"name"`
+	Age     int       `json:"age"`
+	Charges []float32 `json:"charges"`
+}
+
+type bodyHandler struct {
+	Body user
+}
+
+func (h *bodyHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Body.Name)
+}
+
+func BenchmarkBindJSONBodyBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.Post("/users", func(boar.Context) (boar.Handler, error) {
+		return &bodyHandler{}, nil
+	})
+	serve(b, rtr, post("/users", "application/json", jsonBody))
+}
+
+func BenchmarkBindJSONBodyHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.POST("/users", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		var body user
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(body.Name))
+	})
+	serve(b, rtr, post("/users", "application/json", jsonBody))
+}
@@ -0,0 +1,11 @@
+// Package benchmarks measures the overhead of boar compared to serving the same requests
+// with httprouter directly. Each boar benchmark has an httprouter counterpart which does
+// the equivalent work by hand so that regressions in routing, binding, middlewares, and
+// error handling show up as a change in the gap between the two.
+//
+// Run them from the root of the repository with
+//
+//	go test -run XXX -bench . -benchmem ./benchmarks
+//
+// or make bench-compare, and compare runs with benchstat.
+package benchmarks
@@ -0,0 +1,67 @@
+package benchmarks
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/julienschmidt/httprouter"
+)
+
+func BenchmarkErrorBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.MethodFunc(http.MethodGet, "/", func(boar.Context) error {
+		return boar.ErrNotFound
+	})
+	serve(b, rtr, get("/"))
+}
+
+func BenchmarkErrorHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":["Not Found"]}`))
+	})
+	serve(b, rtr, get("/"))
+}
+
+func BenchmarkValidationErrorBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.Get("/users", func(boar.Context) (boar.Handler, error) {
+		return &queryHandler{}, nil
+	})
+	serve(b, rtr, get("/users?page=two"))
+}
+
+func BenchmarkValidationErrorHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/users", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		if _, err := strconv.Atoi(r.URL.Query().Get("page")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	})
+	serve(b, rtr, get("/users?page=two"))
+}
+
+func BenchmarkPanicBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.Use(boar.PanicMiddleware)
+	rtr.MethodFunc(http.MethodGet, "/", func(boar.Context) error {
+		panic("boom")
+	})
+	serve(b, rtr, get("/"))
+}
+
+func BenchmarkPanicHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.PanicHandler = func(w http.ResponseWriter, r *http.Request, _ interface{}) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+	rtr.GET("/", func(http.ResponseWriter, *http.Request, httprouter.Params) {
+		panic("boom")
+	})
+	serve(b, rtr, get("/"))
+}
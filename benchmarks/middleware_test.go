@@ -0,0 +1,53 @@
+package benchmarks
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/julienschmidt/httprouter"
+)
+
+// depths are the lengths of the middleware chains which are benchmarked
+var depths = []int{0, 1, 5, 10}
+
+func BenchmarkMiddlewareBoar(b *testing.B) {
+	for _, depth := range depths {
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			rtr := newRouter()
+			for i := 0; i < depth; i++ {
+				rtr.Use(func(next boar.HandlerFunc) boar.HandlerFunc {
+					return func(c boar.Context) error {
+						c.Response().Header().Set("X-Depth", "1")
+						return next(c)
+					}
+				})
+			}
+			rtr.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+				return c.WriteString(http.StatusOK, "ok")
+			})
+			serve(b, rtr, get("/"))
+		})
+	}
+}
+
+func BenchmarkMiddlewareHTTPRouter(b *testing.B) {
+	for _, depth := range depths {
+		b.Run(strconv.Itoa(depth), func(b *testing.B) {
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			})
+			for i := 0; i < depth; i++ {
+				next := h
+				h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("X-Depth", "1")
+					next.ServeHTTP(w, r)
+				})
+			}
+			rtr := httprouter.New()
+			rtr.Handler(http.MethodGet, "/", h)
+			serve(b, rtr, get("/"))
+		})
+	}
+}
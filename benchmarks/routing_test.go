@@ -0,0 +1,65 @@
+package benchmarks
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/julienschmidt/httprouter"
+)
+
+func BenchmarkRoutingStaticBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.MethodFunc(http.MethodGet, "/users", func(c boar.Context) error {
+		return c.WriteString(http.StatusOK, "ok")
+	})
+	serve(b, rtr, get("/users"))
+}
+
+func BenchmarkRoutingStaticHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/users", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.Write([]byte("ok"))
+	})
+	serve(b, rtr, get("/users"))
+}
+
+func BenchmarkRoutingParamsBoar(b *testing.B) {
+	rtr := newRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id/posts/:post", func(c boar.Context) error {
+		return c.WriteString(http.StatusOK, c.Param("id")+c.Param("post"))
+	})
+	serve(b, rtr, get("/users/42/posts/7"))
+}
+
+func BenchmarkRoutingParamsHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	rtr.GET("/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		w.Write([]byte(ps.ByName("id") + ps.ByName("post")))
+	})
+	serve(b, rtr, get("/users/42/posts/7"))
+}
+
+// routes is the amount of routes registered by the benchmarks of large route tables
+const routes = 100
+
+func BenchmarkRoutingManyRoutesBoar(b *testing.B) {
+	rtr := newRouter()
+	for i := 0; i < routes; i++ {
+		rtr.MethodFunc(http.MethodGet, fmt.Sprintf("/resource%d/:id", i), func(c boar.Context) error {
+			return c.WriteString(http.StatusOK, c.Param("id"))
+		})
+	}
+	serve(b, rtr, get(fmt.Sprintf("/resource%d/42", routes-1)))
+}
+
+func BenchmarkRoutingManyRoutesHTTPRouter(b *testing.B) {
+	rtr := httprouter.New()
+	for i := 0; i < routes; i++ {
+		rtr.GET(fmt.Sprintf("/resource%d/:id", i), func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			w.Write([]byte(ps.ByName("id")))
+		})
+	}
+	serve(b, rtr, get(fmt.Sprintf("/resource%d/42", routes-1)))
+}
@@ -0,0 +1,23 @@
+package bind
+
+import (
+	"net/http"
+	"reflect"
+)
+
+const (
+	headerTagKey = "header"
+)
+
+// Header parses http headers and injects them into v. Fields are matched with the
+// header named in their header tag or by their field name
+func Header(v interface{}, h http.Header) error {
+	return HeaderValue(reflect.ValueOf(v).Elem(), h)
+}
+
+// HeaderValue parses http headers and injects them into v
+func HeaderValue(obj reflect.Value, h http.Header) error {
+	return valuesValue(obj, headerFields.fields(obj.Type()), h)
+}
+
+var headerFields = &fieldCache{tagKey: headerTagKey, canonical: http.CanonicalHeaderKey}
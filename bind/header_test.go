@@ -0,0 +1,48 @@
+package bind
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderShouldLookupUsingTag(t *testing.T) {
+	var v struct {
+		RequestID string `header:"x-request-id"`
+		Retries   int    `header:"X-Retries"`
+	}
+	h := http.Header{}
+	h.Set("X-Request-Id", "abc")
+	h.Set("X-Retries", "3")
+
+	require.NoError(t, Header(&v, h))
+	assert.Equal(t, "abc", v.RequestID)
+	assert.Equal(t, 3, v.Retries)
+}
+
+func TestHeaderShouldLookupByFieldName(t *testing.T) {
+	var v struct {
+		Authorization string
+		Accept        []string
+	}
+	h := http.Header{}
+	h.Set("Authorization", "Bearer token")
+	h.Add("Accept", "text/html")
+	h.Add("Accept", "application/json")
+
+	require.NoError(t, Header(&v, h))
+	assert.Equal(t, "Bearer token", v.Authorization)
+	assert.Equal(t, []string{"text/html", "application/json"}, v.Accept)
+}
+
+func TestHeaderShouldErrorOnTypeMismatch(t *testing.T) {
+	var v struct {
+		Retries int `header:"X-Retries"`
+	}
+	h := http.Header{}
+	h.Set("X-Retries", "three")
+
+	assert.IsType(t, &TypeMismatchError{}, Header(&v, h))
+}
@@ -0,0 +1,88 @@
+package bind
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// The Parse funcs convert a string value of the field named fieldName to kind. They
+// return the same *TypeMismatchError as the reflective binders so that binders generated
+// by cmd/boarbind behave exactly like Query and Params
+
+// ParseBool parses val as a bool
+func ParseBool(fieldName, val string) (bool, error) {
+	v, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, &TypeMismatchError{Kind: reflect.Bool, Val: val, Cause: err, FieldName: fieldName}
+	}
+	return v, nil
+}
+
+// ParseInt parses val as a signed integer which must fit in kind
+func ParseInt(fieldName, val string, kind reflect.Kind) (int64, error) {
+	v, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || !fitsInt(v, bitSize(kind)) {
+		return 0, &TypeMismatchError{Kind: kind, Val: val, Cause: err, FieldName: fieldName}
+	}
+	return v, nil
+}
+
+// ParseUint parses val as an unsigned integer which must fit in kind
+func ParseUint(fieldName, val string, kind reflect.Kind) (uint64, error) {
+	v, err := strconv.ParseUint(val, 10, 64)
+	if err != nil || !fitsUint(v, bitSize(kind)) {
+		return 0, &TypeMismatchError{Kind: kind, Val: val, Cause: err, FieldName: fieldName}
+	}
+	return v, nil
+}
+
+// ParseFloat parses val as a float which must fit in kind
+func ParseFloat(fieldName, val string, kind reflect.Kind) (float64, error) {
+	v, err := strconv.ParseFloat(val, 64)
+	if err != nil || (kind == reflect.Float32 && overflowsFloat32(v)) {
+		return 0, &TypeMismatchError{Kind: kind, Val: val, Cause: err, FieldName: fieldName}
+	}
+	return v, nil
+}
+
+// MultipleValuesError is the error of a field which is not a slice but was given more than
+// one value
+func MultipleValuesError(fieldName string, kind reflect.Kind, vals []string) error {
+	return &TypeMismatchError{
+		Cause:     errMultiValueSimpleField,
+		FieldName: fieldName,
+		Kind:      kind,
+		Val:       vals,
+	}
+}
+
+func bitSize(kind reflect.Kind) uint {
+	switch kind {
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32:
+		return 32
+	case reflect.Int, reflect.Uint:
+		return strconv.IntSize
+	}
+	return 64
+}
+
+func fitsInt(v int64, bits uint) bool {
+	trunc := (v << (64 - bits)) >> (64 - bits)
+	return v == trunc
+}
+
+func fitsUint(v uint64, bits uint) bool {
+	trunc := (v << (64 - bits)) >> (64 - bits)
+	return v == trunc
+}
+
+// overflowsFloat32 matches reflect.Value.OverflowFloat for float32 values
+func overflowsFloat32(v float64) bool {
+	v = math.Abs(v)
+	return math.MaxFloat32 < v && v <= math.MaxFloat64
+}
@@ -0,0 +1,62 @@
+package bind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIntShouldCheckOverflowOfKind(t *testing.T) {
+	v, err := ParseInt("Age", "127", reflect.Int8)
+	require.NoError(t, err)
+	assert.Equal(t, int64(127), v)
+
+	_, err = ParseInt("Age", "128", reflect.Int8)
+	assert.Equal(t, &TypeMismatchError{Kind: reflect.Int8, Val: "128", FieldName: "Age"}, err)
+
+	_, err = ParseInt("Age", "-129", reflect.Int8)
+	assert.Error(t, err)
+}
+
+func TestParseUintShouldCheckOverflowOfKind(t *testing.T) {
+	v, err := ParseUint("Count", "65535", reflect.Uint16)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(65535), v)
+
+	_, err = ParseUint("Count", "65536", reflect.Uint16)
+	assert.Error(t, err)
+
+	_, err = ParseUint("Count", "-1", reflect.Uint)
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
+
+func TestParseFloatShouldCheckOverflowOfKind(t *testing.T) {
+	_, err := ParseFloat("Money", "1e39", reflect.Float32)
+	assert.Error(t, err)
+
+	v, err := ParseFloat("Money", "1e39", reflect.Float64)
+	require.NoError(t, err)
+	assert.Equal(t, 1e39, v)
+}
+
+func TestParseBoolShouldReturnTypeMismatch(t *testing.T) {
+	v, err := ParseBool("Active", "t")
+	require.NoError(t, err)
+	assert.True(t, v)
+
+	_, err = ParseBool("Active", "yes")
+	tme, ok := err.(*TypeMismatchError)
+	require.True(t, ok)
+	assert.Equal(t, reflect.Bool, tme.Kind)
+}
+
+func TestMultipleValuesErrorShouldMatchQuery(t *testing.T) {
+	var qp struct {
+		Name string
+	}
+	err := Query(&qp, map[string][]string{"Name": {"a", "b"}})
+
+	assert.Equal(t, err, MultipleValuesError("Name", reflect.String, []string{"a", "b"}))
+}
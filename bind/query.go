@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 var (
@@ -23,38 +24,29 @@ func Query(v interface{}, q url.Values) error {
 
 // QueryValue parses query parameters from the http.Request and injects them into v
 func QueryValue(obj reflect.Value, q url.Values) error {
-	kind := obj.Type()
-
-	for i := 0; i < obj.NumField(); i++ {
-		field := obj.Field(i)
-		if !field.CanSet() {
-			continue
-		}
-
-		tField := kind.Field(i)
-
-		kind := tField.Type.Kind()
-		if kind == reflect.Array {
-			return errUseSlice
-		}
+	return valuesValue(obj, queryFields.fields(obj.Type()), q)
+}
 
-		queryKey := tField.Name
-		if tag, ok := tField.Tag.Lookup(queryTagKey); ok {
-			queryKey = tag
+// valuesValue injects values into the fields of obj. Fields are looked up by the name in
+// their tag or by their field name
+func valuesValue(obj reflect.Value, fields []valuesField, values map[string][]string) error {
+	for i := range fields {
+		f := &fields[i]
+		if f.err != nil {
+			return f.err
 		}
-
-		if queryKey == "-" {
+		field := obj.Field(f.index)
+		if !field.CanSet() {
 			continue
 		}
 
-		vals := q[queryKey]
-
+		vals := values[f.key]
 		if len(vals) == 0 {
 			continue
 		}
 
-		if kind == reflect.Slice {
-			if err := setFieldSlice(field, tField.Name, vals); err != nil {
+		if f.kind == reflect.Slice {
+			if err := setFieldSlice(field, f.name, f.elemKind, vals); err != nil {
 				return err
 			}
 			continue
@@ -62,23 +54,72 @@ func QueryValue(obj reflect.Value, q url.Values) error {
 
 		// simple fields cannot have multiple values
 		if len(vals) > 1 {
-			return &TypeMismatchError{
-				Cause:     errMultiValueSimpleField,
-				FieldName: tField.Name,
-				Kind:      kind,
-				Val:       vals,
-			}
+			return MultipleValuesError(f.name, f.kind, vals)
 		}
 
 		val := strings.TrimSpace(vals[0])
 		if val == "" {
 			continue
 		}
-		err := setSimpleField(field, queryKey, kind, val)
-		if err != nil {
+		if err := setSimpleField(field, f.key, f.kind, val); err != nil {
 			return err
 		}
-
 	}
 	return nil
 }
+
+// valuesField is a field bound by valuesValue
+type valuesField struct {
+	index    int
+	name     string
+	key      string
+	kind     reflect.Kind
+	elemKind reflect.Kind
+	// err is returned when the field is reached since it cannot be bound
+	err error
+}
+
+// fieldCache caches the fields of struct types so that binding does not look up the
+// fields and tags of a type for every request
+type fieldCache struct {
+	tagKey string
+	// canonical transforms the keys of fields, e.g. to canonical header keys
+	canonical func(string) string
+	m         sync.Map // map[reflect.Type][]valuesField
+}
+
+var queryFields = &fieldCache{tagKey: queryTagKey}
+
+func (c *fieldCache) fields(typ reflect.Type) []valuesField {
+	if fields, ok := c.m.Load(typ); ok {
+		return fields.([]valuesField)
+	}
+
+	fields := make([]valuesField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != "" {
+			// unexported fields cannot be set
+			continue
+		}
+		key := sf.Name
+		if tag, ok := sf.Tag.Lookup(c.tagKey); ok {
+			key = tag
+		}
+		f := valuesField{index: i, name: sf.Name, key: key, kind: sf.Type.Kind()}
+		switch {
+		case f.kind == reflect.Array:
+			f.err = errUseSlice
+		case key == "-":
+			continue
+		case f.kind == reflect.Slice:
+			f.elemKind = sf.Type.Elem().Kind()
+		}
+		if c.canonical != nil {
+			f.key = c.canonical(f.key)
+		}
+		fields = append(fields, f)
+	}
+	c.m.Store(typ, fields)
+	return fields
+}
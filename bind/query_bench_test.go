@@ -24,8 +24,13 @@ func BenchmarkQueryParsingWithAllParams(b *testing.B) {
 	qs = qs + "&Name=brettjones&Age=99&Money=12.12&&Address=1999%ssomeRoad&Debg=999.99"
 	r := httptest.NewRequest(http.MethodGet, "/"+qs, nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		// slices are appended to so they are reset to measure a single request
+		qp.Bools, qp.Nums = nil, nil
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -48,8 +53,11 @@ func BenchmarkQueryParsingWithAllParamsWithTags(b *testing.B) {
 	qs = qs + "&name=brettjones&age=99&money=12.12&&address=1999%ssomeroad&debg=999.99"
 	r := httptest.NewRequest(http.MethodGet, "/"+qs, nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -68,8 +76,11 @@ func BenchmarkQueryParsingWithSimpleParams(b *testing.B) {
 	qs := "?Name=brettjones&Age=99&Money=12.12&&Address=1999%ssomeRoad&Debg=999.99"
 	r := httptest.NewRequest(http.MethodGet, "/"+qs, nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -88,8 +99,11 @@ func BenchmarkQueryParsingWithSimpleParamsWithTags(b *testing.B) {
 	qs := "?Name=brettjones&Age=99&Money=12.12&&Address=1999%ssomeRoad&Debg=999.99"
 	r := httptest.NewRequest(http.MethodGet, "/"+qs, nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -103,8 +117,11 @@ func BenchmarkQueryParsingWithOneSimpleParam(b *testing.B) {
 
 	r := httptest.NewRequest(http.MethodGet, "/?Name=brett", nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -118,8 +135,12 @@ func BenchmarkQueryParsingWithOneSliceParam(b *testing.B) {
 
 	r := httptest.NewRequest(http.MethodGet, "/?Names=brett&Names=kristy&Names=jack&Names=jill", nil)
 
+	q := r.URL.Query()
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		err := bind.Query(&qp, r.URL.Query())
+		qp.Names = nil
+		err := bind.Query(&qp, q)
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -3,6 +3,7 @@ package bind
 import (
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -215,3 +216,48 @@ func TestParseErrorsTypeMismatchForBadSliceValues(t *testing.T) {
 	err := Query(&QueryParams, r.URL.Query())
 	assert.IsType(t, &TypeMismatchError{}, err)
 }
+
+func TestParseShouldAppendToSlices(t *testing.T) {
+	qp := struct {
+		Nums []int
+	}{Nums: []int{1}}
+
+	require.NoError(t, Query(&qp, map[string][]string{"Nums": {"2", "3"}}))
+	assert.Equal(t, []int{1, 2, 3}, qp.Nums)
+}
+
+func TestParseShouldCacheFieldsPerType(t *testing.T) {
+	type QueryParams struct {
+		Name string `query:"name"`
+	}
+
+	for _, name := range []string{"brett", "kristy"} {
+		var qp QueryParams
+		require.NoError(t, Query(&qp, map[string][]string{"name": {name}}))
+		assert.Equal(t, name, qp.Name)
+	}
+	// headers are bound by their own tag
+	var h struct {
+		Name string `query:"name" header:"x-name"`
+	}
+	require.NoError(t, Header(&h, http.Header{"X-Name": {"jack"}}))
+	assert.Equal(t, "jack", h.Name)
+}
+
+func TestParseShouldNotAllocateForSimpleFields(t *testing.T) {
+	var qp struct {
+		Name  string
+		Age   int
+		Money float64
+		Admin bool
+	}
+	q := map[string][]string{"Name": {"brett"}, "Age": {"99"}, "Money": {"1.5"}, "Admin": {"true"}}
+	v := reflect.ValueOf(&qp).Elem()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := QueryValue(v, q); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Equal(t, 0.0, allocs)
+}
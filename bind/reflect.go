@@ -5,25 +5,23 @@ package bind
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 )
 
-func setFieldSlice(field reflect.Value, fieldName string, vals []string) error {
-	len := len(vals)
-	if len == 0 {
+// setFieldSlice appends vals to the slice field. The slice is grown once for all of vals
+func setFieldSlice(field reflect.Value, fieldName string, elemKind reflect.Kind, vals []string) error {
+	if len(vals) == 0 {
 		return nil
 	}
-	fieldType := field.Type()
-	for _, v := range vals {
-		v = strings.TrimSpace(v)
-		elemType := fieldType.Elem()
-		fieldVal := reflect.New(elemType)
-		if err := setSimpleField(reflect.Indirect(fieldVal), fieldName, elemType.Kind(), v); err != nil {
+	n := field.Len()
+	slice := reflect.MakeSlice(field.Type(), n+len(vals), n+len(vals))
+	reflect.Copy(slice, field)
+	for i, v := range vals {
+		if err := setSimpleField(slice.Index(n+i), fieldName, elemKind, strings.TrimSpace(v)); err != nil {
 			return err
 		}
-		field.Set(reflect.Append(field, reflect.Indirect(fieldVal)))
 	}
+	field.Set(slice)
 	return nil
 }
 
@@ -31,59 +29,33 @@ func setSimpleField(f reflect.Value, fieldName string, kind reflect.Kind, val st
 	switch kind {
 	case reflect.String:
 		f.SetString(val)
-		return nil
 	case reflect.Bool:
-		v, err := strconv.ParseBool(val)
+		v, err := ParseBool(fieldName, val)
 		if err != nil {
-			return &TypeMismatchError{
-				Kind:      kind,
-				Val:       val,
-				Cause:     err,
-				FieldName: fieldName,
-			}
+			return err
 		}
 		f.SetBool(v)
-		break
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v, err := strconv.ParseInt(val, 10, 64)
-		if err != nil || f.OverflowInt(v) {
-			return &TypeMismatchError{
-				Kind:      kind,
-				Val:       val,
-				Cause:     err,
-				FieldName: fieldName,
-			}
+		v, err := ParseInt(fieldName, val, kind)
+		if err != nil {
+			return err
 		}
 		f.SetInt(v)
-		break
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v, err := strconv.ParseUint(val, 10, 64)
-		if err != nil || f.OverflowUint(v) {
-			return &TypeMismatchError{
-				Kind:      kind,
-				Val:       val,
-				Cause:     err,
-				FieldName: fieldName,
-			}
+		v, err := ParseUint(fieldName, val, kind)
+		if err != nil {
+			return err
 		}
 		f.SetUint(v)
-		break
 	case reflect.Float32, reflect.Float64:
-		v, err := strconv.ParseFloat(val, 64)
-		if err != nil || f.OverflowFloat(v) {
-			return &TypeMismatchError{
-				Kind:      kind,
-				Val:       val,
-				Cause:     err,
-				FieldName: fieldName,
-			}
+		v, err := ParseFloat(fieldName, val, kind)
+		if err != nil {
+			return err
 		}
 		f.SetFloat(v)
-		return nil
 	default:
 		return fmt.Errorf("%s is not a supported query parameter type", kind)
 	}
-
 	return nil
 }
 
@@ -13,14 +13,14 @@ import (
 func TestSetFieldSliceShouldDoNothingWhenValsEmpty(t *testing.T) {
 	var slice []string
 	field := reflect.Indirect(reflect.ValueOf(&slice))
-	err := setFieldSlice(field, "", make([]string, 0))
+	err := setFieldSlice(field, "", reflect.String, make([]string, 0))
 	assert.NoError(t, err)
 }
 
 func TestSetFieldSliceShouldCreateSlice(t *testing.T) {
 	var slice []int
 	field := reflect.Indirect(reflect.ValueOf(&slice))
-	err := setFieldSlice(field, "", []string{"1", "2"})
+	err := setFieldSlice(field, "", reflect.Int, []string{"1", "2"})
 	assert.NoError(t, err)
 	assert.Len(t, slice, 2)
 }
@@ -28,7 +28,7 @@ func TestSetFieldSliceShouldCreateSlice(t *testing.T) {
 func TestSetFieldSliceShouldErrorOnComplexFieldTypes(t *testing.T) {
 	var slice []func()
 	field := reflect.Indirect(reflect.ValueOf(&slice))
-	err := setFieldSlice(field, "", []string{"1", "2"})
+	err := setFieldSlice(field, "", reflect.Func, []string{"1", "2"})
 	assert.Error(t, err)
 }
 
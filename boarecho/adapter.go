@@ -0,0 +1,92 @@
+// Package boarecho runs echo handlers on a boar Router so that an application can move
+// its routes from echo to boar one at a time. Each request is served with an echo.Context
+// which reads the request, URL parameters, and route path of the boar Context and writes
+// to its response, so handlers keep working without changes until they are rewritten.
+//
+// Errors returned by handlers are converted to boar errors. An *echo.HTTPError becomes a
+// boar.HTTPError with the same status so that the Router's error handler writes it.
+//
+// Example:
+//
+//	rtr := boar.NewRouter()
+//	rtr.MethodFunc(http.MethodGet, "/users/:id", boarecho.Wrap(getUser))
+package boarecho
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/blockloop/boar"
+	"github.com/labstack/echo/v4"
+)
+
+// contextKey is the key of the boar Context in the store of the echo.Context
+const contextKey = "boarecho.context"
+
+// Adapter serves echo handlers with the Binder, Validator, Renderer, and other settings
+// of an Echo instance
+type Adapter struct {
+	echo *echo.Echo
+}
+
+// New creates an Adapter which serves handlers with the settings of e
+func New(e *echo.Echo) *Adapter {
+	if e == nil {
+		panic("boarecho requires an *echo.Echo")
+	}
+	return &Adapter{echo: e}
+}
+
+var defaultAdapter = New(echo.New())
+
+// Wrap converts h to a boar.HandlerFunc using an Echo instance with the default settings.
+// Use New to serve handlers which depend on the settings of an Echo instance
+func Wrap(h echo.HandlerFunc) boar.HandlerFunc {
+	return defaultAdapter.Wrap(h)
+}
+
+// Wrap converts h to a boar.HandlerFunc
+func (a *Adapter) Wrap(h echo.HandlerFunc) boar.HandlerFunc {
+	if h == nil {
+		panic("boarecho requires an echo.HandlerFunc")
+	}
+	return func(c boar.Context) error {
+		ec := a.echo.AcquireContext()
+		defer a.echo.ReleaseContext(ec)
+		ec.Reset(c.Request(), c.Response())
+		ec.SetPath(c.RoutePattern())
+
+		params := c.URLParams()
+		names := make([]string, len(params))
+		values := make([]string, len(params))
+		for i, p := range params {
+			names[i], values[i] = p.Key, p.Value
+		}
+		ec.SetParamNames(names...)
+		ec.SetParamValues(values...)
+		ec.Set(contextKey, c)
+
+		return fromEchoError(h(ec))
+	}
+}
+
+// Context returns the boar Context which ec was created from so that handlers can use
+// boar while they are migrated
+func Context(ec echo.Context) (boar.Context, bool) {
+	c, ok := ec.Get(contextKey).(boar.Context)
+	return c, ok
+}
+
+// fromEchoError converts an *echo.HTTPError returned by a handler to a boar.HTTPError.
+// Other errors are returned as is
+func fromEchoError(err error) error {
+	var he *echo.HTTPError
+	if err == nil || !errors.As(err, &he) {
+		return err
+	}
+	if he.Message == nil || he.Message == http.StatusText(he.Code) {
+		return boar.NewHTTPErrorStatus(he.Code)
+	}
+	return boar.NewHTTPError(he.Code, errors.New(fmt.Sprint(he.Message)))
+}
@@ -0,0 +1,123 @@
+package boarecho
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(rtr *boar.Router, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("content-type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	rtr.ServeHTTP(w, req)
+	return w
+}
+
+func TestWrapShouldServeEchoHandlers(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", Wrap(func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{
+			"id":   c.Param("id"),
+			"tab":  c.QueryParam("tab"),
+			"path": c.Path(),
+		})
+	}))
+
+	w := serve(rtr, http.MethodGet, "/users/42?tab=posts", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "42", "tab": "posts", "path": "/users/:id"}`, w.Body.String())
+}
+
+func TestWrapShouldBindRequestBodies(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodPost, "/users", Wrap(func(c echo.Context) error {
+		var user struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&user); err != nil {
+			return err
+		}
+		return c.String(http.StatusCreated, "created "+user.Name)
+	}))
+
+	w := serve(rtr, http.MethodPost, "/users", `{"name": "ada"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "created ada", w.Body.String())
+
+	w = serve(rtr, http.MethodPost, "/users", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestWrapShouldConvertEchoHTTPErrors(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/missing", Wrap(func(c echo.Context) error {
+		return echo.ErrNotFound
+	}))
+	rtr.MethodFunc(http.MethodGet, "/forbidden", Wrap(func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "not your account")
+	}))
+
+	w := serve(rtr, http.MethodGet, "/missing", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(rtr, http.MethodGet, "/forbidden", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error": "not your account"}`, w.Body.String())
+}
+
+func TestFromEchoErrorShouldKeepOtherErrors(t *testing.T) {
+	err := errors.New("boom")
+	assert.Equal(t, err, fromEchoError(err))
+	assert.NoError(t, fromEchoError(nil))
+
+	herr := boar.NewHTTPError(http.StatusConflict, err)
+	assert.Equal(t, herr, fromEchoError(herr))
+	assert.True(t, errors.Is(fromEchoError(echo.ErrUnauthorized), boar.ErrUnauthorized))
+}
+
+func TestContextShouldReturnTheBoarContext(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", Wrap(func(ec echo.Context) error {
+		c, ok := Context(ec)
+		require.True(t, ok)
+		return c.WriteJSON(http.StatusOK, boar.JSON{"id": c.URLParams().ByName("id")})
+	}))
+
+	w := serve(rtr, http.MethodGet, "/users/7", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "7"}`, w.Body.String())
+}
+
+type rejectValidator struct{}
+
+func (rejectValidator) Validate(i interface{}) error {
+	return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid")
+}
+
+func TestAdapterShouldUseTheSettingsOfTheEchoInstance(t *testing.T) {
+	e := echo.New()
+	e.Validator = rejectValidator{}
+
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/", New(e).Wrap(func(c echo.Context) error {
+		return c.Validate(struct{}{})
+	}))
+
+	w := serve(rtr, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestNewShouldPanicWithoutEcho(t *testing.T) {
+	assert.Panics(t, func() { New(nil) })
+	assert.Panics(t, func() { Wrap(nil) })
+}
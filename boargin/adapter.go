@@ -0,0 +1,98 @@
+// Package boargin runs gin handlers on a boar Router so that an application can move its
+// routes from gin to boar one at a time. Each request is served with a *gin.Context which
+// reads the request and URL parameters of the boar Context and writes to its response, so
+// handlers keep working without changes until they are rewritten.
+//
+// The last error attached with Context.Error or Context.AbortWithError is returned to the
+// Router. When the handler aborted with a status of 400 or above the error becomes a
+// boar.HTTPError with that status, so the Router's error handler writes it unless the
+// handler already wrote a body.
+//
+// Example:
+//
+//	rtr := boar.NewRouter()
+//	rtr.MethodFunc(http.MethodGet, "/users/:id", boargin.Wrap(getUser))
+package boargin
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/blockloop/boar"
+	"github.com/gin-gonic/gin"
+)
+
+// contextKey is the key of the boar Context in the Keys of the *gin.Context
+type contextKey struct{}
+
+// Adapter serves gin handlers with the HTML templates, trusted proxies, and other
+// settings of an Engine
+type Adapter struct {
+	engine *gin.Engine
+}
+
+// New creates an Adapter which serves handlers with the settings of engine
+func New(engine *gin.Engine) *Adapter {
+	if engine == nil {
+		panic("boargin requires a *gin.Engine")
+	}
+	return &Adapter{engine: engine}
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultAdapter *Adapter
+)
+
+// Wrap converts h to a boar.HandlerFunc using an Engine with the default settings. Use New
+// to serve handlers which depend on the settings of an Engine
+func Wrap(h gin.HandlerFunc) boar.HandlerFunc {
+	// the Engine is created on first use since gin prints a warning for every new Engine
+	// in debug mode
+	defaultOnce.Do(func() {
+		defaultAdapter = New(gin.New())
+	})
+	return defaultAdapter.Wrap(h)
+}
+
+// Wrap converts h to a boar.HandlerFunc
+func (a *Adapter) Wrap(h gin.HandlerFunc) boar.HandlerFunc {
+	if h == nil {
+		panic("boargin requires a gin.HandlerFunc")
+	}
+	return func(c boar.Context) error {
+		gc := gin.CreateTestContextOnly(c.Response(), a.engine)
+		gc.Request = c.Request()
+		for _, p := range c.URLParams() {
+			gc.Params = append(gc.Params, gin.Param{Key: p.Key, Value: p.Value})
+		}
+		gc.Set(contextKey{}, c)
+
+		h(gc)
+		// gin holds back a status set with Context.Status until the engine sends it
+		gc.Writer.WriteHeaderNow()
+
+		last := gc.Errors.Last()
+		if last == nil {
+			return nil
+		}
+		if _, ok := last.Err.(boar.HTTPError); ok {
+			return last.Err
+		}
+		if status := gc.Writer.Status(); status >= http.StatusBadRequest {
+			return boar.NewHTTPError(status, last.Err)
+		}
+		return last.Err
+	}
+}
+
+// Context returns the boar Context which gc was created from so that handlers can use
+// boar while they are migrated
+func Context(gc *gin.Context) (boar.Context, bool) {
+	v, ok := gc.Get(contextKey{})
+	if !ok {
+		return nil, false
+	}
+	c, ok := v.(boar.Context)
+	return c, ok
+}
@@ -0,0 +1,155 @@
+package boargin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func serve(rtr *boar.Router, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("content-type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	rtr.ServeHTTP(w, req)
+	return w
+}
+
+func TestWrapShouldServeGinHandlers(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", Wrap(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"id":  c.Param("id"),
+			"tab": c.Query("tab"),
+		})
+	}))
+
+	w := serve(rtr, http.MethodGet, "/users/42?tab=posts", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "42", "tab": "posts"}`, w.Body.String())
+}
+
+func TestWrapShouldBindRequestBodies(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodPost, "/users", Wrap(func(c *gin.Context) {
+		var user struct {
+			Name string `json:"name" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&user); err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		c.String(http.StatusCreated, "created %s", user.Name)
+	}))
+
+	w := serve(rtr, http.MethodPost, "/users", `{"name": "ada"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "created ada", w.Body.String())
+
+	w = serve(rtr, http.MethodPost, "/users", `{}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "required")
+}
+
+func TestWrapShouldSendStatusesWithoutBodies(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodDelete, "/users/:id", Wrap(func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	}))
+	rtr.MethodFunc(http.MethodGet, "/admin", Wrap(func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}))
+
+	w := serve(rtr, http.MethodDelete, "/users/1", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = serve(rtr, http.MethodGet, "/admin", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestWrapShouldReturnErrorsToTheRouter(t *testing.T) {
+	boom := errors.New("boom")
+	var reported []error
+	rtr := boar.NewRouter()
+	rtr.OnError(func(c boar.Context, err error) {
+		reported = append(reported, err)
+	})
+	rtr.MethodFunc(http.MethodGet, "/fail", Wrap(func(c *gin.Context) {
+		c.Error(boom)
+	}))
+	rtr.MethodFunc(http.MethodGet, "/handled", Wrap(func(c *gin.Context) {
+		c.Error(boom)
+		c.JSON(http.StatusBadGateway, gin.H{"message": "upstream failed"})
+	}))
+	rtr.MethodFunc(http.MethodGet, "/gone", Wrap(func(c *gin.Context) {
+		c.Error(boar.ErrGone)
+	}))
+
+	w := serve(rtr, http.MethodGet, "/fail", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = serve(rtr, http.MethodGet, "/handled", "")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"message": "upstream failed"}`, w.Body.String())
+
+	w = serve(rtr, http.MethodGet, "/gone", "")
+	assert.Equal(t, http.StatusGone, w.Code)
+
+	require.Len(t, reported, 3)
+	assert.True(t, errors.Is(reported[0], boom))
+	assert.True(t, errors.Is(reported[1], boom))
+	assert.True(t, errors.Is(reported[2], boar.ErrGone))
+}
+
+func TestContextShouldReturnTheBoarContext(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", Wrap(func(gc *gin.Context) {
+		c, ok := Context(gc)
+		require.True(t, ok)
+		c.WriteJSON(http.StatusOK, boar.JSON{"id": c.URLParams().ByName("id")})
+	}))
+
+	w := serve(rtr, http.MethodGet, "/users/7", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id": "7"}`, w.Body.String())
+
+	_, ok := Context(&gin.Context{})
+	assert.False(t, ok)
+}
+
+func TestAdapterShouldUseTheSettingsOfTheEngine(t *testing.T) {
+	engine := gin.New()
+	engine.ForwardedByClientIP = true
+	engine.RemoteIPHeaders = []string{"X-Real-Ip"}
+	require.NoError(t, engine.SetTrustedProxies([]string{"192.0.2.1"}))
+
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/ip", New(engine).Wrap(func(c *gin.Context) {
+		c.String(http.StatusOK, c.ClientIP())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Real-Ip", "198.51.100.7")
+	w := httptest.NewRecorder()
+	rtr.ServeHTTP(w, req)
+	assert.Equal(t, "198.51.100.7", w.Body.String())
+}
+
+func TestNewShouldPanicWithoutEngine(t *testing.T) {
+	assert.Panics(t, func() { New(nil) })
+	assert.Panics(t, func() { Wrap(nil) })
+}
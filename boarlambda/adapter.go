@@ -0,0 +1,286 @@
+// Package boarlambda runs a boar Router, or any other http.Handler, on AWS Lambda behind
+// API Gateway. Events of REST APIs and of HTTP APIs using payload format version 1.0 or
+// 2.0 are converted into requests and the responses are converted back into Lambda proxy
+// responses, so the same Router can run in a server and serverless without changes.
+//
+// The Adapter implements the Handler interface of github.com/aws/aws-lambda-go/lambda.
+//
+// Example:
+//
+//	func main() {
+//		rtr := boar.NewRouter()
+//		rtr.Get("/users/:id", getUser)
+//		lambda.StartHandler(boarlambda.New(rtr))
+//	}
+package boarlambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Adapter serves API Gateway events with an http.Handler
+type Adapter struct {
+	handler http.Handler
+}
+
+// New creates an Adapter which serves events with h
+func New(h http.Handler) *Adapter {
+	if h == nil {
+		panic("boarlambda requires an http.Handler")
+	}
+	return &Adapter{handler: h}
+}
+
+type eventKey struct{}
+
+// ProxyRequest returns the APIGatewayProxyRequest which r was created from
+func ProxyRequest(r *http.Request) (*APIGatewayProxyRequest, bool) {
+	e, ok := r.Context().Value(eventKey{}).(*APIGatewayProxyRequest)
+	return e, ok
+}
+
+// V2HTTPRequest returns the APIGatewayV2HTTPRequest which r was created from
+func V2HTTPRequest(r *http.Request) (*APIGatewayV2HTTPRequest, bool) {
+	e, ok := r.Context().Value(eventKey{}).(*APIGatewayV2HTTPRequest)
+	return e, ok
+}
+
+// Invoke serves the JSON encoded event payload and returns the JSON encoded response. The
+// payload format version is detected from the event
+func (a *Adapter) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	var probe struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return nil, fmt.Errorf("could not decode event: %+v", err)
+	}
+
+	if probe.Version == "2.0" {
+		var e APIGatewayV2HTTPRequest
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return nil, fmt.Errorf("could not decode event: %+v", err)
+		}
+		resp, err := a.ServeV2HTTP(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(resp)
+	}
+
+	var e APIGatewayProxyRequest
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return nil, fmt.Errorf("could not decode event: %+v", err)
+	}
+	resp, err := a.ServeProxy(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resp)
+}
+
+// ServeProxy serves an event of a REST API or of an HTTP API using payload format
+// version 1.0
+func (a *Adapter) ServeProxy(ctx context.Context, e APIGatewayProxyRequest) (APIGatewayProxyResponse, error) {
+	query := url.Values(e.MultiValueQueryStringParameters)
+	if len(query) == 0 {
+		query = make(url.Values, len(e.QueryStringParameters))
+		for k, v := range e.QueryStringParameters {
+			query.Set(k, v)
+		}
+	}
+	u := &url.URL{Path: e.Path, RawQuery: query.Encode()}
+
+	header := make(http.Header, len(e.Headers))
+	if len(e.MultiValueHeaders) > 0 {
+		for k, vs := range e.MultiValueHeaders {
+			for _, v := range vs {
+				header.Add(k, v)
+			}
+		}
+	} else {
+		for k, v := range e.Headers {
+			header.Set(k, v)
+		}
+	}
+
+	ctx = context.WithValue(ctx, eventKey{}, &e)
+	r, err := newRequest(ctx, e.HTTPMethod, u, header, e.Body, e.IsBase64Encoded)
+	if err != nil {
+		return APIGatewayProxyResponse{}, err
+	}
+	setHost(r, e.RequestContext.DomainName)
+	r.RemoteAddr = e.RequestContext.Identity.SourceIP
+
+	w := newResponseWriter()
+	a.handler.ServeHTTP(w, r)
+
+	body, encoded := w.encodedBody()
+	return APIGatewayProxyResponse{
+		StatusCode:        w.status,
+		MultiValueHeaders: w.header,
+		Body:              body,
+		IsBase64Encoded:   encoded,
+	}, nil
+}
+
+// ServeV2HTTP serves an event of an HTTP API using payload format version 2.0 or of a
+// Lambda function URL
+func (a *Adapter) ServeV2HTTP(ctx context.Context, e APIGatewayV2HTTPRequest) (APIGatewayV2HTTPResponse, error) {
+	path := e.RawPath
+	if path == "" {
+		path = e.RequestContext.HTTP.Path
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return APIGatewayV2HTTPResponse{}, fmt.Errorf("could not parse path %q: %+v", path, err)
+	}
+	u.RawQuery = e.RawQueryString
+
+	header := make(http.Header, len(e.Headers)+1)
+	for k, v := range e.Headers {
+		header.Set(k, v)
+	}
+	if len(e.Cookies) > 0 {
+		header.Set("Cookie", strings.Join(e.Cookies, "; "))
+	}
+
+	ctx = context.WithValue(ctx, eventKey{}, &e)
+	r, err := newRequest(ctx, e.RequestContext.HTTP.Method, u, header, e.Body, e.IsBase64Encoded)
+	if err != nil {
+		return APIGatewayV2HTTPResponse{}, err
+	}
+	setHost(r, e.RequestContext.DomainName)
+	r.RemoteAddr = e.RequestContext.HTTP.SourceIP
+
+	w := newResponseWriter()
+	a.handler.ServeHTTP(w, r)
+
+	body, encoded := w.encodedBody()
+	resp := APIGatewayV2HTTPResponse{
+		StatusCode:      w.status,
+		Headers:         make(map[string]string, len(w.header)),
+		Body:            body,
+		IsBase64Encoded: encoded,
+	}
+	for k, vs := range w.header {
+		if k == "Set-Cookie" {
+			resp.Cookies = vs
+			continue
+		}
+		resp.Headers[k] = strings.Join(vs, ", ")
+	}
+	return resp, nil
+}
+
+func newRequest(ctx context.Context, method string, u *url.URL, header http.Header, body string, encoded bool) (*http.Request, error) {
+	b := []byte(body)
+	if encoded {
+		var err error
+		if b, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, fmt.Errorf("could not decode body: %+v", err)
+		}
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %+v", err)
+	}
+	r.Header = header
+	r.RequestURI = u.RequestURI()
+	r.Proto, r.ProtoMajor, r.ProtoMinor = "HTTP/1.1", 1, 1
+	return r, nil
+}
+
+// setHost sets the host of r to its Host header, or to the domain name of the API when
+// the event does not have one
+func setHost(r *http.Request, domainName string) {
+	host := r.Header.Get("Host")
+	if host == "" {
+		host = domainName
+	}
+	r.Host = host
+	r.URL.Host = host
+}
+
+// responseWriter buffers the response of the handler for a Lambda proxy response
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+var (
+	_ http.Flusher    = (*responseWriter)(nil)
+	_ io.StringWriter = (*responseWriter)(nil)
+)
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: make(http.Header)}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= http.StatusOK {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.WriteString(s)
+}
+
+// Flush does nothing because Lambda proxy responses cannot be streamed
+func (w *responseWriter) Flush() {}
+
+// encodedBody returns the body of the response and whether it had to be base64 encoded
+// because it is binary
+func (w *responseWriter) encodedBody() (string, bool) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	b := w.body.Bytes()
+	if w.header.Get("Content-Encoding") == "" && isText(w.header.Get("Content-Type")) && utf8.Valid(b) {
+		return string(b), false
+	}
+	return base64.StdEncoding.EncodeToString(b), true
+}
+
+// isText reports whether responses with the content type are text
+func isText(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript",
+		"application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
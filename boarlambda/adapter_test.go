@@ -0,0 +1,224 @@
+package boarlambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter() *boar.Router {
+	rtr := boar.NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/users/:id", func(c boar.Context) error {
+		return c.WriteJSON(http.StatusOK, map[string]interface{}{
+			"id":   c.URLParams().ByName("id"),
+			"tags": c.Request().URL.Query()["tag"],
+			"ip":   c.ClientIP(),
+			"host": c.Request().Host,
+			"auth": c.Request().Header.Get("Authorization"),
+		})
+	})
+	rtr.MethodFunc(http.MethodPost, "/echo", func(c boar.Context) error {
+		b, err := ioutil.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		http.SetCookie(c.Response(), &http.Cookie{Name: "a", Value: "1"})
+		http.SetCookie(c.Response(), &http.Cookie{Name: "b", Value: "2"})
+		return c.Write(http.StatusCreated, "application/octet-stream", b)
+	})
+	return rtr
+}
+
+func TestServeProxy(t *testing.T) {
+	resp, err := New(newRouter()).ServeProxy(context.Background(), APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/users/42",
+		MultiValueHeaders: map[string][]string{
+			"host":          {"api.example.com"},
+			"authorization": {"Bearer token"},
+		},
+		MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+		RequestContext: APIGatewayProxyRequestContext{
+			Identity: APIGatewayIdentity{SourceIP: "203.0.113.7"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, resp.IsBase64Encoded)
+	assert.Equal(t, []string{"application/json"}, resp.MultiValueHeaders["Content-Type"])
+	assert.JSONEq(t, `{
+		"id": "42",
+		"tags": ["a", "b"],
+		"ip": "203.0.113.7",
+		"host": "api.example.com",
+		"auth": "Bearer token"
+	}`, resp.Body)
+}
+
+func TestServeProxyUsesSingleValueParameters(t *testing.T) {
+	resp, err := New(newRouter()).ServeProxy(context.Background(), APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Path:                  "/users/42",
+		Headers:               map[string]string{"Authorization": "Bearer token"},
+		QueryStringParameters: map[string]string{"tag": "a"},
+		RequestContext:        APIGatewayProxyRequestContext{DomainName: "abc.execute-api.aws.com"},
+	})
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resp.Body), &body))
+	assert.Equal(t, []interface{}{"a"}, body["tags"])
+	assert.Equal(t, "Bearer token", body["auth"])
+	assert.Equal(t, "abc.execute-api.aws.com", body["host"])
+}
+
+func TestServeProxyEncodesBinaryBodies(t *testing.T) {
+	data := []byte{0xff, 0x00, 0xfe}
+	resp, err := New(newRouter()).ServeProxy(context.Background(), APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Path:            "/echo",
+		Body:            base64.StdEncoding.EncodeToString(data),
+		IsBase64Encoded: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.True(t, resp.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(data), resp.Body)
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+}
+
+func TestServeProxyReturnsErrorForInvalidBody(t *testing.T) {
+	_, err := New(newRouter()).ServeProxy(context.Background(), APIGatewayProxyRequest{
+		HTTPMethod:      http.MethodPost,
+		Path:            "/echo",
+		Body:            "not base64!",
+		IsBase64Encoded: true,
+	})
+	assert.Error(t, err)
+}
+
+func TestServeV2HTTP(t *testing.T) {
+	e := APIGatewayV2HTTPRequest{
+		Version:        "2.0",
+		RawPath:        "/users/42",
+		RawQueryString: "tag=a&tag=b",
+		Headers:        map[string]string{"authorization": "Bearer token"},
+		RequestContext: APIGatewayV2HTTPRequestContext{
+			DomainName: "abc.lambda-url.aws.com",
+			HTTP: APIGatewayV2HTTPRequestContextHTTP{
+				Method:   http.MethodGet,
+				SourceIP: "203.0.113.7",
+			},
+		},
+	}
+	resp, err := New(newRouter()).ServeV2HTTP(context.Background(), e)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+	assert.JSONEq(t, `{
+		"id": "42",
+		"tags": ["a", "b"],
+		"ip": "203.0.113.7",
+		"host": "abc.lambda-url.aws.com",
+		"auth": "Bearer token"
+	}`, resp.Body)
+}
+
+func TestServeV2HTTPSendsCookiesSeparately(t *testing.T) {
+	resp, err := New(newRouter()).ServeV2HTTP(context.Background(), APIGatewayV2HTTPRequest{
+		Version: "2.0",
+		RawPath: "/echo",
+		Body:    "hello",
+		RequestContext: APIGatewayV2HTTPRequestContext{
+			HTTP: APIGatewayV2HTTPRequestContextHTTP{Method: http.MethodPost},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.Cookies)
+	assert.NotContains(t, resp.Headers, "Set-Cookie")
+	assert.True(t, resp.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("hello")), resp.Body)
+}
+
+func TestEventAccessors(t *testing.T) {
+	var v1, v2 bool
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, v1 = ProxyRequest(r)
+		_, v2 = V2HTTPRequest(r)
+	})
+
+	_, err := New(h).ServeProxy(context.Background(), APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/"})
+	require.NoError(t, err)
+	assert.True(t, v1)
+	assert.False(t, v2)
+
+	_, err = New(h).ServeV2HTTP(context.Background(), APIGatewayV2HTTPRequest{Version: "2.0", RawPath: "/"})
+	require.NoError(t, err)
+	assert.False(t, v1)
+	assert.True(t, v2)
+}
+
+func TestInvokeDetectsPayloadVersion(t *testing.T) {
+	a := New(newRouter())
+
+	out, err := a.Invoke(context.Background(), []byte(`{
+		"httpMethod": "GET",
+		"path": "/users/1"
+	}`))
+	require.NoError(t, err)
+	var v1 APIGatewayProxyResponse
+	require.NoError(t, json.Unmarshal(out, &v1))
+	assert.Equal(t, http.StatusOK, v1.StatusCode)
+	assert.Contains(t, v1.MultiValueHeaders, "Content-Type")
+
+	out, err = a.Invoke(context.Background(), []byte(`{
+		"version": "2.0",
+		"rawPath": "/users/1",
+		"requestContext": {"http": {"method": "GET"}}
+	}`))
+	require.NoError(t, err)
+	var v2 APIGatewayV2HTTPResponse
+	require.NoError(t, json.Unmarshal(out, &v2))
+	assert.Equal(t, http.StatusOK, v2.StatusCode)
+	assert.Equal(t, "application/json", v2.Headers["Content-Type"])
+}
+
+func TestInvokeReturnsErrorForInvalidPayload(t *testing.T) {
+	_, err := New(newRouter()).Invoke(context.Background(), []byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestNotFoundIsServedByTheRouter(t *testing.T) {
+	resp, err := New(newRouter()).ServeProxy(context.Background(), APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/missing",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestIsText(t *testing.T) {
+	for ct, want := range map[string]bool{
+		"":                         true,
+		"text/html; charset=utf-8": true,
+		"application/json":         true,
+		"application/problem+json": true,
+		"image/svg+xml":            true,
+		"application/octet-stream": false,
+		"image/png":                false,
+		"not a media type;;":       false,
+	} {
+		assert.Equal(t, want, isText(ct), ct)
+	}
+}
@@ -0,0 +1,95 @@
+package boarlambda
+
+// APIGatewayProxyRequest is the event sent by API Gateway REST APIs and by HTTP APIs using
+// payload format version 1.0
+type APIGatewayProxyRequest struct {
+	Version                         string                        `json:"version,omitempty"`
+	Resource                        string                        `json:"resource"`
+	Path                            string                        `json:"path"`
+	HTTPMethod                      string                        `json:"httpMethod"`
+	Headers                         map[string]string             `json:"headers"`
+	MultiValueHeaders               map[string][]string           `json:"multiValueHeaders"`
+	QueryStringParameters           map[string]string             `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string           `json:"multiValueQueryStringParameters"`
+	PathParameters                  map[string]string             `json:"pathParameters"`
+	StageVariables                  map[string]string             `json:"stageVariables"`
+	RequestContext                  APIGatewayProxyRequestContext `json:"requestContext"`
+	Body                            string                        `json:"body"`
+	IsBase64Encoded                 bool                          `json:"isBase64Encoded"`
+}
+
+// APIGatewayProxyRequestContext is the request context of an APIGatewayProxyRequest
+type APIGatewayProxyRequestContext struct {
+	AccountID    string                 `json:"accountId"`
+	APIID        string                 `json:"apiId"`
+	DomainName   string                 `json:"domainName"`
+	Stage        string                 `json:"stage"`
+	RequestID    string                 `json:"requestId"`
+	ResourcePath string                 `json:"resourcePath"`
+	Identity     APIGatewayIdentity     `json:"identity"`
+	Authorizer   map[string]interface{} `json:"authorizer"`
+}
+
+// APIGatewayIdentity is the identity of the caller of an APIGatewayProxyRequest
+type APIGatewayIdentity struct {
+	SourceIP  string `json:"sourceIp"`
+	UserAgent string `json:"userAgent"`
+}
+
+// APIGatewayProxyResponse is the response to an APIGatewayProxyRequest
+type APIGatewayProxyResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
+
+// APIGatewayV2HTTPRequest is the event sent by API Gateway HTTP APIs using payload format
+// version 2.0 and by Lambda function URLs
+type APIGatewayV2HTTPRequest struct {
+	Version               string                         `json:"version"`
+	RouteKey              string                         `json:"routeKey"`
+	RawPath               string                         `json:"rawPath"`
+	RawQueryString        string                         `json:"rawQueryString"`
+	Cookies               []string                       `json:"cookies,omitempty"`
+	Headers               map[string]string              `json:"headers"`
+	QueryStringParameters map[string]string              `json:"queryStringParameters,omitempty"`
+	PathParameters        map[string]string              `json:"pathParameters,omitempty"`
+	StageVariables        map[string]string              `json:"stageVariables,omitempty"`
+	RequestContext        APIGatewayV2HTTPRequestContext `json:"requestContext"`
+	Body                  string                         `json:"body,omitempty"`
+	IsBase64Encoded       bool                           `json:"isBase64Encoded"`
+}
+
+// APIGatewayV2HTTPRequestContext is the request context of an APIGatewayV2HTTPRequest
+type APIGatewayV2HTTPRequestContext struct {
+	AccountID  string                             `json:"accountId"`
+	APIID      string                             `json:"apiId"`
+	DomainName string                             `json:"domainName"`
+	Stage      string                             `json:"stage"`
+	RequestID  string                             `json:"requestId"`
+	RouteKey   string                             `json:"routeKey"`
+	TimeEpoch  int64                              `json:"timeEpoch"`
+	HTTP       APIGatewayV2HTTPRequestContextHTTP `json:"http"`
+	Authorizer map[string]interface{}             `json:"authorizer,omitempty"`
+}
+
+// APIGatewayV2HTTPRequestContextHTTP describes the HTTP request of an
+// APIGatewayV2HTTPRequest
+type APIGatewayV2HTTPRequestContextHTTP struct {
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Protocol  string `json:"protocol"`
+	SourceIP  string `json:"sourceIp"`
+	UserAgent string `json:"userAgent"`
+}
+
+// APIGatewayV2HTTPResponse is the response to an APIGatewayV2HTTPRequest
+type APIGatewayV2HTTPResponse struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+	Cookies         []string          `json:"cookies,omitempty"`
+}
@@ -0,0 +1,264 @@
+// Package boartest provides utilities for testing boar handlers and middlewares without
+// starting a Router or an HTTP server.
+//
+// Example:
+//
+//	func TestGetUser(t *testing.T) {
+//		c := boartest.NewContext(http.MethodGet, "/users/42").
+//			Param("id", "42").
+//			Build()
+//
+//		err := c.Handle(&GetUserHandler{db: db})
+//
+//		require.NoError(t, err)
+//		c.AssertStatus(t, http.StatusOK)
+//		c.AssertJSON(t, `{"id": 42, "name": "Brett"}`)
+//	}
+package boartest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/julienschmidt/httprouter"
+)
+
+// ContextBuilder builds a Context for a request. Create one with NewContext
+type ContextBuilder struct {
+	method string
+	target string
+	params httprouter.Params
+	query  url.Values
+	header http.Header
+	body   io.Reader
+	values map[string]interface{}
+	router *boar.Router
+	err    error
+}
+
+// NewContext creates a ContextBuilder for a request with the given method and target, which
+// is a path or an absolute URL and may include a query string
+func NewContext(method, target string) *ContextBuilder {
+	return &ContextBuilder{
+		method: method,
+		target: target,
+		query:  url.Values{},
+		header: http.Header{},
+		values: map[string]interface{}{},
+	}
+}
+
+// Param adds a URL parameter as if it was matched by a route such as /users/:id
+func (b *ContextBuilder) Param(name, value string) *ContextBuilder {
+	b.params = append(b.params, httprouter.Param{Key: name, Value: value})
+	return b
+}
+
+// Query adds a query string value
+func (b *ContextBuilder) Query(name, value string) *ContextBuilder {
+	b.query.Add(name, value)
+	return b
+}
+
+// Header adds a request header
+func (b *ContextBuilder) Header(name, value string) *ContextBuilder {
+	b.header.Add(name, value)
+	return b
+}
+
+// Set stores a value on the Context as if it was set by a middleware
+func (b *ContextBuilder) Set(key string, v interface{}) *ContextBuilder {
+	b.values[key] = v
+	return b
+}
+
+// Body sets the request body and its Content-Type
+func (b *ContextBuilder) Body(contentType string, body []byte) *ContextBuilder {
+	b.header.Set("Content-Type", contentType)
+	b.body = bytes.NewReader(body)
+	return b
+}
+
+// JSON sets the request body to v encoded as JSON. Strings and []byte are used as they are
+func (b *ContextBuilder) JSON(v interface{}) *ContextBuilder {
+	var body []byte
+	switch v := v.(type) {
+	case string:
+		body = []byte(v)
+	case []byte:
+		body = v
+	default:
+		body, b.err = json.Marshal(v)
+	}
+	return b.Body("application/json", body)
+}
+
+// Router creates the Context with rtr so that its bases, ContextFactory, and settings
+// apply to the handler as they do when rtr serves the request
+func (b *ContextBuilder) Router(rtr *boar.Router) *ContextBuilder {
+	b.router = rtr
+	return b
+}
+
+// Form sets the request body to the URL encoded form values
+func (b *ContextBuilder) Form(values url.Values) *ContextBuilder {
+	return b.Body("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// Build creates the Context. It panics if the target cannot be parsed or the JSON body
+// cannot be encoded since both are mistakes in the test itself
+func (b *ContextBuilder) Build() *Context {
+	if b.err != nil {
+		panic("boartest: could not encode JSON body: " + b.err.Error())
+	}
+	r := httptest.NewRequest(b.method, b.target, b.body)
+	if len(b.query) > 0 {
+		q := r.URL.Query()
+		for name, values := range b.query {
+			q[name] = append(q[name], values...)
+		}
+		r.URL.RawQuery = q.Encode()
+	}
+	for name, values := range b.header {
+		r.Header[name] = values
+	}
+
+	rec := httptest.NewRecorder()
+	c := &Context{
+		baseContext: boar.NewContext(r, rec, b.params),
+		Recorder:    rec,
+	}
+	if b.router != nil {
+		c.baseContext = b.router.NewContext(r, rec, b.params)
+	}
+	for key, v := range b.values {
+		c.Set(key, v)
+	}
+	return c
+}
+
+// baseContext allows embedding boar.Context despite its Context method
+type baseContext = boar.Context
+
+// Context is an in-memory boar.Context whose response is recorded by Recorder
+type Context struct {
+	baseContext
+	// Recorder records the response once it is flushed. The assertions of Context flush
+	// the response before reading it
+	Recorder *httptest.ResponseRecorder
+}
+
+// Unwrap returns the boar.Context which c records
+func (c *Context) Unwrap() boar.Context {
+	return c.baseContext
+}
+
+// Handle handles the request with h exactly as a Router would, see boar.ServeHandler, and
+// flushes the response. Errors are returned instead of being written to the response.
+// Bases registered with PopulateBase are only filled when the Context was built with
+// ContextBuilder.Router
+func (c *Context) Handle(h boar.Handler) error {
+	err := boar.ServeHandler(c, h)
+	c.Response().Flush()
+	return err
+}
+
+// Result flushes the response and returns the recorded response
+func (c *Context) Result() *http.Response {
+	c.Response().Flush()
+	return c.Recorder.Result()
+}
+
+// Body flushes the response and returns the recorded body
+func (c *Context) Body() string {
+	c.Response().Flush()
+	return c.Recorder.Body.String()
+}
+
+// AssertStatus reports an error to t when the recorded status is not status
+func (c *Context) AssertStatus(t testing.TB, status int) bool {
+	t.Helper()
+	if got := c.Result().StatusCode; got != status {
+		t.Errorf("expected status %d but got %d", status, got)
+		return false
+	}
+	return true
+}
+
+// AssertHeader reports an error to t when the recorded header named name is not value
+func (c *Context) AssertHeader(t testing.TB, name, value string) bool {
+	t.Helper()
+	if got := c.Result().Header.Get(name); got != value {
+		t.Errorf("expected header %s to be %q but got %q", name, value, got)
+		return false
+	}
+	return true
+}
+
+// AssertBody reports an error to t when the recorded body is not body
+func (c *Context) AssertBody(t testing.TB, body string) bool {
+	t.Helper()
+	if got := c.Body(); got != body {
+		t.Errorf("expected body %q but got %q", body, got)
+		return false
+	}
+	return true
+}
+
+// AssertContains reports an error to t when the recorded body does not contain s
+func (c *Context) AssertContains(t testing.TB, s string) bool {
+	t.Helper()
+	if got := c.Body(); !strings.Contains(got, s) {
+		t.Errorf("expected body %q to contain %q", got, s)
+		return false
+	}
+	return true
+}
+
+// AssertJSON reports an error to t when the recorded body is not JSON equivalent to
+// expected. Strings and []byte are compared as JSON documents and other values are
+// encoded as JSON before comparison
+func (c *Context) AssertJSON(t testing.TB, expected interface{}) bool {
+	t.Helper()
+	return assertJSON(t, expected, []byte(c.Body()))
+}
+
+func assertJSON(t testing.TB, expected interface{}, actual []byte) bool {
+	t.Helper()
+	var want []byte
+	switch v := expected.(type) {
+	case string:
+		want = []byte(v)
+	case []byte:
+		want = v
+	default:
+		var err error
+		if want, err = json.Marshal(v); err != nil {
+			t.Errorf("could not encode expected JSON: %+v", err)
+			return false
+		}
+	}
+
+	var wantv, gotv interface{}
+	if err := json.Unmarshal(want, &wantv); err != nil {
+		t.Errorf("expected value is not valid JSON: %+v", err)
+		return false
+	}
+	if err := json.Unmarshal(actual, &gotv); err != nil {
+		t.Errorf("body %q is not valid JSON: %+v", actual, err)
+		return false
+	}
+	if !reflect.DeepEqual(wantv, gotv) {
+		t.Errorf("expected JSON %s but got %s", want, actual)
+		return false
+	}
+	return true
+}
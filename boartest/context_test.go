@@ -0,0 +1,145 @@
+package boartest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records the failures of assertions instead of failing the test
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+type createUserHandler struct {
+	URLParams struct {
+		Org string `url:"org"`
+	}
+	Query struct {
+		Notify bool `query:"notify"`
+	}
+	Header struct {
+		RequestID string `header:"X-Request-Id"`
+	}
+	Body struct {
+		Name string `json:"name"`
+	}
+	User string `ctx:"user"`
+}
+
+func (h *createUserHandler) Handle(c boar.Context) error {
+	c.Response().Header().Set("X-Request-Id", h.Header.RequestID)
+	return c.WriteJSON(http.StatusCreated, boar.JSON{
+		"org":    h.URLParams.Org,
+		"notify": h.Query.Notify,
+		"name":   h.Body.Name,
+		"by":     h.User,
+	})
+}
+
+func TestHandleShouldBindRequest(t *testing.T) {
+	c := NewContext(http.MethodPost, "/orgs/acme/users").
+		Param("org", "acme").
+		Query("notify", "true").
+		Header("X-Request-Id", "abc").
+		Set("user", "admin").
+		JSON(boar.JSON{"name": "Brett"}).
+		Build()
+
+	require.NoError(t, c.Handle(&createUserHandler{}))
+	c.AssertStatus(t, http.StatusCreated)
+	c.AssertHeader(t, "X-Request-Id", "abc")
+	c.AssertJSON(t, `{"org": "acme", "notify": true, "name": "Brett", "by": "admin"}`)
+}
+
+func TestBuildShouldKeepQueryOfTarget(t *testing.T) {
+	c := NewContext(http.MethodGet, "/users?page=2").Query("sort", "name").Build()
+
+	assert.Equal(t, "2", c.Request().URL.Query().Get("page"))
+	assert.Equal(t, "name", c.Request().URL.Query().Get("sort"))
+}
+
+func TestFormShouldSetFormBody(t *testing.T) {
+	c := NewContext(http.MethodPost, "/").Form(url.Values{"name": {"Brett"}}).Build()
+
+	assert.True(t, c.IsForm())
+	assert.Equal(t, "Brett", c.FormValue("name"))
+}
+
+func TestHandleShouldReturnBindErrors(t *testing.T) {
+	c := NewContext(http.MethodPost, "/").JSON("{").Build()
+
+	assert.Error(t, c.Handle(&createUserHandler{}))
+}
+
+func TestBuildShouldPanicWhenJSONCannotBeEncoded(t *testing.T) {
+	assert.Panics(t, func() {
+		NewContext(http.MethodPost, "/").JSON(func() {}).Build()
+	})
+}
+
+func TestAssertionsShouldReportFailures(t *testing.T) {
+	c := NewContext(http.MethodGet, "/").Build()
+	require.NoError(t, c.WriteString(http.StatusOK, "hello"))
+
+	rt := &recordingT{}
+	assert.False(t, c.AssertStatus(rt, http.StatusNotFound))
+	assert.False(t, c.AssertHeader(rt, "X-Missing", "value"))
+	assert.False(t, c.AssertBody(rt, "goodbye"))
+	assert.False(t, c.AssertContains(rt, "bye"))
+	assert.False(t, c.AssertJSON(rt, boar.JSON{}))
+	assert.Len(t, rt.errors, 5)
+
+	assert.True(t, c.AssertStatus(t, http.StatusOK))
+	assert.True(t, c.AssertBody(t, "hello"))
+	assert.True(t, c.AssertContains(t, "ell"))
+}
+
+type Tenant struct {
+	Name string
+}
+
+type pipelineHandler struct {
+	Tenant
+	bound    bool
+	Response struct {
+		Tenant string `json:"tenant"`
+		Bound  bool   `json:"bound"`
+	}
+}
+
+func (h *pipelineHandler) AfterBind(boar.Context) error {
+	h.bound = true
+	return nil
+}
+
+func (h *pipelineHandler) Handle(boar.Context) error {
+	h.Response.Tenant = h.Name
+	h.Response.Bound = h.bound
+	return nil
+}
+
+func TestHandleShouldUseTheRouterPipeline(t *testing.T) {
+	rtr := boar.NewRouter()
+	rtr.PopulateBase(func(c boar.Context, b *Tenant) error {
+		b.Name = "acme"
+		return nil
+	})
+	c := NewContext(http.MethodGet, "/").Router(rtr).Build()
+
+	require.NoError(t, c.Handle(&pipelineHandler{}))
+	c.AssertStatus(t, http.StatusOK)
+	c.AssertJSON(t, `{"tenant": "acme", "bound": true}`)
+}
@@ -0,0 +1,116 @@
+package boartest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is registered as the -boartest.update flag of every test binary which imports
+// boartest. It is namespaced so that it does not conflict with an -update flag defined by
+// the test package or another library
+var update = flag.Bool("boartest.update", false, "update boartest golden files")
+
+// Golden compares the status, the named headers, and the body of the response to the golden
+// file at path and reports the differences. Running the tests with -boartest.update writes the
+// response to the file instead. JSON bodies are indented so that changes produce readable
+// diffs.
+//
+// Example:
+//
+//	boartest.Request(rtr).Get("/users/1").Expect(t).
+//		Golden("testdata/get_user.golden", "Content-Type")
+func (e *Expectation) Golden(path string, headers ...string) *Expectation {
+	e.t.Helper()
+	assertGolden(e.t, path, goldenResponse(e.resp.StatusCode, e.resp.Header, e.body, headers))
+	return e
+}
+
+// AssertGolden compares the recorded response to the golden file at path. See
+// Expectation.Golden
+func (c *Context) AssertGolden(t testing.TB, path string, headers ...string) bool {
+	t.Helper()
+	resp := c.Result()
+	return assertGolden(t, path, goldenResponse(resp.StatusCode, resp.Header, []byte(c.Body()), headers))
+}
+
+func goldenResponse(status int, header http.Header, body []byte, headers []string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%d %s\n", status, http.StatusText(status))
+	for _, name := range headers {
+		for _, v := range header[http.CanonicalHeaderKey(name)] {
+			fmt.Fprintf(&b, "%s: %s\n", http.CanonicalHeaderKey(name), v)
+		}
+	}
+	b.WriteByte('\n')
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "application/json" || mediaType == "application/problem+json" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, bytes.TrimSpace(body), "", "  "); err == nil {
+			body = append(indented.Bytes(), '\n')
+		}
+	}
+	b.Write(body)
+	return b.Bytes()
+}
+
+func assertGolden(t testing.TB, path string, actual []byte) bool {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Errorf("could not create golden file directory: %+v", err)
+			return false
+		}
+		if err := ioutil.WriteFile(path, actual, 0644); err != nil {
+			t.Errorf("could not update golden file: %+v", err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Errorf("could not read golden file, run the tests with -boartest.update to create it: %+v", err)
+		return false
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("response does not match golden file %s, run the tests with -boartest.update to update it\n%s",
+			path, diff(expected, actual))
+		return false
+	}
+	return true
+}
+
+// diff describes the lines of expected and actual which differ
+func diff(expected, actual []byte) string {
+	exp := bytes.Split(expected, []byte("\n"))
+	act := bytes.Split(actual, []byte("\n"))
+	var b bytes.Buffer
+	for i := 0; i < len(exp) || i < len(act); i++ {
+		var e, a []byte
+		if i < len(exp) {
+			e = exp[i]
+		}
+		if i < len(act) {
+			a = act[i]
+		}
+		if bytes.Equal(e, a) {
+			continue
+		}
+		if i < len(exp) {
+			fmt.Fprintf(&b, "%d - %s\n", i+1, e)
+		}
+		if i < len(act) {
+			fmt.Fprintf(&b, "%d + %s\n", i+1, a)
+		}
+	}
+	return b.String()
+}
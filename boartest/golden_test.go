@@ -0,0 +1,71 @@
+package boartest
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withUpdate(t *testing.T) {
+	*update = true
+	t.Cleanup(func() { *update = false })
+}
+
+func TestGoldenShouldRegisterANamespacedUpdateFlag(t *testing.T) {
+	assert.NotNil(t, flag.Lookup("boartest.update"))
+	assert.Nil(t, flag.Lookup("update"))
+}
+
+func TestGoldenShouldWriteFileWithUpdate(t *testing.T) {
+	withUpdate(t)
+	path := filepath.Join(t.TempDir(), "testdata", "user.golden")
+
+	Request(newUsersRouter()).
+		Post("/orgs/acme/users").
+		WithJSON(boar.JSON{"name": "Brett"}).
+		Expect(t).
+		Golden(path, "content-type")
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `201 Created
+Content-Type: application/json
+
+{
+  "by": "",
+  "name": "Brett",
+  "notify": false,
+  "org": "acme"
+}
+`, string(b))
+}
+
+func TestGoldenShouldCompareWithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.golden")
+	require.NoError(t, ioutil.WriteFile(path, []byte("200 OK\n\nhello"), 0644))
+
+	c := NewContext(http.MethodGet, "/").Build()
+	require.NoError(t, c.WriteString(http.StatusOK, "hello"))
+	assert.True(t, c.AssertGolden(t, path))
+
+	rt := &recordingT{}
+	c = NewContext(http.MethodGet, "/").Build()
+	require.NoError(t, c.WriteString(http.StatusOK, "goodbye"))
+	assert.False(t, c.AssertGolden(rt, path))
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], "3 - hello\n3 + goodbye\n")
+}
+
+func TestGoldenShouldReportMissingFile(t *testing.T) {
+	rt := &recordingT{}
+	Request(newUsersRouter()).Get("/").Expect(rt).Golden(filepath.Join(t.TempDir(), "missing.golden"))
+
+	require.Len(t, rt.errors, 1)
+	assert.Contains(t, rt.errors[0], "-boartest.update")
+}
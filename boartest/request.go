@@ -0,0 +1,223 @@
+package boartest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// RequestBuilder builds a request which is served by a handler, usually a *boar.Router, so
+// that tests exercise routing, middlewares, and binding. Create one with Request
+type RequestBuilder struct {
+	handler http.Handler
+	method  string
+	target  string
+	query   url.Values
+	header  http.Header
+	body    []byte
+	err     error
+}
+
+// Request creates a RequestBuilder for requests served by h.
+//
+// Example:
+//
+//	var user User
+//	boartest.Request(rtr).
+//		Post("/users").
+//		WithHeader("Authorization", "Bearer "+token).
+//		WithJSON(boar.JSON{"name": "Brett"}).
+//		Expect(t).
+//		Status(http.StatusCreated).
+//		JSONBody(&user)
+func Request(h http.Handler) *RequestBuilder {
+	return &RequestBuilder{
+		handler: h,
+		method:  http.MethodGet,
+		target:  "/",
+		query:   url.Values{},
+		header:  http.Header{},
+	}
+}
+
+// Method sets the method and target of the request. The target is a path or an absolute
+// URL and may include a query string
+func (b *RequestBuilder) Method(method, target string) *RequestBuilder {
+	b.method = method
+	b.target = target
+	return b
+}
+
+// Get sets the request to a GET of target
+func (b *RequestBuilder) Get(target string) *RequestBuilder {
+	return b.Method(http.MethodGet, target)
+}
+
+// Post sets the request to a POST to target
+func (b *RequestBuilder) Post(target string) *RequestBuilder {
+	return b.Method(http.MethodPost, target)
+}
+
+// Put sets the request to a PUT to target
+func (b *RequestBuilder) Put(target string) *RequestBuilder {
+	return b.Method(http.MethodPut, target)
+}
+
+// Patch sets the request to a PATCH to target
+func (b *RequestBuilder) Patch(target string) *RequestBuilder {
+	return b.Method(http.MethodPatch, target)
+}
+
+// Delete sets the request to a DELETE of target
+func (b *RequestBuilder) Delete(target string) *RequestBuilder {
+	return b.Method(http.MethodDelete, target)
+}
+
+// WithHeader adds a request header
+func (b *RequestBuilder) WithHeader(name, value string) *RequestBuilder {
+	b.header.Add(name, value)
+	return b
+}
+
+// WithQuery adds a query string value
+func (b *RequestBuilder) WithQuery(name, value string) *RequestBuilder {
+	b.query.Add(name, value)
+	return b
+}
+
+// WithBody sets the request body and its Content-Type
+func (b *RequestBuilder) WithBody(contentType string, body []byte) *RequestBuilder {
+	b.header.Set("Content-Type", contentType)
+	b.body = body
+	return b
+}
+
+// WithJSON sets the request body to v encoded as JSON. Strings and []byte are used as
+// they are
+func (b *RequestBuilder) WithJSON(v interface{}) *RequestBuilder {
+	var body []byte
+	switch v := v.(type) {
+	case string:
+		body = []byte(v)
+	case []byte:
+		body = v
+	default:
+		body, b.err = json.Marshal(v)
+	}
+	return b.WithBody("application/json", body)
+}
+
+// WithForm sets the request body to the URL encoded form values
+func (b *RequestBuilder) WithForm(values url.Values) *RequestBuilder {
+	return b.WithBody("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// Build creates the request. It panics if the target cannot be parsed or the JSON body
+// cannot be encoded since both are mistakes in the test itself
+func (b *RequestBuilder) Build() *http.Request {
+	if b.err != nil {
+		panic("boartest: could not encode JSON body: " + b.err.Error())
+	}
+	var body io.Reader
+	if b.body != nil {
+		body = bytes.NewReader(b.body)
+	}
+	r := httptest.NewRequest(b.method, b.target, body)
+	if len(b.query) > 0 {
+		q := r.URL.Query()
+		for name, values := range b.query {
+			q[name] = append(q[name], values...)
+		}
+		r.URL.RawQuery = q.Encode()
+	}
+	for name, values := range b.header {
+		r.Header[name] = values
+	}
+	return r
+}
+
+// Expect serves the request and returns an Expectation which reports failed assertions
+// about the response to t
+func (b *RequestBuilder) Expect(t testing.TB) *Expectation {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	b.handler.ServeHTTP(rec, b.Build())
+	return &Expectation{t: t, resp: rec.Result(), body: rec.Body.Bytes()}
+}
+
+// Expectation makes assertions about a response. Failed assertions are reported to the
+// test without stopping it so that every failure of a response is reported at once
+type Expectation struct {
+	t    testing.TB
+	resp *http.Response
+	body []byte
+}
+
+// Status asserts the status of the response
+func (e *Expectation) Status(status int) *Expectation {
+	e.t.Helper()
+	if e.resp.StatusCode != status {
+		e.t.Errorf("expected status %d but got %d with body %q", status, e.resp.StatusCode, e.body)
+	}
+	return e
+}
+
+// Header asserts the value of a response header
+func (e *Expectation) Header(name, value string) *Expectation {
+	e.t.Helper()
+	if got := e.resp.Header.Get(name); got != value {
+		e.t.Errorf("expected header %s to be %q but got %q", name, value, got)
+	}
+	return e
+}
+
+// Body asserts the body of the response
+func (e *Expectation) Body(body string) *Expectation {
+	e.t.Helper()
+	if got := string(e.body); got != body {
+		e.t.Errorf("expected body %q but got %q", body, got)
+	}
+	return e
+}
+
+// Contains asserts that the body of the response contains s
+func (e *Expectation) Contains(s string) *Expectation {
+	e.t.Helper()
+	if !strings.Contains(string(e.body), s) {
+		e.t.Errorf("expected body %q to contain %q", e.body, s)
+	}
+	return e
+}
+
+// JSON asserts that the body of the response is JSON equivalent to expected. See
+// Context.AssertJSON
+func (e *Expectation) JSON(expected interface{}) *Expectation {
+	e.t.Helper()
+	assertJSON(e.t, expected, e.body)
+	return e
+}
+
+// JSONBody decodes the JSON body of the response into v
+func (e *Expectation) JSONBody(v interface{}) *Expectation {
+	e.t.Helper()
+	if err := json.Unmarshal(e.body, v); err != nil {
+		e.t.Errorf("could not decode body %q: %+v", e.body, err)
+	}
+	return e
+}
+
+// Response returns the response. Its body has already been read and is returned by
+// RawBody
+func (e *Expectation) Response() *http.Response {
+	return e.resp
+}
+
+// RawBody returns the body of the response
+func (e *Expectation) RawBody() []byte {
+	return e.body
+}
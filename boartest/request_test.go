@@ -0,0 +1,83 @@
+package boartest
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUsersRouter() *boar.Router {
+	r := boar.NewRouter()
+	r.Use(func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			c.Set("user", c.Request().Header.Get("X-User"))
+			return next(c)
+		}
+	})
+	r.Post("/orgs/:org/users", func(boar.Context) (boar.Handler, error) {
+		return &createUserHandler{}, nil
+	})
+	return r
+}
+
+func TestRequestShouldExerciseRouter(t *testing.T) {
+	var out struct {
+		Org    string `json:"org"`
+		Notify bool   `json:"notify"`
+		Name   string `json:"name"`
+		By     string `json:"by"`
+	}
+
+	Request(newUsersRouter()).
+		Post("/orgs/acme/users").
+		WithQuery("notify", "true").
+		WithHeader("X-Request-Id", "abc").
+		WithHeader("X-User", "admin").
+		WithJSON(boar.JSON{"name": "Brett"}).
+		Expect(t).
+		Status(http.StatusCreated).
+		Header("X-Request-Id", "abc").
+		Contains(`"acme"`).
+		JSON(`{"org": "acme", "notify": true, "name": "Brett", "by": "admin"}`).
+		JSONBody(&out)
+
+	assert.Equal(t, "acme", out.Org)
+	assert.True(t, out.Notify)
+	assert.Equal(t, "Brett", out.Name)
+	assert.Equal(t, "admin", out.By)
+}
+
+func TestRequestShouldSendForm(t *testing.T) {
+	r := boar.NewRouter()
+	r.MethodFunc(http.MethodPut, "/", func(c boar.Context) error {
+		return c.WriteString(http.StatusOK, c.FormValue("name"))
+	})
+
+	Request(r).Put("/").WithForm(url.Values{"name": {"Brett"}}).Expect(t).Body("Brett")
+}
+
+func TestExpectationShouldReportFailures(t *testing.T) {
+	rt := &recordingT{}
+	e := Request(newUsersRouter()).Get("/missing").Expect(rt).
+		Status(http.StatusOK).
+		Header("X-Missing", "value").
+		Body("body").
+		Contains("body").
+		JSON(boar.JSON{}).
+		JSONBody(&struct{}{})
+
+	assert.Len(t, rt.errors, 6)
+	assert.Equal(t, http.StatusNotFound, e.Response().StatusCode)
+}
+
+func TestBuildShouldCreateRequest(t *testing.T) {
+	r := Request(nil).Delete("/users/1?force=true").WithQuery("reason", "spam").Build()
+
+	require.Equal(t, http.MethodDelete, r.Method)
+	assert.Equal(t, "true", r.URL.Query().Get("force"))
+	assert.Equal(t, "spam", r.URL.Query().Get("reason"))
+}
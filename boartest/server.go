@@ -0,0 +1,78 @@
+package boartest
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Server is a started httptest.Server whose Client keeps cookies between requests and
+// whose helpers accept paths relative to the server's URL
+type Server struct {
+	*httptest.Server
+	client *http.Client
+}
+
+// NewServer starts a server for h, usually a *boar.Router, which is closed when the test
+// and its subtests complete.
+//
+// Example:
+//
+//	srv := boartest.NewServer(t, rtr)
+//	resp, err := srv.Post("/login", "application/json", strings.NewReader(creds))
+//	...
+//	resp, err = srv.Get("/profile") // sends the session cookie set by /login
+func NewServer(t testing.TB, h http.Handler) *Server {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("could not create cookie jar: %+v", err)
+	}
+	client := srv.Client()
+	client.Jar = jar
+	return &Server{Server: srv, client: client}
+}
+
+// Client returns the client of the server which keeps cookies between requests
+func (s *Server) Client() *http.Client {
+	return s.client
+}
+
+// NewRequest creates a request for path, which is relative to the server's URL
+func (s *Server) NewRequest(method, path string, body io.Reader) (*http.Request, error) {
+	return http.NewRequest(method, s.resolve(path), body)
+}
+
+// Do sends the request with the server's Client
+func (s *Server) Do(r *http.Request) (*http.Response, error) {
+	return s.client.Do(r)
+}
+
+// Get sends a GET request for path
+func (s *Server) Get(path string) (*http.Response, error) {
+	return s.client.Get(s.resolve(path))
+}
+
+// Post sends a POST request to path
+func (s *Server) Post(path, contentType string, body io.Reader) (*http.Response, error) {
+	return s.client.Post(s.resolve(path), contentType, body)
+}
+
+// PostForm sends a POST request to path with the URL encoded form values
+func (s *Server) PostForm(path string, values url.Values) (*http.Response, error) {
+	return s.client.PostForm(s.resolve(path), values)
+}
+
+func (s *Server) resolve(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return s.URL + "/" + strings.TrimPrefix(path, "/")
+}
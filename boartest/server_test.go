@@ -0,0 +1,82 @@
+package boartest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionRouter() *boar.Router {
+	r := boar.NewRouter()
+	r.MethodFunc(http.MethodPost, "/login", func(c boar.Context) error {
+		http.SetCookie(c.Response(), &http.Cookie{Name: "session", Value: c.FormValue("user"), Path: "/"})
+		return c.NoContent()
+	})
+	r.MethodFunc(http.MethodGet, "/profile", func(c boar.Context) error {
+		cookie, err := c.Request().Cookie("session")
+		if err != nil {
+			return boar.ErrUnauthorized
+		}
+		return c.WriteString(http.StatusOK, cookie.Value)
+	})
+	r.MethodFunc(http.MethodPost, "/echo", func(c boar.Context) error {
+		b, err := ioutil.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.Write(http.StatusOK, c.ContentType(), b)
+	})
+	return r
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestServerShouldKeepCookies(t *testing.T) {
+	srv := NewServer(t, newSessionRouter())
+
+	resp, err := srv.Get("/profile")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = srv.PostForm("/login", url.Values{"user": {"brett"}})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, err = srv.Get("profile")
+	require.NoError(t, err)
+	assert.Equal(t, "brett", readBody(t, resp))
+}
+
+func TestServerShouldResolveRequests(t *testing.T) {
+	srv := NewServer(t, newSessionRouter())
+
+	resp, err := srv.Post("/echo", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", readBody(t, resp))
+
+	req, err := srv.NewRequest(http.MethodPost, "/echo", strings.NewReader("world"))
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/echo", req.URL.String())
+	resp, err = srv.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "world", readBody(t, resp))
+
+	resp, err = srv.Get(srv.URL + "/profile")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.NotNil(t, srv.Client().Jar)
+}
@@ -0,0 +1,41 @@
+package boar
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// MutualTLSConfig creates the TLS configuration of an http.Server which authenticates
+// clients with certificates issued by clientCAs. When required is false clients may
+// connect without a certificate so that only some routes require one, for example with
+// the middleware.ClientCert middleware. Certificates which are presented are always
+// verified. Add the certificate of the server with Certificates or GetCertificate.
+//
+// Example:
+//
+//	srv := &http.Server{
+//		Addr:      ":8443",
+//		Handler:   rtr,
+//		TLSConfig: boar.MutualTLSConfig(clientCAs, true),
+//	}
+//	log.Fatal(srv.ListenAndServeTLS("server.crt", "server.key"))
+func MutualTLSConfig(clientCAs *x509.CertPool, required bool) *tls.Config {
+	auth := tls.VerifyClientCertIfGiven
+	if required {
+		auth = tls.RequireAndVerifyClientCert
+	}
+	return &tls.Config{
+		ClientCAs:  clientCAs,
+		ClientAuth: auth,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+func (r *requestContext) ClientCertificate() *x509.Certificate {
+	tlsState := r.Request().TLS
+	// PeerCertificates are not verified unless they are part of a verified chain
+	if tlsState == nil || len(tlsState.VerifiedChains) == 0 || len(tlsState.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return tlsState.VerifiedChains[0][0]
+}
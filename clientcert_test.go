@@ -0,0 +1,118 @@
+package boar
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCert creates a certificate for tmpl signed by parent, or self-signed when parent
+// is nil
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := tmpl, interface{}(key)
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestMutualTLSConfig(t *testing.T) {
+	pool := x509.NewCertPool()
+
+	cfg := MutualTLSConfig(pool, true)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, cfg.ClientAuth)
+	assert.Equal(t, pool, cfg.ClientCAs)
+
+	cfg = MutualTLSConfig(pool, false)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, cfg.ClientAuth)
+}
+
+func TestClientCertificateShouldOnlyReturnVerifiedCertificates(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing"}}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := NewContext(req, httptest.NewRecorder(), nil)
+
+	assert.Nil(t, c.ClientCertificate())
+
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	assert.Nil(t, c.ClientCertificate())
+
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+	assert.Equal(t, cert, c.ClientCertificate())
+}
+
+func TestMutualTLSExposesClientCertificate(t *testing.T) {
+	ca := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+	clientCert := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "billing"},
+		DNSNames:     []string{"billing.internal"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+
+	rtr := NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		cert := c.ClientCertificate()
+		if cert == nil {
+			return c.WriteString(http.StatusOK, "anonymous")
+		}
+		return c.WriteString(http.StatusOK, cert.Subject.CommonName+" "+strings.Join(cert.DNSNames, ","))
+	})
+	srv := httptest.NewUnstartedServer(rtr)
+	srv.TLS = MutualTLSConfig(pool, false)
+	srv.TLS.Certificates = []tls.Certificate{serverCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(certs ...tls.Certificate) string {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      pool,
+			Certificates: certs,
+		}}}
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+	assert.Equal(t, "billing billing.internal", get(clientCert))
+	assert.Equal(t, "anonymous", get())
+}
@@ -0,0 +1,114 @@
+package boar
+
+import (
+	"net"
+	"strings"
+)
+
+// ParseCIDRs parses CIDR notation strings, e.g. "10.0.0.0/8", for Router.TrustedProxies.
+// Plain IP addresses are treated as a single host
+func ParseCIDRs(cidrs ...string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip4 := ip.To4(); ip4 != nil {
+					ip, bits = ip4, 8*net.IPv4len
+				}
+				nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
+
+func (r *requestContext) ClientIP() string {
+	remote := stripPort(r.request.RemoteAddr)
+	if !r.fromTrustedProxy() {
+		return remote
+	}
+	proxies := r.router.TrustedProxies
+
+	if ips := forwardedParam(r.request.Header.Get("forwarded"), "for"); len(ips) > 0 {
+		return firstUntrusted(proxies, ips, remote)
+	}
+	if xff := r.request.Header.Get("x-forwarded-for"); xff != "" {
+		return firstUntrusted(proxies, strings.Split(xff, ","), remote)
+	}
+	if ip := strings.TrimSpace(r.request.Header.Get("x-real-ip")); net.ParseIP(ip) != nil {
+		return ip
+	}
+	return remote
+}
+
+// firstUntrusted walks the chain of addresses from the closest proxy to the client and
+// returns the first address that is not a trusted proxy. The walk stops at tokens which are
+// not IP addresses, such as unknown or obfuscated identifiers, since nothing before them
+// can be verified, and the last valid address, starting with remote, is returned instead
+func firstUntrusted(proxies []*net.IPNet, chain []string, remote string) string {
+	ip := remote
+	for i := len(chain) - 1; i >= 0; i-- {
+		next := stripPort(strings.TrimSpace(chain[i]))
+		if net.ParseIP(next) == nil {
+			return ip
+		}
+		ip = next
+		if !isTrusted(proxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+// fromTrustedProxy reports whether the request was sent by one of the Router's
+// TrustedProxies
+func (r *requestContext) fromTrustedProxy() bool {
+	if r.router == nil {
+		return false
+	}
+	return isTrusted(r.router.TrustedProxies, stripPort(r.request.RemoteAddr))
+}
+
+// forwardedParam returns the values of the named parameter, e.g. for, proto, or host,
+// from each element of an RFC 7239 Forwarded header
+func forwardedParam(header, name string) []string {
+	var vals []string
+	for _, element := range strings.Split(header, ",") {
+		for _, pair := range strings.Split(element, ";") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], name) {
+				continue
+			}
+			vals = append(vals, strings.Trim(kv[1], `"`))
+		}
+	}
+	return vals
+}
+
+func isTrusted(proxies []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripPort removes the port, and brackets, from addresses such as 1.2.3.4:80 and [::1]:80
+func stripPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
@@ -0,0 +1,65 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clientIP(t *testing.T, remote string, headers map[string]string, trusted ...string) string {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remote
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	c := newContext(req, httptest.NewRecorder(), nil)
+	c.router = NewRouter()
+	proxies, err := ParseCIDRs(trusted...)
+	require.NoError(t, err)
+	c.router.TrustedProxies = proxies
+	return c.ClientIP()
+}
+
+func TestClientIPShouldIgnoreHeadersFromUntrustedRemotes(t *testing.T) {
+	ip := clientIP(t, "203.0.113.9:5000", map[string]string{"X-Forwarded-For": "1.1.1.1"})
+	assert.Equal(t, "203.0.113.9", ip)
+}
+
+func TestClientIPShouldUseXForwardedForFromTrustedProxies(t *testing.T) {
+	ip := clientIP(t, "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.7, 10.0.0.1"}, "10.0.0.0/8")
+	assert.Equal(t, "198.51.100.7", ip)
+}
+
+func TestClientIPShouldUseForwardedHeader(t *testing.T) {
+	ip := clientIP(t, "10.0.0.2:5000", map[string]string{"Forwarded": `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`}, "10.0.0.0/8")
+	assert.Equal(t, "2001:db8::1", ip)
+}
+
+func TestClientIPShouldUseXRealIP(t *testing.T) {
+	ip := clientIP(t, "[::1]:5000", map[string]string{"X-Real-IP": "192.0.2.1"}, "::1")
+	assert.Equal(t, "192.0.2.1", ip)
+}
+
+func TestClientIPShouldReturnLeftmostWhenAllProxiesAreTrusted(t *testing.T) {
+	ip := clientIP(t, "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "10.0.0.5, 10.0.0.1"}, "10.0.0.0/8")
+	assert.Equal(t, "10.0.0.5", ip)
+}
+
+func TestClientIPShouldStopAtTokensWhichAreNotIPs(t *testing.T) {
+	ip := clientIP(t, "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "1.1.1.1, <script>, 10.0.0.1"}, "10.0.0.0/8")
+	assert.Equal(t, "10.0.0.1", ip)
+
+	ip = clientIP(t, "10.0.0.2:5000", map[string]string{"X-Forwarded-For": "1.1.1.1, garbage"}, "10.0.0.0/8")
+	assert.Equal(t, "10.0.0.2", ip)
+
+	ip = clientIP(t, "10.0.0.2:5000", map[string]string{"Forwarded": "for=unknown"}, "10.0.0.0/8")
+	assert.Equal(t, "10.0.0.2", ip)
+}
+
+func TestParseCIDRsShouldErrorOnInvalidInput(t *testing.T) {
+	_, err := ParseCIDRs("not-a-cidr")
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package main
+
+import "time"
+
+// exampleHandler has fields of every type supported by boarbind. Its binders are generated
+// into example_binders_test.go by TestGenerateShouldMatchExampleBinders
+type exampleHandler struct {
+	Query struct {
+		Name   string `query:"name"`
+		Page   int    `query:"page"`
+		Small  int8
+		Count  uint16   `query:"count"`
+		Money  float32  `query:"money"`
+		Active bool     `query:"active"`
+		Tags   []string `query:"tag"`
+		IDs    []int64  `query:"id"`
+		Flags  []bool   `query:"flag"`
+		Skip   string   `query:"-"`
+		hidden string
+	}
+	URLParams exampleParams
+}
+
+type exampleParams struct {
+	ID   int    `url:"id"`
+	Slug string `url:"slug"`
+	On   bool
+}
+
+// fallbackHandler has a Query field which boarbind does not support
+type fallbackHandler struct {
+	Query struct {
+		Since time.Time `query:"since"`
+	}
+	URLParams struct {
+		ID uint `url:"id"`
+	}
+}
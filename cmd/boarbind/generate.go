@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"reflect"
+	"strconv"
+)
+
+const (
+	queryField     = "Query"
+	urlParamsField = "URLParams"
+	queryTagKey    = "query"
+	paramTagKey    = "url"
+)
+
+// kinds are the reflect.Kind names of the types supported by the binders
+var kinds = map[string]string{
+	"string":  "String",
+	"bool":    "Bool",
+	"int":     "Int",
+	"int8":    "Int8",
+	"int16":   "Int16",
+	"int32":   "Int32",
+	"rune":    "Int32",
+	"int64":   "Int64",
+	"uint":    "Uint",
+	"uint8":   "Uint8",
+	"byte":    "Uint8",
+	"uint16":  "Uint16",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"float32": "Float32",
+	"float64": "Float64",
+}
+
+// bindField is a field of a Query or URLParams struct
+type bindField struct {
+	name  string
+	key   string
+	typ   string
+	slice bool
+}
+
+// generate creates the binders of the named struct types of files
+func generate(pkg string, files []*ast.File, typeNames []string) ([]byte, []string, error) {
+	structs := map[string]*ast.StructType{}
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+
+	var (
+		body     bytes.Buffer
+		warnings []string
+		imports  = map[string]bool{}
+	)
+	for _, name := range typeNames {
+		st, ok := structs[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("struct type %s not found", name)
+		}
+		for _, group := range []string{queryField, urlParamsField} {
+			typ, ok := fieldType(st, group)
+			if !ok {
+				continue
+			}
+			inner := structOf(typ, structs)
+			if inner == nil {
+				warnings = append(warnings, fmt.Sprintf("%s.%s is not a struct", name, group))
+				continue
+			}
+			fields, err := bindFields(inner, group)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s.%s uses the reflective binder: %v", name, group, err))
+				continue
+			}
+			if group == queryField {
+				writeQueryBinder(&body, name, fields, imports)
+			} else {
+				writeParamsBinder(&body, name, fields, imports)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by boarbind. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	if len(imports) > 0 {
+		b.WriteString("import (\n")
+		for _, group := range [][]string{
+			{"net/url", "reflect", "strings"},
+			{"github.com/blockloop/boar/bind", "github.com/julienschmidt/httprouter"},
+		} {
+			b.WriteString("\n")
+			for _, path := range group {
+				if imports[path] {
+					fmt.Fprintf(&b, "\t%q\n", path)
+				}
+			}
+		}
+		b.WriteString(")\n")
+	}
+	b.Write(body.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not format generated code: %v", err)
+	}
+	return src, warnings, nil
+}
+
+// fieldType returns the type of the named field of st
+func fieldType(st *ast.StructType, name string) (ast.Expr, bool) {
+	for _, f := range st.Fields.List {
+		for _, n := range f.Names {
+			if n.Name == name {
+				return f.Type, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// structOf returns the struct of an inline struct type or a struct type of the package
+func structOf(typ ast.Expr, structs map[string]*ast.StructType) *ast.StructType {
+	switch t := typ.(type) {
+	case *ast.StructType:
+		return t
+	case *ast.Ident:
+		return structs[t.Name]
+	}
+	return nil
+}
+
+// bindFields returns the fields of st which are bound. Unexported fields are skipped just
+// as they are by the reflective binder
+func bindFields(st *ast.StructType, group string) ([]bindField, error) {
+	tagKey := queryTagKey
+	if group == urlParamsField {
+		tagKey = paramTagKey
+	}
+
+	var fields []bindField
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("embedded fields are not supported")
+		}
+		typ, slice := f.Type, false
+		if arr, ok := typ.(*ast.ArrayType); ok && arr.Len == nil && group == queryField {
+			typ, slice = arr.Elt, true
+		}
+		ident, ok := typ.(*ast.Ident)
+		if !ok || kinds[ident.Name] == "" {
+			return nil, fmt.Errorf("field type %s is not supported", exprString(f.Type))
+		}
+
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(s)
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			key := n.Name
+			if v, ok := tag.Lookup(tagKey); ok {
+				key = v
+			}
+			if key == "-" {
+				continue
+			}
+			fields = append(fields, bindField{name: n.Name, key: key, typ: ident.Name, slice: slice})
+		}
+	}
+	return fields, nil
+}
+
+func exprString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.MapType:
+		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
+	case *ast.StructType:
+		return "struct"
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+func writeQueryBinder(b *bytes.Buffer, typeName string, fields []bindField, imports map[string]bool) {
+	imports["net/url"] = true
+	fmt.Fprintf(b, "\n// BindQuery binds q to the Query field of h without reflection\n")
+	fmt.Fprintf(b, "func (h *%s) BindQuery(q url.Values) error {\n", typeName)
+	for _, f := range fields {
+		dst := "h.Query." + f.name
+		imports["strings"] = true
+		if f.slice {
+			fmt.Fprintf(b, "for _, val := range q[%q] {\nval = strings.TrimSpace(val)\n", f.key)
+			writeParse(b, f, f.name, dst+" = append("+dst+", %s)", imports)
+			b.WriteString("}\n")
+			continue
+		}
+		imports["reflect"] = true
+		imports["github.com/blockloop/boar/bind"] = true
+		fmt.Fprintf(b, "if vals := q[%q]; len(vals) > 1 {\n", f.key)
+		fmt.Fprintf(b, "return bind.MultipleValuesError(%q, reflect.%s, vals)\n", f.name, kinds[f.typ])
+		fmt.Fprintf(b, "} else if len(vals) == 1 {\nif val := strings.TrimSpace(vals[0]); val != \"\" {\n")
+		// the reflective binder reports type mismatches of simple query fields by key
+		writeParse(b, f, f.key, dst+" = %s", imports)
+		b.WriteString("}\n}\n")
+	}
+	b.WriteString("return nil\n}\n")
+}
+
+func writeParamsBinder(b *bytes.Buffer, typeName string, fields []bindField, imports map[string]bool) {
+	imports["github.com/julienschmidt/httprouter"] = true
+	fmt.Fprintf(b, "\n// BindURLParams binds params to the URLParams field of h without reflection\n")
+	fmt.Fprintf(b, "func (h *%s) BindURLParams(params httprouter.Params) error {\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(b, "if val := params.ByName(%q); val != \"\" {\n", f.key)
+		writeParse(b, f, f.name, "h.URLParams."+f.name+" = %s", imports)
+		b.WriteString("}\n")
+	}
+	b.WriteString("return nil\n}\n")
+}
+
+// writeParse writes the parsing of val into the field f followed by assign, which is a
+// format of the statement assigning the parsed value
+func writeParse(b *bytes.Buffer, f bindField, errName, assign string, imports map[string]bool) {
+	kind := kinds[f.typ]
+	var parse string
+	switch kind {
+	case "String":
+		fmt.Fprintf(b, assign+"\n", "val")
+		return
+	case "Bool":
+		fmt.Fprintf(b, "v, err := bind.ParseBool(%q, val)\n", errName)
+		imports["github.com/blockloop/boar/bind"] = true
+		fmt.Fprintf(b, "if err != nil {\nreturn err\n}\n"+assign+"\n", "v")
+		return
+	case "Int", "Int8", "Int16", "Int32", "Int64":
+		parse = "ParseInt"
+	case "Uint", "Uint8", "Uint16", "Uint32", "Uint64":
+		parse = "ParseUint"
+	default:
+		parse = "ParseFloat"
+	}
+	imports["reflect"] = true
+	imports["github.com/blockloop/boar/bind"] = true
+	fmt.Fprintf(b, "v, err := bind.%s(%q, val, reflect.%s)\n", parse, errName, kind)
+	fmt.Fprintf(b, "if err != nil {\nreturn err\n}\n"+assign+"\n", f.typ+"(v)")
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/blockloop/boar/bind"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update example_binders_test.go")
+
+func TestGenerateShouldMatchExampleBinders(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example_test.go", nil, 0)
+	require.NoError(t, err)
+
+	src, warnings, err := generate("main", []*ast.File{f}, []string{"exampleHandler", "fallbackHandler"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fallbackHandler.Query uses the reflective binder: field type time.Time is not supported"}, warnings)
+
+	if *update {
+		require.NoError(t, ioutil.WriteFile("example_binders_test.go", src, 0644))
+	}
+	expected, err := ioutil.ReadFile("example_binders_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run go test -update to regenerate example_binders_test.go")
+}
+
+func TestGenerateShouldFailForMissingType(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "example_test.go", nil, 0)
+	require.NoError(t, err)
+
+	_, _, err = generate("main", []*ast.File{f}, []string{"missingHandler"})
+	assert.EqualError(t, err, "struct type missingHandler not found")
+}
+
+func TestGeneratedQueryBinderShouldMatchReflectiveBinder(t *testing.T) {
+	for _, qs := range []string{
+		"",
+		"name=+brett+&page=2&Small=-8&count=65535&money=1.5&active=t",
+		"tag=a&tag=+b&tag=&id=1&id=2&flag=true&flag=0",
+		"name=a&name=b",
+		"page=",
+		"page=two",
+		"Small=128",
+		"count=-1",
+		"money=1e39",
+		"active=yes",
+		"id=1&id=x",
+		"flag=maybe",
+		"Skip=x&hidden=x&Name=x",
+	} {
+		q, err := url.ParseQuery(qs)
+		require.NoError(t, err)
+
+		var reflective, generated exampleHandler
+		expectedErr := bind.QueryValue(reflect.ValueOf(&reflective.Query).Elem(), q)
+		err = generated.BindQuery(q)
+
+		assert.Equal(t, expectedErr, err, qs)
+		if expectedErr == nil {
+			assert.Equal(t, reflective.Query, generated.Query, qs)
+		}
+	}
+}
+
+func TestGeneratedParamsBinderShouldMatchReflectiveBinder(t *testing.T) {
+	for _, params := range []httprouter.Params{
+		nil,
+		{{Key: "id", Value: "42"}, {Key: "slug", Value: " hello "}, {Key: "On", Value: "true"}},
+		{{Key: "id", Value: "x"}},
+		{{Key: "On", Value: "x"}},
+	} {
+		var reflective, generated exampleHandler
+		expectedErr := bind.ParamsValue(reflect.ValueOf(&reflective.URLParams).Elem(), params)
+		err := generated.BindURLParams(params)
+
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, reflective.URLParams, generated.URLParams)
+	}
+}
+
+func TestDefaultOutputShouldBeNamedAfterGoFile(t *testing.T) {
+	assert.Equal(t, "handlers_binders.go", defaultOutput("handlers.go"))
+	assert.Equal(t, "boar_binders.go", defaultOutput(""))
+}
+
+func TestRunShouldWriteBindersOfPackage(t *testing.T) {
+	dir := t.TempDir()
+	src := "package handlers\n\ntype listHandler struct {\n\tQuery struct {\n\t\tPage int `query:\"page\"`\n\t}\n}\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "handlers.go"), []byte(src), 0644))
+	out := filepath.Join(dir, "handlers_binders.go")
+
+	require.NoError(t, run(dir, out, []string{"listHandler"}))
+	// the output of a previous run is ignored when generating again
+	require.NoError(t, run(dir, out, []string{"listHandler"}))
+
+	b, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "package handlers")
+	assert.Contains(t, string(b), "func (h *listHandler) BindQuery(q url.Values) error {")
+}
@@ -0,0 +1,89 @@
+// Command boarbind generates binders for the Query and URLParams fields of boar handlers
+// so that high traffic routes bind requests without reflection. The generated BindQuery
+// and BindURLParams methods satisfy boar.QueryBinder and boar.URLParamsBinder and bind
+// exactly like the reflective binder which is still used for fields whose types boarbind
+// does not support. Only string, bool, integer, and float fields, and slices of them for
+// Query, are supported.
+//
+// Usage:
+//
+//	//go:generate boarbind -type ListUsersHandler,GetUserHandler
+//
+// The methods are written to <file>_binders.go where file is the name of the file with
+// the go:generate comment, or to the file given with -output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("boarbind: ")
+
+	typeNames := flag.String("type", "", "comma separated names of the handler types")
+	output := flag.String("output", "", "output file name. Default is <file>_binders.go")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	if *output == "" {
+		*output = defaultOutput(os.Getenv("GOFILE"))
+	}
+	out := filepath.Join(dir, *output)
+
+	if err := run(dir, out, strings.Split(*typeNames, ",")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func defaultOutput(gofile string) string {
+	if gofile == "" {
+		return "boar_binders.go"
+	}
+	return strings.TrimSuffix(gofile, ".go") + "_binders.go"
+}
+
+func run(dir, out string, typeNames []string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != filepath.Base(out)
+	}, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) != 1 {
+		return fmt.Errorf("expected one package in %s but found %d", dir, len(pkgs))
+	}
+
+	for name, pkg := range pkgs {
+		files := make([]*ast.File, 0, len(pkg.Files))
+		for _, f := range pkg.Files {
+			files = append(files, f)
+		}
+		src, warnings, err := generate(name, files, typeNames)
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings {
+			log.Print(w)
+		}
+		return ioutil.WriteFile(out, src, 0644)
+	}
+	return nil
+}
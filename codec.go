@@ -0,0 +1,58 @@
+package boar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Codec encodes and decodes JSON for Context.ReadJSON, Context.WriteJSON, and the other
+// JSON helpers. It allows encoding/json to be replaced by a faster implementation such
+// as jsoniter, go-json, or sonic.
+//
+// Example:
+//
+//	type jsoniterCodec struct{}
+//
+//	func (jsoniterCodec) Encode(w io.Writer, v interface{}) error {
+//		return jsoniter.ConfigCompatibleWithStandardLibrary.NewEncoder(w).Encode(v)
+//	}
+//
+//	func (jsoniterCodec) Decode(r io.Reader, v interface{}) error {
+//		return jsoniter.ConfigCompatibleWithStandardLibrary.NewDecoder(r).Decode(v)
+//	}
+//	...
+//	rtr.Codec = jsoniterCodec{}
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+// StdCodec is the default Codec which uses encoding/json
+type StdCodec struct{}
+
+// Encode writes v to w with json.Encoder
+func (StdCodec) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Decode reads v from r with json.Decoder
+func (StdCodec) Decode(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+func (r *requestContext) codec() Codec {
+	if r.router != nil && r.router.Codec != nil {
+		return r.router.Codec
+	}
+	return StdCodec{}
+}
+
+// marshal encodes v with the codec without the trailing newline written by encoders
+func marshal(codec Codec, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,56 @@
+package boar
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCodec counts calls and delegates to encoding/json
+type countingCodec struct {
+	encodes, decodes int
+}
+
+func (c *countingCodec) Encode(w io.Writer, v interface{}) error {
+	c.encodes++
+	return json.NewEncoder(w).Encode(v)
+}
+
+func (c *countingCodec) Decode(r io.Reader, v interface{}) error {
+	c.decodes++
+	return json.NewDecoder(r).Decode(v)
+}
+
+func TestRouterCodecShouldBeUsedForJSON(t *testing.T) {
+	codec := &countingCodec{}
+	r := NewRouter()
+	r.Codec = codec
+	r.MethodFunc(http.MethodPost, "/", func(c Context) error {
+		var body JSON
+		if err := c.ReadJSON(&body); err != nil {
+			return err
+		}
+		return c.WriteJSON(http.StatusOK, body)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"a":1}`))
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"a":1}`, rec.Body.String())
+	assert.Equal(t, 1, codec.encodes)
+	assert.Equal(t, 1, codec.decodes)
+}
+
+func TestMarshalShouldTrimTheTrailingNewline(t *testing.T) {
+	b, err := marshal(StdCodec{}, JSON{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(b))
+}
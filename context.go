@@ -1,34 +1,104 @@
-// Package boar provides HTTP middleware for semantic and organized HTTP server applications
+// Package boar provides HTTP middleware for semantic and organized HTTP server applications.
+// It requires Go 1.23 or later for http.ServeMux patterns and Request.Pattern
 package boar
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
 
 	"github.com/blockloop/boar/bind"
 	"github.com/gorilla/schema"
 	"github.com/julienschmidt/httprouter"
 )
 
+// jsonpCallbackPattern matches javascript identifiers and dotted paths such as jQuery.cb_1
+var jsonpCallbackPattern = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*(\.[a-zA-Z_$][a-zA-Z0-9_$]*)*$`)
+
 // Context is an http handler context
 type Context interface {
 
 	// Context is a shortcut for Request().Context()
 	Context() context.Context
 
+	// Ctx returns the context of the request which is canceled when the client
+	// disconnects. It should be passed to database calls and other blocking work
+	Ctx() context.Context
+
+	// WithTimeout replaces the context of the request with one that is canceled
+	// after d. Middleware and handlers further down the chain see the new context.
+	// The returned CancelFunc should be deferred
+	WithTimeout(d time.Duration) context.CancelFunc
+
+	// WithCancel replaces the context of the request with one that is canceled when
+	// the returned CancelFunc is called
+	WithCancel() context.CancelFunc
+
+	// Defer runs fn after the response has been sent so that work such as audit logs,
+	// webhooks, and cache warming does not delay the client. fn is given a context with
+	// the values of the request which is not canceled with the request but times out
+	// after the Router's DeferTimeout. Funcs run in the order they were deferred on a
+	// separate goroutine. Errors and panics are logged and passed to the OnError hooks.
+	// Funcs are only run for requests served by a Router
+	Defer(fn func(ctx context.Context) error)
+
 	// Request returns the underlying http.Request
 	Request() *http.Request
 
+	// RoutePattern returns the path of the matched route as it was registered, such as
+	// /users/:id, or an empty string when the request was not routed by a Router. Metrics
+	// and tracing should be labeled with it rather than the URL path so that their
+	// cardinality stays bounded
+	RoutePattern() string
+
 	// Response returns the underlying http.ResponseWriter
 	Response() ResponseWriter
 
+	// Bind populates the Query, URLParams, Header, and Body fields of the struct
+	// pointed to by v from the request and validates them exactly as they would be
+	// for a Handler. This allows MethodFunc handlers and middleware to reuse the
+	// binder
+	//
+	// Example:
+	//
+	//	var req struct {
+	//		URLParams struct {
+	//			ID int `url:"id"`
+	//		}
+	//		Header struct {
+	//			RequestID string `header:"X-Request-Id"`
+	//		}
+	//	}
+	//	if err := c.Bind(&req); err != nil {
+	//		return err
+	//	}
+	Bind(v interface{}) error
+
 	// ReadQuery parses the query string from the request into a struct
 	// if the query string has invalid types (e.g. alpha for an int field)
 	// then a ValidationError will be returned with a status code of 400
 	ReadQuery(v interface{}) error
 
+	// QueryString returns the named query parameter or def when it is missing
+	QueryString(name, def string) string
+
+	// QueryInt returns the named query parameter as an int or def when it is
+	// missing or not an int
+	QueryInt(name string, def int) int
+
+	// QueryBool returns the named query parameter as a bool or def when it is
+	// missing or not a bool
+	QueryBool(name string, def bool) bool
+
 	// ReadParams parses the url parameters into a struct
 	// ReadParams(v interface{}) error
 
@@ -38,15 +108,176 @@ type Context interface {
 	// ReadForm reads the contents of the request form and populates the values of v.
 	ReadForm(v interface{}) error
 
+	// FormValue returns the first value of the named form field from either the
+	// request body or the query string
+	FormValue(name string) string
+
+	// FormFile returns the first file uploaded as the named multipart form field. A
+	// ValidationError is returned when the file is missing. Multipart forms are
+	// parsed with MultiPartFormMaxMemory
+	FormFile(name string) (multipart.File, *multipart.FileHeader, error)
+
+	// SaveUploadedFile saves the uploaded file to dst, creating its directory if
+	// it does not exist
+	SaveUploadedFile(fh *multipart.FileHeader, dst string) error
+
 	// WriteJSON writes the status code and then sends a json response message
 	WriteJSON(status int, v interface{}) error
 
+	// WriteJSONP writes the status code and then sends v as json wrapped in a call
+	// to the callback function for JSONP clients. An HTTPError with a status of 400
+	// is returned when callback is not a valid javascript identifier
+	WriteJSONP(status int, callback string, v interface{}) error
+
+	// WriteProblem writes the status code and then sends an RFC 7807 problem
+	// document with the title and detail. The standard status text is used when
+	// title is empty
+	WriteProblem(status int, title, detail string) error
+
+	// WriteProblemDetails writes the Status of p and then sends p as an RFC 7807
+	// problem document
+	WriteProblemDetails(p *Problem) error
+
+	// WriteXML writes the status code and then sends an xml response message
+	// beginning with the standard xml header
+	WriteXML(status int, v interface{}) error
+
+	// WriteString writes the status code and then sends s as a text/plain response
+	WriteString(status int, s string) error
+
+	// Write writes the status code and then sends b as the response body with
+	// the given content type
+	Write(status int, contentType string, b []byte) error
+
+	// Render writes the status code and then sends the named template, rendered
+	// with data by the Router's Renderer, as an html response
+	Render(status int, name string, data interface{}) error
+
+	// Accepts returns the type from types which is preferred by the Accept header
+	// of the request or an empty string when none of them are acceptable. Earlier
+	// types are preferred when the client accepts several equally
+	Accepts(types ...string) string
+
+	// ContentType returns the media type of the request body without parameters
+	// such as charset, e.g. "application/json"
+	ContentType() string
+
+	// IsJSON reports whether the request body is JSON, including +json media types
+	// such as application/merge-patch+json
+	IsJSON() bool
+
+	// IsForm reports whether the request body is a url encoded or multipart form
+	IsForm() bool
+
+	// Negotiate writes the status code and then sends v in the format preferred by
+	// the Accept header of the request. JSON and XML are supported by default, html
+	// is supported for Templated values when the Router has a Renderer, and other
+	// formats can be added with RegisterNegotiator. ErrNotAcceptable is returned
+	// when no format is acceptable
+	Negotiate(status int, v interface{}) error
+
+	// Redirect sets the Location header to url and writes the status code. An error
+	// matching ErrInvalidRedirectCode is returned if status is not a 3xx redirection status
+	Redirect(status int, url string) error
+
+	// File sends the contents of the file at path with a content type detected
+	// from the file extension or contents. ErrNotFound is returned if the file
+	// does not exist. Range requests are answered with 206 Partial Content. The file
+	// is streamed to the client so middleware cannot change the response afterwards
+	File(path string) error
+
+	// Attachment sends the contents of r with a content disposition which causes
+	// browsers to download it as filename. Range requests are supported when r is
+	// an io.ReadSeeker
+	Attachment(r io.Reader, filename string) error
+
+	// Inline sends the contents of r with a content disposition which causes
+	// browsers to display it, using filename if it is saved
+	Inline(r io.Reader, filename string) error
+
+	// Stream writes the status code and headers immediately and then copies r
+	// directly to the client, flushing every StreamFlushInterval. Unlike other
+	// writes the body is not buffered so large responses do not consume memory.
+	// Headers cannot be changed once Stream has been called
+	Stream(status int, contentType string, r io.Reader) error
+
+	// WriteJSONStream writes the status code and headers immediately and then
+	// encodes v directly to the client. Slices, arrays, and receive channels are
+	// encoded one element at a time as a JSON array so large responses are never
+	// held in memory. Channels are read until they are closed or the request is
+	// cancelled. Headers cannot be changed once WriteJSONStream has been called
+	WriteJSONStream(status int, v interface{}) error
+
+	// Unbuffer switches the response to streaming mode so the status code and
+	// headers are sent by the next write and every write goes straight to the
+	// client. It is useful for long downloads and proxies. See Unbuffered to
+	// stream every response of a handler
+	Unbuffer() error
+
+	// SSE begins a server-sent event stream by sending the event stream headers to
+	// the client. The EventStream must be closed before the handler returns
+	SSE() (*EventStream, error)
+
+	// Upgrade upgrades the request to a websocket connection using the Router's
+	// Upgrader. Once upgraded nothing can be written to the response. Failed
+	// handshakes return an error which should be returned by the handler
+	Upgrade() (WebSocket, error)
+
 	// WriteStatus is an alias to c.Response().WriteHeader(status)
 	WriteStatus(status int) error
 
+	// Status writes only the status code with no response body
+	Status(status int) error
+
+	// NoContent writes only the status code http.StatusNoContent
+	NoContent() error
+
+	// ClientIP returns the IP address of the client. Proxy headers are only used when
+	// the request was sent by one of the Router's TrustedProxies
+	ClientIP() string
+
+	// ClientCertificate returns the verified TLS certificate of the client, which
+	// includes its subject and SANs, or nil when the client did not present one.
+	// See MutualTLSConfig
+	ClientCertificate() *x509.Certificate
+
+	// BaseURL returns the scheme and host used by the client, e.g.
+	// https://example.com. X-Forwarded-Proto, X-Forwarded-Host, and Forwarded are
+	// only used when the request was sent by one of the Router's TrustedProxies
+	BaseURL() string
+
+	// FullURL returns the absolute URL requested by the client including the query
+	// string. See BaseURL
+	FullURL() string
+
+	// Set stores v under key for the lifetime of the request so that middleware can
+	// pass data such as the authenticated user to handlers
+	Set(key string, v interface{})
+
+	// Get returns the value stored under key by Set and whether it exists
+	Get(key string) (interface{}, bool)
+
+	// Logger returns the Logger of the Router which handled the request
+	Logger() Logger
+
 	// URLParams returns all params as a key/value pair for quick lookups
 	URLParams() httprouter.Params
 
+	// Param returns the value of the named URL parameter or an empty string
+	Param(name string) string
+
+	// ParamInt returns the named URL parameter as an int. A ValidationError with a
+	// status of 400 is returned when the parameter is missing or not an int
+	ParamInt(name string) (int, error)
+
+	// ParamInt64 returns the named URL parameter as an int64. A ValidationError with
+	// a status of 400 is returned when the parameter is missing or not an int64
+	ParamInt64(name string) (int64, error)
+
+	// ParamBool returns the named URL parameter as a bool. A ValidationError with a
+	// status of 400 is returned when the parameter is missing or not a bool
+	ParamBool(name string) (bool, error)
+
 	// ReadURLParams maps all URL parameters to struct fields of v and returns
 	// a validation error if there are any type mismatches
 	ReadURLParams(v interface{}) error
@@ -58,7 +289,12 @@ func NewContext(r *http.Request, w http.ResponseWriter, ps httprouter.Params) Co
 }
 
 func newContext(r *http.Request, w http.ResponseWriter, ps httprouter.Params) *requestContext {
+	var rtr *Router
+	if r != nil {
+		rtr, _ = r.Context().Value(routerContextKey{}).(*Router)
+	}
 	return &requestContext{
+		router:     rtr,
 		response:   NewBufferedResponseWriter(w),
 		request:    r,
 		urlParams:  ps,
@@ -67,16 +303,73 @@ func newContext(r *http.Request, w http.ResponseWriter, ps httprouter.Params) *r
 }
 
 type requestContext struct {
+	router     *Router
 	response   ResponseWriter
 	request    *http.Request
 	urlParams  httprouter.Params
+	pattern    string
 	formParser *schema.Decoder
+	storeMu    sync.RWMutex
+	store      map[string]interface{}
+	deferMu    sync.Mutex
+	deferred   []func(context.Context) error
+	// clientCtx is the context of the request served by the Router which is canceled
+	// when the client closes the connection
+	clientCtx context.Context
+	// pooled contexts are returned to contextPool once their request completes and
+	// released is set until they are reused. See Router.PoolContexts
+	pooled   bool
+	released bool
 }
 
 func (r *requestContext) Context() context.Context {
 	return r.Request().Context()
 }
 
+func (r *requestContext) Ctx() context.Context {
+	return r.request.Context()
+}
+
+func (r *requestContext) WithTimeout(d time.Duration) context.CancelFunc {
+	ctx, cancel := context.WithTimeout(r.request.Context(), d)
+	r.request = r.request.WithContext(ctx)
+	return cancel
+}
+
+func (r *requestContext) WithCancel() context.CancelFunc {
+	ctx, cancel := context.WithCancel(r.request.Context())
+	r.request = r.request.WithContext(ctx)
+	return cancel
+}
+
+func (r *requestContext) Set(key string, v interface{}) {
+	r.storeMu.Lock()
+	defer r.storeMu.Unlock()
+	if r.store == nil {
+		r.store = make(map[string]interface{})
+	}
+	r.store[key] = v
+}
+
+func (r *requestContext) Get(key string) (interface{}, bool) {
+	r.storeMu.RLock()
+	defer r.storeMu.RUnlock()
+	v, ok := r.store[key]
+	return v, ok
+}
+
+func (r *requestContext) Bind(v interface{}) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("could not bind request: %+v", errNotAStruct)
+	}
+	return bindRequest(val.Elem(), r)
+}
+
+func (r *requestContext) RoutePattern() string {
+	return r.pattern
+}
+
 func (r *requestContext) ReadURLParams(v interface{}) error {
 	return bind.Params(v, r.URLParams())
 }
@@ -90,16 +383,29 @@ func (r *requestContext) WriteStatus(status int) error {
 	return nil
 }
 
+func (r *requestContext) Status(status int) error {
+	return r.WriteStatus(status)
+}
+
+func (r *requestContext) NoContent() error {
+	return r.WriteStatus(http.StatusNoContent)
+}
+
 func (r *requestContext) Request() *http.Request {
+	r.mustNotBeReleased()
 	return r.request
 }
 
 func (r *requestContext) Response() ResponseWriter {
+	r.mustNotBeReleased()
 	return r.response
 }
 
 func (r *requestContext) ReadJSON(v interface{}) error {
-	if err := json.NewDecoder(r.Request().Body).Decode(v); err != nil {
+	if err := r.codec().Decode(r.Request().Body, v); err != nil {
+		if errors.Is(err, ErrRequestEntityTooLarge) {
+			return ErrRequestEntityTooLarge
+		}
 		return NewValidationError(bodyField, fmt.Errorf("failed to parse JSON body: %v", err))
 	}
 	return nil
@@ -107,6 +413,9 @@ func (r *requestContext) ReadJSON(v interface{}) error {
 
 func (r *requestContext) ReadForm(v interface{}) error {
 	if err := r.Request().ParseForm(); err != nil {
+		if errors.Is(err, ErrRequestEntityTooLarge) {
+			return ErrRequestEntityTooLarge
+		}
 		return NewValidationError(bodyField, err)
 	}
 
@@ -117,14 +426,79 @@ func (r *requestContext) ReadForm(v interface{}) error {
 }
 
 func (r *requestContext) WriteJSON(status int, v interface{}) error {
+	if r.router != nil && r.router.ValidateResponses {
+		if err := validateResponse(v); err != nil {
+			r.Logger().Error("handler wrote an invalid response",
+				"method", r.Request().Method,
+				"path", r.Request().URL.Path,
+				"error", err,
+			)
+			return fmt.Errorf("response failed validation: %v", err)
+		}
+	}
 	r.response.Header().Set("content-type", "application/json")
 	r.response.WriteHeader(status)
-	if err := json.NewEncoder(r.Response()).Encode(v); err != nil {
+	if err := r.codec().Encode(r.Response(), v); err != nil {
 		return fmt.Errorf("could not encode JSON response: %+v", err)
 	}
 	return nil
 }
 
+func (r *requestContext) WriteJSONP(status int, callback string, v interface{}) error {
+	if !jsonpCallbackPattern.MatchString(callback) {
+		return NewHTTPError(http.StatusBadRequest, fmt.Errorf("invalid JSONP callback %q", callback))
+	}
+	b, err := marshal(r.codec(), v)
+	if err != nil {
+		return fmt.Errorf("could not encode JSONP response: %+v", err)
+	}
+	r.response.Header().Set("x-content-type-options", "nosniff")
+	// the leading comment prevents the response from being interpreted as a flash file
+	body := make([]byte, 0, len(b)+len(callback)+8)
+	body = append(body, "/**/"+callback+"("...)
+	body = append(body, b...)
+	body = append(body, ");"...)
+	return r.Write(status, contentTypeJavaScript, body)
+}
+
+func (r *requestContext) WriteXML(status int, v interface{}) error {
+	r.response.Header().Set("content-type", contentTypeXML)
+	r.response.WriteHeader(status)
+	if _, err := io.WriteString(r.Response(), xml.Header); err != nil {
+		return fmt.Errorf("could not write XML response: %+v", err)
+	}
+	if err := xml.NewEncoder(r.Response()).Encode(v); err != nil {
+		return fmt.Errorf("could not encode XML response: %+v", err)
+	}
+	return nil
+}
+
+func (r *requestContext) WriteString(status int, s string) error {
+	return r.Write(status, contentTypeText, []byte(s))
+}
+
+func (r *requestContext) Write(status int, contentType string, b []byte) error {
+	r.response.Header().Set("content-type", contentType)
+	r.response.WriteHeader(status)
+	if _, err := r.response.Write(b); err != nil {
+		return fmt.Errorf("could not write response: %+v", err)
+	}
+	return nil
+}
+
+// ErrInvalidRedirectCode is returned by Context.Redirect when the status code is not
+// a 3xx redirection status. The returned error wraps it so use errors.Is to match it
+var ErrInvalidRedirectCode = errors.New("invalid redirect status code")
+
+func (r *requestContext) Redirect(status int, url string) error {
+	if status < http.StatusMultipleChoices || status > http.StatusPermanentRedirect {
+		return fmt.Errorf("%w: %d", ErrInvalidRedirectCode, status)
+	}
+	r.response.Header().Set("location", url)
+	r.response.WriteHeader(status)
+	return nil
+}
+
 func (r *requestContext) ReadQuery(v interface{}) error {
 	if err := bind.Query(v, r.Request().URL.Query()); err != nil {
 		return NewValidationError(queryField, err)
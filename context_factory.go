@@ -0,0 +1,87 @@
+package boar
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ContextFactory creates the Context of each request. See Router.SetContextFactory
+type ContextFactory func(*http.Request, http.ResponseWriter, httprouter.Params) Context
+
+// routerContextKey is the key of the Router in the context of requests given to a
+// ContextFactory so that Contexts created by NewContext use the Router's settings
+type routerContextKey struct{}
+
+// SetContextFactory sets the factory used to create the Context of every request so that
+// applications can supply an extended Context, such as one with typed accessors for
+// session data, which handlers and middlewares can assert to. Extended Contexts should
+// embed a Context created by NewContext with the arguments of the factory and return it
+// from an Unwrap() Context method so that settings of the Router such as Renderer,
+// ErrorMode, and ErrorTemplate apply to them. Context must be embedded through an alias
+// because its field name would collide with the Context method.
+//
+// Example:
+//
+//	type BoarContext = boar.Context
+//
+//	type AppContext struct {
+//		BoarContext
+//	}
+//
+//	func (c *AppContext) Unwrap() boar.Context { return c.BoarContext }
+//
+//	func (c *AppContext) Session() *Session {
+//		s, _ := c.Get("session")
+//		return s.(*Session)
+//	}
+//
+//	rtr.SetContextFactory(func(r *http.Request, w http.ResponseWriter, ps httprouter.Params) boar.Context {
+//		return &AppContext{BoarContext: boar.NewContext(r, w, ps)}
+//	})
+func (rtr *Router) SetContextFactory(factory ContextFactory) {
+	rtr.root().contextFactory = factory
+}
+
+// NewContext creates the Context for a request exactly as rtr does when it serves one, so
+// that its ContextFactory, bases, and settings apply. It allows handlers to be tested
+// without serving the request. See ServeHandler
+func (rtr *Router) NewContext(r *http.Request, w http.ResponseWriter, ps httprouter.Params) Context {
+	return rtr.newContext(r, w, ps)
+}
+
+// newContext creates the Context of a request handled by rtr
+func (rtr *Router) newContext(r *http.Request, w http.ResponseWriter, ps httprouter.Params) Context {
+	root := rtr.root()
+	if root.contextFactory != nil {
+		r = r.WithContext(context.WithValue(r.Context(), routerContextKey{}, root))
+		return root.contextFactory(r, w, ps)
+	}
+	if root.PoolContexts {
+		return acquireContext(root, r, w, ps)
+	}
+	c := newContext(r, w, ps)
+	c.router = root
+	return c
+}
+
+// routerOf returns the Router which created c or nil when it is not known
+func routerOf(c Context) *Router {
+	if rc := requestContextOf(c); rc != nil {
+		return rc.router
+	}
+	return nil
+}
+
+// requestContextOf returns the Context created by NewContext which c is or wraps. Contexts
+// of a ContextFactory are unwrapped with their Unwrap method
+func requestContextOf(c Context) *requestContext {
+	switch rc := c.(type) {
+	case *requestContext:
+		return rc
+	case interface{ Unwrap() Context }:
+		return requestContextOf(rc.Unwrap())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+// baseContext allows Context to be embedded despite its Context method
+type baseContext = Context
+
+type appContext struct {
+	baseContext
+	tenant string
+}
+
+func (c *appContext) Unwrap() Context { return c.baseContext }
+
+func newAppRouter() *Router {
+	r := NewRouter()
+	r.SetContextFactory(func(req *http.Request, w http.ResponseWriter, ps httprouter.Params) Context {
+		return &appContext{baseContext: NewContext(req, w, ps), tenant: req.Header.Get("X-Tenant")}
+	})
+	return r
+}
+
+func TestSetContextFactoryShouldCreateContexts(t *testing.T) {
+	r := newAppRouter()
+	r.Group("/api").MethodFunc(http.MethodGet, "/tenant", func(c Context) error {
+		return c.WriteString(http.StatusOK, c.(*appContext).tenant)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tenant", nil)
+	req.Header.Set("X-Tenant", "acme")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme", rec.Body.String())
+}
+
+func TestSetContextFactoryShouldKeepRouterSettings(t *testing.T) {
+	r := newAppRouter()
+	r.ResponseStatus = http.StatusAccepted
+	r.Get("/", func(c Context) (Handler, error) {
+		assert.Equal(t, r, routerOf(c))
+		return &responseHandler{}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"name":"boar"}`, rec.Body.String())
+}
+
+func TestRouterOfShouldBeNilForUnknownContexts(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	assert.Nil(t, routerOf(c))
+	assert.Nil(t, routerOf(struct{ baseContext }{c}))
+}
+
+func TestRouterNewContextShouldUseFactoryAndSettings(t *testing.T) {
+	r := newAppRouter()
+	r.ResponseStatus = http.StatusAccepted
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Tenant", "acme")
+	rec := httptest.NewRecorder()
+
+	c := r.NewContext(req, rec, nil)
+	assert.Equal(t, "acme", c.(*appContext).tenant)
+	assert.Equal(t, r, routerOf(c))
+
+	assert.NoError(t, ServeHandler(c, &responseHandler{}))
+	c.Response().Flush()
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"name":"boar"}`, rec.Body.String())
+}
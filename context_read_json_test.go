@@ -55,3 +55,18 @@ func TestReadJSONSetsBadRequestStatusIfJSONIsInvalid(t *testing.T) {
 	err := c.ReadJSON(&req).(HTTPError)
 	assert.Equal(t, http.StatusBadRequest, err.Status())
 }
+
+type tooLargeReader struct{}
+
+func (tooLargeReader) Read([]byte) (int, error) {
+	return 0, ErrRequestEntityTooLarge
+}
+
+func TestReadJSONReturnsRequestEntityTooLargeFromBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", tooLargeReader{})
+
+	c := NewContext(r, nil, nil)
+
+	var req json.RawMessage
+	assert.Equal(t, ErrRequestEntityTooLarge, c.ReadJSON(&req))
+}
@@ -2,10 +2,17 @@ package boar
 
 import (
 	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
 	"io"
+	"io/ioutil"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/julienschmidt/httprouter"
@@ -183,3 +190,278 @@ func TestReadQueryShouldReturnValidationErrorIfQueryTypesMismatch(t *testing.T)
 	err = c.ReadQuery(&fields)
 	require.IsType(t, &ValidationError{}, err)
 }
+
+func TestWriteJSONFailsInvalidResponsesWhenValidateResponses(t *testing.T) {
+	r := NewRouter()
+	r.ValidateResponses = true
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	type user struct {
+		Email string `validate:"required,email"`
+	}
+
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteJSON(http.StatusOK, []user{{Email: "a@b.com"}, {Email: "nope"}})
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Email")
+}
+
+func TestWriteJSONDoesNotValidateResponsesByDefault(t *testing.T) {
+	r := NewRouter()
+
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteJSON(http.StatusOK, struct {
+			Email string `validate:"required"`
+		}{})
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestWriteXMLSetsContentTypeAndHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	type user struct {
+		Name string `xml:"name"`
+	}
+
+	require.NoError(t, c.WriteXML(http.StatusCreated, user{Name: "brett"}))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/xml", w.Header().Get("content-type"))
+	assert.Equal(t, xml.Header+"<user><name>brett</name></user>", w.Body.String())
+}
+
+func TestWriteXMLReturnsErrorWhenXMLEncodeFails(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	err := c.WriteXML(http.StatusOK, make(chan int))
+	assert.Error(t, err)
+}
+
+func TestWriteStringWritesPlainText(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	require.NoError(t, c.WriteString(http.StatusAccepted, "hello"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("content-type"))
+	assert.Equal(t, "hello", w.Body.String())
+}
+
+func TestWriteSetsContentType(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	require.NoError(t, c.Write(http.StatusOK, "image/png", []byte{0x89, 0x50}))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, "image/png", w.Header().Get("content-type"))
+	assert.Equal(t, []byte{0x89, 0x50}, w.Body.Bytes())
+}
+
+func TestWriteReturnsErrorWhenWriteFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	w := NewMockResponseWriter(ctrl)
+	w.EXPECT().Header().Return(http.Header{})
+	w.EXPECT().WriteHeader(http.StatusOK)
+	w.EXPECT().Write(gomock.Any()).Return(0, io.ErrClosedPipe)
+
+	c := newContext(nil, nil, nil)
+	c.response = w
+	err := c.Write(http.StatusOK, "text/csv", []byte("a,b"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), io.ErrClosedPipe.Error())
+}
+
+func TestRedirectSetsLocationAndStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	require.NoError(t, c.Redirect(http.StatusSeeOther, "/login"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/login", w.Header().Get("location"))
+}
+
+func TestRedirectErrorsForNonRedirectStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	err := c.Redirect(http.StatusOK, "/login")
+	assert.True(t, errors.Is(err, ErrInvalidRedirectCode))
+	assert.Equal(t, "invalid redirect status code: 200", err.Error())
+	assert.Empty(t, w.Header().Get("location"))
+	assert.Equal(t, 0, c.Response().Status())
+}
+
+func TestNoContentIsFlushedByTheRouter(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodDelete, "/", func(c Context) error {
+		return c.NoContent()
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+}
+
+func TestStatusWritesOnlyTheStatus(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodPost, "/", func(c Context) error {
+		return c.Status(http.StatusAccepted)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+}
+
+func TestSetShouldStoreValuesForGet(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	_, ok := c.Get("user")
+	assert.False(t, ok)
+
+	c.Set("user", "brett")
+	v, ok := c.Get("user")
+	assert.True(t, ok)
+	assert.Equal(t, "brett", v)
+}
+
+func TestSetShouldPassValuesFromMiddlewareToHandlers(t *testing.T) {
+	r := NewRouter()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			c.Set("tenant", 42)
+			return next(c)
+		}
+	})
+	var tenant interface{}
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		tenant, _ = c.Get("tenant")
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, 42, tenant)
+}
+
+func TestWithTimeoutShouldReplaceTheRequestContext(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	cancel := c.WithTimeout(time.Millisecond)
+	defer cancel()
+
+	_, ok := c.Ctx().Deadline()
+	assert.True(t, ok)
+	<-c.Request().Context().Done()
+	assert.Equal(t, context.DeadlineExceeded, c.Ctx().Err())
+}
+
+func TestWithCancelShouldPropagateToHandlers(t *testing.T) {
+	r := NewRouter()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			cancel := c.WithCancel()
+			cancel()
+			return next(c)
+		}
+	})
+	called := false
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		called = true
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.False(t, called)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestBindShouldPopulateAllRequestFields(t *testing.T) {
+	var req struct {
+		Query struct {
+			Page int `query:"page"`
+		}
+		URLParams struct {
+			ID int `url:"id"`
+		}
+		Header struct {
+			RequestID string `header:"X-Request-Id"`
+		}
+		Body struct {
+			Name string `json:"name" validate:"required"`
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/?page=2", bytes.NewBufferString(`{"name":"boar"}`))
+	r.Header.Set("content-type", contentTypeJSON)
+	r.Header.Set("x-request-id", "abc")
+	c := NewContext(r, httptest.NewRecorder(), httprouter.Params{{Key: "id", Value: "7"}})
+
+	require.NoError(t, c.Bind(&req))
+	assert.Equal(t, 2, req.Query.Page)
+	assert.Equal(t, 7, req.URLParams.ID)
+	assert.Equal(t, "abc", req.Header.RequestID)
+	assert.Equal(t, "boar", req.Body.Name)
+}
+
+func TestBindShouldValidate(t *testing.T) {
+	var req struct {
+		Header struct {
+			RequestID string `header:"X-Request-Id" validate:"required"`
+		}
+	}
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	err := c.Bind(&req)
+	require.IsType(t, &ValidationError{}, err)
+}
+
+func TestBindShouldRequireAStructPointer(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	assert.Error(t, c.Bind(struct{}{}))
+}
+
+func TestWriteJSONPShouldWrapJSONInCallback(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	require.NoError(t, c.WriteJSONP(http.StatusOK, "jQuery.cb_1", JSON{"a": 1}))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, contentTypeJavaScript, w.Header().Get("content-type"))
+	assert.Equal(t, "nosniff", w.Header().Get("x-content-type-options"))
+	assert.Equal(t, `/**/jQuery.cb_1({"a":1});`, w.Body.String())
+}
+
+func TestWriteJSONPShouldRejectInvalidCallbacks(t *testing.T) {
+	for _, cb := range []string{"", "alert(1)//", "a b", "1abc", "a..b"} {
+		c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+		err := c.WriteJSONP(http.StatusOK, cb, nil)
+		require.Implements(t, (*HTTPError)(nil), err, cb)
+		assert.Equal(t, http.StatusBadRequest, err.(HTTPError).Status())
+	}
+}
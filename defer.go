@@ -0,0 +1,86 @@
+package boar
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// DefaultDeferTimeout is the timeout of funcs given to Context.Defer when the Router has
+// no DeferTimeout
+const DefaultDeferTimeout = time.Minute
+
+func (r *requestContext) Defer(fn func(ctx context.Context) error) {
+	r.deferMu.Lock()
+	defer r.deferMu.Unlock()
+	r.deferred = append(r.deferred, fn)
+}
+
+// takeDeferred returns the deferred funcs and clears them so that they run only once
+func (r *requestContext) takeDeferred() []func(context.Context) error {
+	r.deferMu.Lock()
+	defer r.deferMu.Unlock()
+	fns := r.deferred
+	r.deferred = nil
+	return fns
+}
+
+// runDeferred runs the funcs deferred with c on a new goroutine. Errors are logged and
+// reported to the OnError hooks of rtr. c is released once the funcs complete since they
+// may use it
+func (rtr *Router) runDeferred(c Context) {
+	rc := requestContextOf(c)
+	if rc == nil {
+		return
+	}
+	fns := rc.takeDeferred()
+	if len(fns) == 0 {
+		rc.release()
+		return
+	}
+
+	timeout := rtr.root().DeferTimeout
+	if timeout <= 0 {
+		timeout = DefaultDeferTimeout
+	}
+	ctx := detachedContext{c.Context()}
+	method, path := c.Request().Method, c.Request().URL.Path
+	go func() {
+		for _, fn := range fns {
+			if err := runDeferredFunc(ctx, timeout, fn); err != nil {
+				c.Logger().Error("deferred func failed",
+					"method", method,
+					"path", path,
+					"error", err,
+				)
+				rtr.reportError(c, err)
+			}
+		}
+		rc.release()
+	}()
+}
+
+// runDeferredFunc runs fn with a timeout and returns its panic as a PanicError
+func runDeferredFunc(ctx context.Context, timeout time.Duration, fn func(context.Context) error) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = NewPanicError(rec, debug.Stack())
+		}
+	}()
+	return fn(ctx)
+}
+
+// detachedContext has the values of its parent but is never canceled with it
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
@@ -0,0 +1,81 @@
+package boar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deferKey struct{}
+
+func TestDeferShouldRunAfterResponseIsSent(t *testing.T) {
+	done := make(chan string, 2)
+	r := NewRouter()
+	r.DeferTimeout = time.Second
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		c.Defer(func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+			done <- ctx.Value(deferKey{}).(string)
+			return nil
+		})
+		c.Defer(func(ctx context.Context) error {
+			done <- "second"
+			return nil
+		})
+		return c.WriteString(http.StatusOK, "sent")
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), deferKey{}, "first"))
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	cancel()
+
+	assert.Equal(t, "sent", rec.Body.String())
+	assert.Equal(t, "first", <-done)
+	assert.Equal(t, "second", <-done)
+}
+
+func TestDeferShouldReportErrorsAndPanics(t *testing.T) {
+	reported := make(chan error, 2)
+	r := NewRouter()
+	r.Logger = NopLogger
+	r.OnError(func(c Context, err error) {
+		reported <- err
+	})
+	failed := errors.New("webhook failed")
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		c.Defer(func(context.Context) error {
+			return failed
+		})
+		c.Defer(func(context.Context) error {
+			panic("cache warm failed")
+		})
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, failed, <-reported)
+	err := <-reported
+	require.IsType(t, &PanicError{}, err)
+	assert.Equal(t, "cache warm failed", err.Error())
+}
+
+func TestDetachedContextShouldNotBeCanceled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), deferKey{}, "v"))
+	cancel()
+
+	ctx := detachedContext{parent}
+	assert.NoError(t, ctx.Err())
+	assert.Nil(t, ctx.Done())
+	assert.Equal(t, "v", ctx.Value(deferKey{}))
+}
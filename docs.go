@@ -0,0 +1,85 @@
+package boar
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// SwaggerUIAssets are the stylesheet and script of Swagger UI loaded by the page of
+// ServeDocs. When an integrity hash is set the browser refuses to run a file which does
+// not match it
+type SwaggerUIAssets struct {
+	CSSURL string
+	// CSSIntegrity is the Subresource Integrity hash of CSSURL such as sha384-...
+	CSSIntegrity string
+	JSURL        string
+	// JSIntegrity is the Subresource Integrity hash of JSURL such as sha384-...
+	JSIntegrity string
+}
+
+// SwaggerUI is the Swagger UI loaded by the page of ServeDocs. The default pins a release
+// of swagger-ui-dist on unpkg.com. Point the URLs at copies served by the application to
+// avoid depending on a CDN, or set the integrity hashes of the release which is used.
+//
+// Example:
+//
+//	boar.SwaggerUI = boar.SwaggerUIAssets{
+//		CSSURL: "/static/swagger-ui.css",
+//		JSURL:  "/static/swagger-ui-bundle.js",
+//	}
+var SwaggerUI = SwaggerUIAssets{
+	CSSURL: "https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css",
+	JSURL:  "https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js",
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="{{.Assets.CSSURL}}"{{with .Assets.CSSIntegrity}} integrity="{{.}}" crossorigin="anonymous"{{end}}>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.Assets.JSURL}}"{{with .Assets.JSIntegrity}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+<script>
+window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`))
+
+// ServeDocs serves the OpenAPI document of the Router at prefix/openapi.json and a Swagger
+// UI page for it at prefix so that API documentation ships with the binary. The page loads
+// Swagger UI from SwaggerUI. mw are added to both routes, which are not documented
+// themselves.
+//
+// Example:
+//
+//	rtr.ServeDocs("/docs", boar.OpenAPIInfo{Title: "Users API", Version: "1.0.0"})
+func (rtr *Router) ServeDocs(prefix string, info OpenAPIInfo, mw ...Middleware) {
+	if strings.Trim(prefix, "/") == "" {
+		panic("ServeDocs requires a prefix such as /docs")
+	}
+	docs := rtr.Group(prefix, mw...)
+	specURL := docs.prefix + "/openapi.json"
+
+	docs.MethodFunc(http.MethodGet, "/openapi.json", func(c Context) error {
+		return c.WriteJSON(http.StatusOK, rtr.OpenAPI(info))
+	}).hidden = true
+
+	docs.MethodFunc(http.MethodGet, "", func(c Context) error {
+		var buf bytes.Buffer
+		err := docsTemplate.Execute(&buf, struct {
+			Title   string
+			Assets  SwaggerUIAssets
+			SpecURL string
+		}{info.Title, SwaggerUI, specURL})
+		if err != nil {
+			return err
+		}
+		return c.Write(http.StatusOK, contentTypeHTML, buf.Bytes())
+	}).hidden = true
+}
@@ -0,0 +1,57 @@
+package boar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeDocsShouldServeSpecAndPage(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/health", func(Context) error { return nil })
+	r.Group("/api").ServeDocs("/docs", OpenAPIInfo{Title: "Health", Version: "1"})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Len(t, spec.Paths, 1)
+	assert.Contains(t, spec.Paths, "/health")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), `<title>Health</title>`)
+	assert.Contains(t, rec.Body.String(), `url: "/api/docs/openapi.json"`)
+	assert.Contains(t, rec.Body.String(), `src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"`)
+}
+
+func TestServeDocsShouldRequirePrefix(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouter().ServeDocs("/", OpenAPIInfo{})
+	})
+}
+
+func TestServeDocsShouldLoadConfiguredSwaggerUI(t *testing.T) {
+	defer func(a SwaggerUIAssets) { SwaggerUI = a }(SwaggerUI)
+	SwaggerUI = SwaggerUIAssets{
+		CSSURL:       "/static/swagger-ui.css",
+		CSSIntegrity: "sha384-css",
+		JSURL:        "/static/swagger-ui-bundle.js",
+	}
+	r := NewRouter()
+	r.ServeDocs("/docs", OpenAPIInfo{Title: "Health"})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Contains(t, rec.Body.String(), `<link rel="stylesheet" href="/static/swagger-ui.css" integrity="sha384-css" crossorigin="anonymous">`)
+	assert.Contains(t, rec.Body.String(), `<script src="/static/swagger-ui-bundle.js"></script>`)
+}
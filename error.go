@@ -18,6 +18,10 @@ var (
 	// ErrNotFound is an HTTPError for StatusNotFound
 	ErrNotFound = NewHTTPErrorStatus(http.StatusNotFound)
 
+	// ErrMethodNotAllowed is an HTTPError for StatusMethodNotAllowed. Use WithHeader to set
+	// the Allow header
+	ErrMethodNotAllowed = NewHTTPErrorStatus(http.StatusMethodNotAllowed)
+
 	// ErrNotAcceptable is an HTTPError for StatusNotAcceptable
 	ErrNotAcceptable = NewHTTPErrorStatus(http.StatusNotAcceptable)
 
@@ -30,6 +34,11 @@ var (
 	// ErrTooManyRequests is an HTTPError for StatusTooManyRequests
 	ErrTooManyRequests = NewHTTPErrorStatus(http.StatusTooManyRequests)
 
+	// ErrRequestEntityTooLarge is an HTTPError for StatusRequestEntityTooLarge. Request
+	// body readers may return it when a body exceeds its limit and it is returned from
+	// the request parser as is rather than as a ValidationError
+	ErrRequestEntityTooLarge = NewHTTPErrorStatus(http.StatusRequestEntityTooLarge)
+
 	// ErrEntityNotFound should be used to provide a more valuable 404 error
 	// message to the client. Simply sending 404 with no body to the client
 	// is confusing because it is not clear what was not found. Was the path
@@ -50,13 +59,23 @@ type HTTPError interface {
 type httpError struct {
 	status int
 	cause  error
+	code   string
+	// statusOnly errors are created by NewHTTPErrorStatus and match every error with
+	// the same status with errors.Is
+	statusOnly bool
 }
 
 // NewHTTPErrorStatus creates a new HTTP Error with the given status code and
 // uses the default status text for that status code. These are useful for concise
-// errors such as "Forbidden" or "Unauthorized"
+// errors such as "Forbidden" or "Unauthorized". Every HTTPError with the same status
+// matches these errors with errors.Is so that errors.Is(err, ErrNotFound) is true for
+// any 404
 func NewHTTPErrorStatus(status int) error {
-	return NewHTTPError(status, fmt.Errorf(http.StatusText(status)))
+	return &httpError{
+		status:     status,
+		cause:      errors.New(http.StatusText(status)),
+		statusOnly: true,
+	}
 }
 
 // NewHTTPError creates a new HTTPError that will be marshaled to the requestor
@@ -67,6 +86,31 @@ func NewHTTPError(status int, cause error) HTTPError {
 	}
 }
 
+// NewHTTPErrorCode creates a new HTTPError with an application error code. The code is
+// written to the client alongside the error so that clients can branch on stable codes
+// rather than parsing messages.
+//
+// Example:
+//
+//	return boar.NewHTTPErrorCode(http.StatusForbidden, "USER_SUSPENDED", err)
+func NewHTTPErrorCode(status int, code string, cause error) HTTPError {
+	return &httpError{
+		status: status,
+		cause:  cause,
+		code:   code,
+	}
+}
+
+// ErrorCode returns the application error code of err or of any error it wraps. An empty
+// string is returned when there is no code. See NewHTTPErrorCode
+func ErrorCode(err error) string {
+	var coder interface{ Code() string }
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+	return ""
+}
+
 // Status returns the status code to be used with this error
 func (h *httpError) Status() int {
 	return h.status
@@ -76,15 +120,40 @@ func (h *httpError) Cause() error {
 	return h.cause
 }
 
+// Code returns the application error code or an empty string when there is none
+func (h *httpError) Code() string {
+	return h.code
+}
+
+// Unwrap returns the cause of the error for errors.Is and errors.As
+func (h *httpError) Unwrap() error {
+	return h.cause
+}
+
+// Is reports whether target is an error created by NewHTTPErrorStatus with the same status
+func (h *httpError) Is(target error) bool {
+	return statusIs(h.status, target)
+}
+
+// statusIs reports whether target is an error created by NewHTTPErrorStatus for status
+func statusIs(status int, target error) bool {
+	t, ok := target.(*httpError)
+	return ok && t.statusOnly && t.status == status
+}
+
 func (h *httpError) Error() string {
 	return fmt.Sprintf("HTTPError: (status: %d, error: %s)", h.Status(), h.Cause())
 }
 
 // MarshalJSON marshals this error to JSON
 func (h *httpError) MarshalJSON() ([]byte, error) {
-	return json.Marshal(JSON{
+	body := JSON{
 		"error": h.cause.Error(),
-	})
+	}
+	if h.code != "" {
+		body["code"] = h.code
+	}
+	return json.Marshal(body)
 }
 
 // ValidationError is an HTTPError that was caused by validation. Validation
@@ -111,9 +180,56 @@ func (h *httpError) MarshalJSON() ([]byte, error) {
 type ValidationError struct {
 	fieldName string
 	status    int
+	fields    []FieldError
 	Errors    []error
 }
 
+// MarshalValidationError customizes the JSON of ValidationErrors. When it is set, the value
+// it returns is marshaled in place of the default {"errors": {...}} document.
+// ValidationErrorsByField can be used to key messages by field.
+//
+// Example:
+//
+//	boar.MarshalValidationError = func(e *boar.ValidationError) interface{} {
+//		return boar.JSON{"code": "INVALID_" + strings.ToUpper(e.FieldName()), "fields": e.FieldErrors()}
+//	}
+var MarshalValidationError func(*ValidationError) interface{}
+
+// ValidationErrorsByField is a MarshalValidationError which keys the messages of
+// ValidationErrors caused by valid tags by the json name of the offending struct field.
+// This makes it simple for frontends to map messages to form inputs. Errors without
+// FieldErrors are marshaled as a list of messages like the default document.
+//
+// Example:
+//
+//	boar.MarshalValidationError = boar.ValidationErrorsByField
+//
+//	{"errors": {"body": {"email": ["must be a valid email"]}}}
+func ValidationErrorsByField(e *ValidationError) interface{} {
+	if len(e.fields) == 0 {
+		return e.document(e.messages())
+	}
+	fields := make(map[string][]string, len(e.fields))
+	for _, f := range e.fields {
+		fields[f.Field] = append(fields[f.Field], f.Message)
+	}
+	return e.document(fields)
+}
+
+// FieldError is a single validation failure of a struct field
+type FieldError struct {
+	// Field is the json path of the field such as address.zip
+	Field string `json:"field"`
+	// StructField is the namespace of the struct field such as Address.Zip
+	StructField string `json:"-"`
+	// Tag is the validation tag which failed such as required or min
+	Tag string `json:"tag"`
+	// Param is the parameter of the tag such as 3 for min=3
+	Param string `json:"param,omitempty"`
+	// Message is a human readable description of the failure
+	Message string `json:"message"`
+}
+
 var _ HTTPError = (*ValidationError)(nil)
 
 // NewValidationError creates a new Validation error with a single reason.
@@ -139,11 +255,36 @@ func (e *ValidationError) Status() int {
 	return e.status
 }
 
+// FieldName returns the area of the request which failed validation such as Body, Query,
+// URLParams, or Header
+func (e *ValidationError) FieldName() string {
+	return e.fieldName
+}
+
+// FieldErrors returns the failures of each struct field when they were reported by the
+// Validator. It is empty for errors which were not caused by validate tags
+func (e *ValidationError) FieldErrors() []FieldError {
+	return e.fields
+}
+
 // Cause is the underlying cause(s) of the validation error
 func (e *ValidationError) Cause() error {
 	return errors.New(e.Error())
 }
 
+// Unwrap returns the first of Errors for errors.Is and errors.As
+func (e *ValidationError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors[0]
+}
+
+// Is reports whether target is an error created by NewHTTPErrorStatus with the same status
+func (e *ValidationError) Is(target error) bool {
+	return statusIs(e.status, target)
+}
+
 func (e *ValidationError) Error() string {
 	s := make([]string, len(e.Errors))
 	for i, err := range e.Errors {
@@ -154,24 +295,43 @@ func (e *ValidationError) Error() string {
 
 // MarshalJSON allows overrides json.Marshal default behavior
 func (e *ValidationError) MarshalJSON() ([]byte, error) {
-	ers := make([]string, len(e.Errors))
-	for i, err := range e.Errors {
-		ers[i] = err.Error()
+	if MarshalValidationError != nil {
+		return json.Marshal(MarshalValidationError(e))
 	}
+	return json.Marshal(e.document(e.messages()))
+}
 
-	return json.Marshal(JSON{
+// document is the {"errors": {...}} document of e with messages under its field name
+func (e *ValidationError) document(messages interface{}) JSON {
+	return JSON{
 		"errors": JSON{
-			strings.ToLower(e.fieldName): ers,
+			strings.ToLower(e.fieldName): messages,
 		},
-	})
+	}
+}
+
+// messages returns the messages of Errors
+func (e *ValidationError) messages() []string {
+	ers := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		ers[i] = err.Error()
+	}
+	return ers
 }
 
 var _ HTTPError = (*PanicError)(nil)
 
+// PanicErrorStack includes the stack in the message returned by PanicError.Error. It is
+// disabled by default so that stacks do not leak through logs and encoders which use the
+// message. The stack is logged by the recovery middleware and is available to OnError
+// hooks through PanicError.Stack
+var PanicErrorStack = false
+
 // PanicError is an error caused by panic that was recovered
 type PanicError struct {
-	cause error
-	Stack []byte
+	cause        error
+	Stack        []byte
+	includeStack bool
 }
 
 // NewPanicError creates a new PanicError with the callstack provided. You can get the
@@ -195,12 +355,30 @@ func (p *PanicError) Cause() error {
 	return p.cause
 }
 
+// Unwrap returns the recovered error, or an error with its text, for errors.Is and
+// errors.As
+func (p *PanicError) Unwrap() error {
+	return p.cause
+}
+
+// Is reports whether target is an error created by NewHTTPErrorStatus with the same status
+func (p *PanicError) Is(target error) bool {
+	return statusIs(p.Status(), target)
+}
+
 func (p *PanicError) Error() string {
-	return fmt.Sprintf("%s\n%s", p.Cause(), string(p.Stack))
+	if PanicErrorStack {
+		return fmt.Sprintf("%s\n%s", p.Cause(), string(p.Stack))
+	}
+	return p.Cause().Error()
 }
 
 func (p *PanicError) MarshalJSON() ([]byte, error) {
-	return json.Marshal(JSON{
+	body := JSON{
 		"error": p.Cause().Error(),
-	})
+	}
+	if p.includeStack {
+		body["stack"] = string(p.Stack)
+	}
+	return json.Marshal(body)
 }
@@ -0,0 +1,69 @@
+package boar
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// errorf creates an HTTPError with status and a cause formatted with fmt.Errorf so that
+// errors wrapped with %w are still matched by errors.Is and errors.As
+func errorf(status int, format string, args ...interface{}) HTTPError {
+	return NewHTTPError(status, fmt.Errorf(format, args...))
+}
+
+// BadRequestf creates an HTTPError for StatusBadRequest with a formatted cause.
+//
+// Example:
+//
+//	return boar.BadRequestf("invalid sort field %q", h.Query.Sort)
+func BadRequestf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusBadRequest, format, args...)
+}
+
+// Unauthorizedf creates an HTTPError for StatusUnauthorized with a formatted cause
+func Unauthorizedf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusUnauthorized, format, args...)
+}
+
+// Forbiddenf creates an HTTPError for StatusForbidden with a formatted cause
+func Forbiddenf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusForbidden, format, args...)
+}
+
+// NotFoundf creates an HTTPError for StatusNotFound with a formatted cause
+func NotFoundf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusNotFound, format, args...)
+}
+
+// Conflictf creates an HTTPError for StatusConflict with a formatted cause
+func Conflictf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusConflict, format, args...)
+}
+
+// Gonef creates an HTTPError for StatusGone with a formatted cause
+func Gonef(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusGone, format, args...)
+}
+
+// UnprocessableEntityf creates an HTTPError for StatusUnprocessableEntity with a formatted
+// cause
+func UnprocessableEntityf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusUnprocessableEntity, format, args...)
+}
+
+// TooManyRequestsf creates an HTTPError for StatusTooManyRequests with a formatted cause
+func TooManyRequestsf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusTooManyRequests, format, args...)
+}
+
+// InternalServerErrorf creates an HTTPError for StatusInternalServerError with a formatted
+// cause
+func InternalServerErrorf(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusInternalServerError, format, args...)
+}
+
+// ServiceUnavailablef creates an HTTPError for StatusServiceUnavailable with a formatted
+// cause
+func ServiceUnavailablef(format string, args ...interface{}) HTTPError {
+	return errorf(http.StatusServiceUnavailable, format, args...)
+}
@@ -0,0 +1,39 @@
+package boar
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorConstructorsShouldSetStatusAndFormatCause(t *testing.T) {
+	tests := []struct {
+		fn     func(string, ...interface{}) HTTPError
+		status int
+	}{
+		{BadRequestf, http.StatusBadRequest},
+		{Unauthorizedf, http.StatusUnauthorized},
+		{Forbiddenf, http.StatusForbidden},
+		{NotFoundf, http.StatusNotFound},
+		{Conflictf, http.StatusConflict},
+		{Gonef, http.StatusGone},
+		{UnprocessableEntityf, http.StatusUnprocessableEntity},
+		{TooManyRequestsf, http.StatusTooManyRequests},
+		{InternalServerErrorf, http.StatusInternalServerError},
+		{ServiceUnavailablef, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		err := tt.fn("user %d: %s", 42, "nope")
+		assert.Equal(t, tt.status, err.Status())
+		assert.Equal(t, "user 42: nope", err.Cause().Error())
+		assert.True(t, errors.Is(err, NewHTTPErrorStatus(tt.status)))
+	}
+}
+
+func TestErrorConstructorsShouldWrapErrors(t *testing.T) {
+	err := ServiceUnavailablef("database: %w", io.ErrUnexpectedEOF)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+}
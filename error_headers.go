@@ -0,0 +1,83 @@
+package boar
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// headerError is an HTTPError with headers which are written by the default ErrorHandler
+// along with the error
+type headerError struct {
+	HTTPError
+	header http.Header
+}
+
+// Header returns the headers to be written with the error
+func (e *headerError) Header() http.Header {
+	return e.header
+}
+
+// Unwrap returns the wrapped HTTPError for errors.Is and errors.As
+func (e *headerError) Unwrap() error {
+	return e.HTTPError
+}
+
+// WithHeader returns an HTTPError which sets the header key to value when err is written by
+// the default ErrorHandler. Headers are written before the body of the error.
+//
+// Example:
+//
+//	return boar.WithHeader(boar.ErrUnauthorized, "WWW-Authenticate", `Bearer realm="api"`)
+func WithHeader(err error, key, value string) HTTPError {
+	header := make(http.Header, 1)
+	header.Set(key, value)
+	return WithHeaders(err, header)
+}
+
+// WithHeaders returns an HTTPError which sets each of header when err is written by the
+// default ErrorHandler. Headers of err, when it already has them, are kept unless they are
+// also in header.
+//
+// Example:
+//
+//	return boar.WithHeaders(boar.ErrMethodNotAllowed, http.Header{"Allow": {"GET, HEAD"}})
+func WithHeaders(err error, header http.Header) HTTPError {
+	return &headerError{HTTPError: toHTTPError(err), header: header}
+}
+
+// RetryAfter returns an HTTPError which sets the Retry-After header to d, rounded up to
+// whole seconds, when err is written by the default ErrorHandler. It tells clients of
+// rate limited or unavailable services how long to back off.
+//
+// Example:
+//
+//	return boar.RetryAfter(boar.ErrTooManyRequests, limiter.Reset())
+func RetryAfter(err error, d time.Duration) HTTPError {
+	return WithHeader(err, "Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
+
+// toHTTPError returns err as an HTTPError. Errors which are not HTTPErrors are server errors
+func toHTTPError(err error) HTTPError {
+	if httperr, ok := err.(HTTPError); ok {
+		return httperr
+	}
+	return NewHTTPError(http.StatusInternalServerError, err)
+}
+
+// writeErrorHeaders copies the headers of err, and of any error it wraps, to the response.
+// Headers of outer errors take precedence
+func writeErrorHeaders(c Context, err error) {
+	var h interface{ Header() http.Header }
+	written := make(map[string]bool)
+	for e := err; errors.As(e, &h); e = errors.Unwrap(h.(error)) {
+		for key, values := range h.Header() {
+			if !written[key] {
+				c.Response().Header()[key] = values
+				written[key] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package boar
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfterShouldBeWrittenByDefaultErrorHandler(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		retry  string
+	}{
+		{RetryAfter(ErrTooManyRequests, 30*time.Second), http.StatusTooManyRequests, "30"},
+		{RetryAfter(NewHTTPErrorStatus(http.StatusServiceUnavailable), 1500*time.Millisecond), http.StatusServiceUnavailable, "2"},
+		{fmt.Errorf("limited: %w", RetryAfter(ErrTooManyRequests, time.Minute)), http.StatusInternalServerError, "60"},
+		{RetryAfter(RetryAfter(ErrTooManyRequests, time.Second), time.Minute), http.StatusTooManyRequests, "60"},
+		{RetryAfter(errors.New("overloaded"), time.Second), http.StatusInternalServerError, "1"},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.MethodFunc(http.MethodGet, "/", func(Context) error {
+			return tt.err
+		})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, tt.status, rec.Code, tt.err.Error())
+		assert.Equal(t, tt.retry, rec.Header().Get("Retry-After"), tt.err.Error())
+	}
+}
+
+func TestRetryAfterShouldPreserveWrappedError(t *testing.T) {
+	err := RetryAfter(ErrTooManyRequests, time.Second)
+	assert.True(t, errors.Is(err, ErrTooManyRequests))
+	assert.Equal(t, http.StatusTooManyRequests, err.Status())
+
+	b, merr := err.MarshalJSON()
+	assert.NoError(t, merr)
+	assert.JSONEq(t, `{"error":"Too Many Requests"}`, string(b))
+}
+
+func TestWithHeaderShouldBeWrittenByDefaultErrorHandler(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		key    string
+		value  string
+	}{
+		{WithHeader(ErrUnauthorized, "WWW-Authenticate", `Bearer realm="api"`), http.StatusUnauthorized, "WWW-Authenticate", `Bearer realm="api"`},
+		{WithHeaders(ErrMethodNotAllowed, http.Header{"Allow": {"GET, HEAD"}}), http.StatusMethodNotAllowed, "Allow", "GET, HEAD"},
+		{WithHeader(RetryAfter(ErrTooManyRequests, time.Second), "X-Rate-Limit", "10"), http.StatusTooManyRequests, "Retry-After", "1"},
+		{WithHeader(RetryAfter(ErrTooManyRequests, time.Second), "Retry-After", "5"), http.StatusTooManyRequests, "Retry-After", "5"},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.MethodFunc(http.MethodGet, "/", func(Context) error {
+			return tt.err
+		})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, tt.status, rec.Code, tt.err.Error())
+		assert.Equal(t, tt.value, rec.Header().Get(tt.key), tt.err.Error())
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"), tt.err.Error())
+	}
+}
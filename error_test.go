@@ -2,7 +2,10 @@ package boar
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"testing"
 
@@ -80,3 +83,115 @@ func TestValidationErrorCauseShowsAllCauses(t *testing.T) {
 		assert.Contains(t, err, er.Error())
 	}
 }
+
+func TestValidationErrorsByFieldShouldKeyMessagesByField(t *testing.T) {
+	MarshalValidationError = ValidationErrorsByField
+	defer func() { MarshalValidationError = nil }()
+
+	e := NewValidationError(bodyField, io.ErrClosedPipe)
+	e.fields = []FieldError{
+		{Field: "email", Message: "is required"},
+		{Field: "email", Message: "must be a valid email"},
+	}
+
+	byts, err := e.MarshalJSON()
+	require.NoError(t, err)
+
+	var ermsg struct {
+		Errors struct {
+			Body map[string][]string `json:"body"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(byts, &ermsg))
+
+	assert.Equal(t, []string{"is required", "must be a valid email"}, ermsg.Errors.Body["email"])
+}
+
+func TestValidationErrorsByFieldShouldUseListWhenNoFieldErrors(t *testing.T) {
+	MarshalValidationError = ValidationErrorsByField
+	defer func() { MarshalValidationError = nil }()
+
+	e := NewValidationError(queryField, io.ErrClosedPipe)
+
+	byts, err := e.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"errors": {"query": ["io: read/write on closed pipe"]}}`, string(byts))
+}
+
+func TestValidationErrorShouldExposeFieldDetails(t *testing.T) {
+	e := NewValidationError(bodyField, io.ErrClosedPipe)
+	e.fields = []FieldError{{Field: "name", StructField: "Name", Tag: "min", Param: "3", Message: "must be at least 3 characters"}}
+
+	assert.Equal(t, bodyField, e.FieldName())
+	assert.Equal(t, e.fields, e.FieldErrors())
+}
+
+func TestValidationErrorMarshalJSONUsesMarshalValidationError(t *testing.T) {
+	MarshalValidationError = func(e *ValidationError) interface{} {
+		return JSON{"area": e.FieldName(), "fields": e.FieldErrors()}
+	}
+	defer func() { MarshalValidationError = nil }()
+
+	e := NewValidationError(bodyField, io.ErrClosedPipe)
+	e.fields = []FieldError{{Field: "name", StructField: "Name", Tag: "min", Param: "3", Message: "must be at least 3 characters"}}
+
+	byts, err := e.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"area": "Body", "fields": [{"field": "name", "tag": "min", "param": "3", "message": "must be at least 3 characters"}]}`, string(byts))
+}
+
+func TestHTTPErrorsShouldSupportErrorsIs(t *testing.T) {
+	assert.True(t, errors.Is(fmt.Errorf("loading user: %w", ErrNotFound), ErrNotFound))
+	assert.True(t, errors.Is(NewHTTPErrorStatus(http.StatusNotFound), ErrNotFound))
+	assert.True(t, errors.Is(ErrEntityNotFound, ErrNotFound))
+	assert.True(t, errors.Is(NewHTTPError(http.StatusNotFound, io.EOF), ErrNotFound))
+	assert.True(t, errors.Is(NewHTTPError(http.StatusNotFound, io.EOF), io.EOF))
+	assert.False(t, errors.Is(ErrForbidden, ErrNotFound))
+	assert.False(t, errors.Is(ErrNotFound, ErrEntityNotFound))
+}
+
+func TestValidationErrorShouldSupportErrorsIs(t *testing.T) {
+	err := NewValidationErrors(bodyField, []error{io.ErrUnexpectedEOF, io.EOF})
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	assert.True(t, errors.Is(err, NewHTTPErrorStatus(http.StatusBadRequest)))
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var verr *ValidationError
+	assert.True(t, errors.As(fmt.Errorf("binding: %w", err), &verr))
+}
+
+func TestPanicErrorShouldSupportErrorsIs(t *testing.T) {
+	err := NewPanicError(io.EOF, nil)
+	assert.True(t, errors.Is(err, io.EOF))
+	assert.True(t, errors.Is(err, NewHTTPErrorStatus(http.StatusInternalServerError)))
+}
+
+func TestNewHTTPErrorCodeShouldMarshalCode(t *testing.T) {
+	err := NewHTTPErrorCode(http.StatusForbidden, "USER_SUSPENDED", errors.New("user is suspended"))
+	b, merr := json.Marshal(err)
+	require.NoError(t, merr)
+	assert.JSONEq(t, `{"error":"user is suspended","code":"USER_SUSPENDED"}`, string(b))
+	assert.Equal(t, http.StatusForbidden, err.Status())
+}
+
+func TestHTTPErrorShouldNotMarshalEmptyCode(t *testing.T) {
+	b, err := json.Marshal(NewHTTPError(http.StatusForbidden, errors.New("nope")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error":"nope"}`, string(b))
+}
+
+func TestErrorCodeShouldFindWrappedCodes(t *testing.T) {
+	err := NewHTTPErrorCode(http.StatusConflict, "EMAIL_TAKEN", errors.New("email is taken"))
+	assert.Equal(t, "EMAIL_TAKEN", ErrorCode(fmt.Errorf("creating user: %w", err)))
+	assert.Equal(t, "", ErrorCode(ErrNotFound))
+	assert.Equal(t, "", ErrorCode(errors.New("plain")))
+}
+
+func TestPanicErrorShouldNotIncludeStackInError(t *testing.T) {
+	err := NewPanicError("something broke", []byte("goroutine 1 [running]"))
+	assert.Equal(t, "something broke", err.Error())
+
+	PanicErrorStack = true
+	defer func() { PanicErrorStack = false }()
+	assert.Equal(t, "something broke\ngoroutine 1 [running]", err.Error())
+}
@@ -0,0 +1,125 @@
+package boar
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// errorMediaTypes are the media types the default ErrorHandler can write in order of
+// preference when the client accepts several equally
+var errorMediaTypes = []string{contentTypeJSON, contentTypeHTML, contentTypeXML, "text/xml", contentTypeText}
+
+// ErrorMode controls how much of the cause of server errors is written to clients
+type ErrorMode int
+
+const (
+	// ErrorModeDefault writes the cause of every error
+	ErrorModeDefault ErrorMode = iota
+	// ErrorModeProduction writes only the status text of 5xx errors so that internal
+	// details such as database errors are not exposed. The cause is logged with the
+	// Router's Logger instead. Stacks of panics are logged by the recovery middleware
+	ErrorModeProduction
+	// ErrorModeDebug writes the cause of every error along with the stack of panics. It
+	// should only be used in development
+	ErrorModeDebug
+)
+
+// ErrorPage is the data passed to the Router's ErrorTemplate and ErrorTemplates
+type ErrorPage struct {
+	// Status is the status code of the response
+	Status int
+	// StatusText is the standard text of Status such as Not Found
+	StatusText string
+	// Message is the message of the error's cause
+	Message string
+	// Code is the application error code of the error. See NewHTTPErrorCode
+	Code string
+	// Error is the error being rendered
+	Error HTTPError
+}
+
+type xmlError struct {
+	XMLName xml.Name `xml:"error"`
+	Status  int      `xml:"status,attr"`
+	Code    string   `xml:"code,attr,omitempty"`
+	Message string   `xml:"message"`
+}
+
+// writeError writes err in the media type preferred by the client. HTML is only written
+// when the Router has a template for the status and JSON is written when the client accepts none
+// of the media types
+func writeError(c Context, err HTTPError) error {
+	status := err.Status()
+	switch c.Accepts(errorMediaTypes...) {
+	case contentTypeHTML:
+		if name := errorTemplate(c, status); name != "" {
+			return c.Render(status, name, newErrorPage(err))
+		}
+	case contentTypeXML, "text/xml":
+		return c.WriteXML(status, xmlError{Status: status, Code: ErrorCode(err), Message: err.Cause().Error()})
+	case contentTypeText:
+		return c.WriteString(status, err.Cause().Error())
+	}
+	return c.WriteJSON(status, err)
+}
+
+// exposeError returns the error written to the client according to the Router's ErrorMode
+func exposeError(c Context, err HTTPError) HTTPError {
+	rtr := routerOf(c)
+	if rtr == nil {
+		return err
+	}
+
+	switch rtr.ErrorMode {
+	case ErrorModeProduction:
+		status := err.Status()
+		if status < http.StatusInternalServerError {
+			return err
+		}
+		if _, ok := err.(*PanicError); !ok {
+			c.Logger().Error("server error",
+				"method", c.Request().Method,
+				"path", c.Request().URL.Path,
+				"status", status,
+				"error", err.Cause(),
+			)
+		}
+		return NewHTTPErrorCode(status, ErrorCode(err), errors.New(http.StatusText(status)))
+	case ErrorModeDebug:
+		if perr, ok := err.(*PanicError); ok {
+			debug := *perr
+			debug.includeStack = true
+			return &debug
+		}
+	}
+	return err
+}
+
+func newErrorPage(err HTTPError) ErrorPage {
+	return ErrorPage{
+		Status:     err.Status(),
+		StatusText: http.StatusText(err.Status()),
+		Message:    err.Cause().Error(),
+		Code:       ErrorCode(err),
+		Error:      err,
+	}
+}
+
+// errorTemplate returns the template of the Router which handled the request for status
+// when it has a Renderer. See Router.ErrorTemplates
+func errorTemplate(c Context, status int) string {
+	rtr := routerOf(c)
+	if rtr == nil || rtr.Renderer == nil {
+		return ""
+	}
+	code := strconv.Itoa(status)
+	if name, ok := rtr.ErrorTemplates[code]; ok {
+		return name
+	}
+	if name, ok := rtr.ErrorTemplates[code[:1]+"xx"]; ok {
+		return name
+	}
+	return rtr.ErrorTemplate
+}
@@ -0,0 +1,167 @@
+package boar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rendererFunc func(w io.Writer, name string, data interface{}) error
+
+func (f rendererFunc) Render(w io.Writer, name string, data interface{}) error {
+	return f(w, name, data)
+}
+
+func serveError(r *Router, accept string) *httptest.ResponseRecorder {
+	r.MethodFunc(http.MethodGet, "/", func(Context) error {
+		return ErrEntityNotFound
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", accept)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDefaultErrorHandlerNegotiatesErrorEncoding(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/json", `{"error":"entity not found"}`},
+		{"*/*", "application/json", `{"error":"entity not found"}`},
+		{"image/png", "application/json", `{"error":"entity not found"}`},
+		{"application/xml", "application/xml", `<error status="404"><message>entity not found</message></error>`},
+		{"text/xml", "application/xml", `<error status="404"><message>entity not found</message></error>`},
+		{"text/plain", "text/plain", `entity not found`},
+		{"text/html", "application/json", `{"error":"entity not found"}`},
+	}
+	for _, tt := range tests {
+		rec := serveError(NewRouter(), tt.accept)
+		assert.Equal(t, http.StatusNotFound, rec.Code, tt.accept)
+		assert.Contains(t, rec.Header().Get("Content-Type"), tt.contentType, tt.accept)
+		assert.Contains(t, rec.Body.String(), tt.body, tt.accept)
+	}
+}
+
+func TestDefaultErrorHandlerRendersErrorTemplateForHTML(t *testing.T) {
+	r := NewRouter()
+	r.ErrorTemplate = "error.html"
+	r.Renderer = rendererFunc(func(w io.Writer, name string, data interface{}) error {
+		page := data.(ErrorPage)
+		_, err := fmt.Fprintf(w, "<h1>%s %d %s</h1><p>%s</p>", name, page.Status, page.StatusText, page.Message)
+		return err
+	})
+
+	rec := serveError(r, "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Equal(t, "<h1>error.html 404 Not Found</h1><p>entity not found</p>", rec.Body.String())
+}
+
+func TestDefaultErrorHandlerRendersErrorTemplatesByStatus(t *testing.T) {
+	tests := []struct {
+		templates map[string]string
+		name      string
+	}{
+		{map[string]string{"404": "404.html", "4xx": "4xx.html"}, "404.html"},
+		{map[string]string{"4xx": "4xx.html", "5xx": "5xx.html"}, "4xx.html"},
+		{map[string]string{"5xx": "5xx.html"}, "error.html"},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.ErrorTemplate = "error.html"
+		r.ErrorTemplates = tt.templates
+		r.Renderer = rendererFunc(func(w io.Writer, name string, data interface{}) error {
+			_, err := io.WriteString(w, name)
+			return err
+		})
+
+		rec := serveError(r, "text/html")
+		assert.Equal(t, http.StatusNotFound, rec.Code, tt.name)
+		assert.Equal(t, tt.name, rec.Body.String())
+	}
+}
+
+func TestDefaultErrorHandlerWritesJSONWithoutErrorTemplateForStatus(t *testing.T) {
+	r := NewRouter()
+	r.ErrorTemplates = map[string]string{"5xx": "5xx.html"}
+	r.Renderer = rendererFunc(func(w io.Writer, name string, data interface{}) error {
+		_, err := io.WriteString(w, name)
+		return err
+	})
+
+	rec := serveError(r, "text/html")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, rec.Body.String(), `{"error":"entity not found"}`)
+}
+
+func serveErrorMode(mode ErrorMode, logger Logger, err error) *httptest.ResponseRecorder {
+	r := NewRouter()
+	r.ErrorMode = mode
+	r.Logger = logger
+	r.Use(PanicMiddleware)
+	r.MethodFunc(http.MethodGet, "/", func(Context) error {
+		if err == nil {
+			panic("something broke")
+		}
+		return err
+	})
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestErrorModeProductionShouldHideServerErrors(t *testing.T) {
+	var buf bytes.Buffer
+	rec := serveErrorMode(ErrorModeProduction, NewStdLogger(log.New(&buf, "", 0)), errors.New("pq: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, `{"error":"Internal Server Error"}`, strings.TrimSpace(rec.Body.String()))
+	assert.Contains(t, buf.String(), `error="pq: connection refused"`)
+
+	rec = serveErrorMode(ErrorModeProduction, NopLogger, nil)
+	assert.Equal(t, `{"error":"Internal Server Error"}`, strings.TrimSpace(rec.Body.String()))
+}
+
+func TestErrorModeProductionShouldWriteClientErrors(t *testing.T) {
+	rec := serveErrorMode(ErrorModeProduction, NopLogger, ErrEntityNotFound)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "entity not found")
+}
+
+func TestErrorModeDebugShouldWritePanicStacks(t *testing.T) {
+	rec := serveErrorMode(ErrorModeDebug, NopLogger, nil)
+	assert.Contains(t, rec.Body.String(), `"error":"something broke"`)
+	assert.Contains(t, rec.Body.String(), `"stack":"goroutine`)
+
+	rec = serveErrorMode(ErrorModeDefault, NopLogger, nil)
+	assert.Contains(t, rec.Body.String(), `"error":"something broke"`)
+	assert.NotContains(t, rec.Body.String(), `"stack"`)
+}
+
+func TestErrorModeProductionShouldKeepErrorCodes(t *testing.T) {
+	rec := serveErrorMode(ErrorModeProduction, NopLogger, NewHTTPErrorCode(http.StatusServiceUnavailable, "MAINTENANCE", errors.New("db migration")))
+	assert.JSONEq(t, `{"error":"Service Unavailable","code":"MAINTENANCE"}`, rec.Body.String())
+}
+
+func TestDefaultErrorHandlerShouldWriteErrorCodesAsXML(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/", func(Context) error {
+		return NewHTTPErrorCode(http.StatusConflict, "EMAIL_TAKEN", errors.New("email is taken"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/xml")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Contains(t, rec.Body.String(), `<error status="409" code="EMAIL_TAKEN"><message>email is taken</message></error>`)
+}
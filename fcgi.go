@@ -0,0 +1,38 @@
+package boar
+
+import (
+	"net"
+	"net/http/cgi"
+	"net/http/fcgi"
+)
+
+// ServeFCGI accepts FastCGI connections on l and serves their requests with rtr. This
+// allows applications to be deployed behind FastCGI frontends such as Apache mod_fcgid or
+// the PHP-style hosting of some providers. When l is nil requests are accepted on the
+// listener passed as stdin by the web server which spawned the process. The environment
+// of a request is available with fcgi.ProcessEnv.
+//
+// Example:
+//
+//	l, err := net.Listen("tcp", "127.0.0.1:9000")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Fatal(rtr.ServeFCGI(l))
+func (rtr *Router) ServeFCGI(l net.Listener) error {
+	return fcgi.Serve(l, rtr)
+}
+
+// ServeCGI serves the request of the current CGI invocation with rtr. The request is read
+// from the environment and stdin and the response is written to stdout.
+//
+// Example:
+//
+//	func main() {
+//		if err := rtr.ServeCGI(); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func (rtr *Router) ServeCGI() error {
+	return cgi.Serve(rtr)
+}
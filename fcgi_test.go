@@ -0,0 +1,130 @@
+package boar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fcgiRouter() *Router {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/users/:id", func(c Context) error {
+		return c.WriteString(http.StatusOK, "user "+c.URLParams().ByName("id"))
+	})
+	return r
+}
+
+// fcgiRecord writes a FastCGI record of the given type for request 1
+func fcgiRecord(w io.Writer, typ uint8, content []byte) {
+	w.Write([]byte{1, typ, 0, 1, byte(len(content) >> 8), byte(len(content)), 0, 0})
+	w.Write(content)
+}
+
+// fcgiParams encodes FastCGI name-value pairs with lengths shorter than 128 bytes
+func fcgiParams(params map[string]string) []byte {
+	var b bytes.Buffer
+	for k, v := range params {
+		b.WriteByte(byte(len(k)))
+		b.WriteByte(byte(len(v)))
+		b.WriteString(k)
+		b.WriteString(v)
+	}
+	return b.Bytes()
+}
+
+// fcgiGet sends a GET request for path to the FastCGI server at addr and returns the CGI
+// response written to stdout
+func fcgiGet(t *testing.T, addr, path string) string {
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const (
+		typeBeginRequest = 1
+		typeEndRequest   = 3
+		typeParams       = 4
+		typeStdin        = 5
+		typeStdout       = 6
+		roleResponder    = 1
+	)
+	fcgiRecord(conn, typeBeginRequest, []byte{0, roleResponder, 0, 0, 0, 0, 0, 0})
+	fcgiRecord(conn, typeParams, fcgiParams(map[string]string{
+		"REQUEST_METHOD":  http.MethodGet,
+		"REQUEST_URI":     path,
+		"SERVER_PROTOCOL": "HTTP/1.1",
+		"HTTP_HOST":       "example.com",
+		"REMOTE_ADDR":     "203.0.113.7",
+	}))
+	fcgiRecord(conn, typeParams, nil)
+	fcgiRecord(conn, typeStdin, nil)
+
+	var stdout bytes.Buffer
+	rd := bufio.NewReader(conn)
+	for {
+		var h struct {
+			Version, Type           uint8
+			ID, ContentLength       uint16
+			PaddingLength, Reserved uint8
+		}
+		require.NoError(t, binary.Read(rd, binary.BigEndian, &h))
+		content := make([]byte, int(h.ContentLength)+int(h.PaddingLength))
+		_, err := io.ReadFull(rd, content)
+		require.NoError(t, err)
+		switch h.Type {
+		case typeStdout:
+			stdout.Write(content[:h.ContentLength])
+		case typeEndRequest:
+			return stdout.String()
+		}
+	}
+}
+
+func TestServeFCGIServesRequests(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go fcgiRouter().ServeFCGI(l)
+
+	resp := fcgiGet(t, l.Addr().String(), "/users/42")
+	assert.Contains(t, resp, "Status: 200 OK")
+	assert.Contains(t, resp, "Content-Type: text/plain; charset=utf-8")
+	assert.Contains(t, resp, "\r\n\r\nuser 42")
+
+	resp = fcgiGet(t, l.Addr().String(), "/missing")
+	assert.Contains(t, resp, "Status: 404 Not Found")
+}
+
+// TestServeCGIChild is executed as a CGI script by TestServeCGIServesRequests
+func TestServeCGIChild(t *testing.T) {
+	if os.Getenv("BOAR_CGI_CHILD") != "1" {
+		t.Skip("only run as a CGI script")
+	}
+	fcgiRouter().ServeCGI()
+	os.Exit(0)
+}
+
+func TestServeCGIServesRequests(t *testing.T) {
+	h := &cgi.Handler{
+		Path: os.Args[0],
+		Args: []string{"-test.run=^TestServeCGIChild$"},
+		Env:  []string{"BOAR_CGI_CHILD=1"},
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.Equal(t, "user 42", string(body))
+}
@@ -0,0 +1,99 @@
+package boar
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sniffLen is the number of bytes used by http.DetectContentType
+const sniffLen = 512
+
+func (r *requestContext) File(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return r.writeFile(f, info.Name(), "", info.ModTime())
+}
+
+func (r *requestContext) Attachment(rdr io.Reader, filename string) error {
+	return r.writeFile(rdr, filename, "attachment", time.Time{})
+}
+
+func (r *requestContext) Inline(rdr io.Reader, filename string) error {
+	return r.writeFile(rdr, filename, "inline", time.Time{})
+}
+
+// writeFile copies rdr to the response with a content type detected from the file name,
+// or the content when the extension is unknown, and the given content disposition. The
+// response is unbuffered so the file is streamed to the client.
+// Seekable readers are served with http.ServeContent which handles Range, If-Range, and
+// the conditional request headers
+func (r *requestContext) writeFile(rdr io.Reader, filename, disposition string, modtime time.Time) error {
+	h := r.response.Header()
+	if disposition != "" {
+		h.Set("content-disposition", mime.FormatMediaType(disposition, map[string]string{
+			"filename": filepath.Base(filename),
+		}))
+	}
+
+	// the file is sent as it is read rather than being held in the response buffer
+	if err := r.Unbuffer(); err != nil {
+		return err
+	}
+
+	if rs, ok := rdr.(io.ReadSeeker); ok && r.request != nil {
+		// ServeContent sniffs the content when the content type has not been set
+		if ct := mime.TypeByExtension(filepath.Ext(filename)); ct != "" {
+			h.Set("content-type", ct)
+		}
+		http.ServeContent(r.response, r.request, filename, modtime, rs)
+		return nil
+	}
+
+	ct, rdr, err := detectContentType(filename, rdr)
+	if err != nil {
+		return err
+	}
+	h.Set("content-type", ct)
+
+	r.response.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(r.response, rdr); err != nil {
+		return fmt.Errorf("could not write file %q: %+v", filename, err)
+	}
+	return nil
+}
+
+// detectContentType finds the content type of a file by its extension. When the extension
+// is unknown the beginning of the content is sniffed and a reader is returned which replays it
+func detectContentType(filename string, rdr io.Reader) (string, io.Reader, error) {
+	if ct := mime.TypeByExtension(filepath.Ext(filename)); ct != "" {
+		return ct, rdr, nil
+	}
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(rdr, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+	buf = buf[:n]
+	return http.DetectContentType(buf), io.MultiReader(bytes.NewReader(buf), rdr), nil
+}
@@ -0,0 +1,141 @@
+package boar
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSendsFileContentsWithContentType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boar-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"a":1}`), 0644))
+
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+	require.NoError(t, c.File(path))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("content-type"))
+	assert.Empty(t, w.Header().Get("content-disposition"))
+	assert.Equal(t, `{"a":1}`, w.Body.String())
+}
+
+func TestFileReturnsNotFoundWhenFileDoesNotExist(t *testing.T) {
+	c := newContext(nil, httptest.NewRecorder(), nil)
+	assert.Equal(t, ErrNotFound, c.File("/does/not/exist.txt"))
+}
+
+func TestFileReturnsErrorForDirectories(t *testing.T) {
+	c := newContext(nil, httptest.NewRecorder(), nil)
+	assert.Error(t, c.File(os.TempDir()))
+}
+
+func TestAttachmentSetsContentDisposition(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+	require.NoError(t, c.Attachment(bytes.NewBufferString("%PDF-1.4"), "my report.pdf"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, `attachment; filename="my report.pdf"`, w.Header().Get("content-disposition"))
+	assert.Equal(t, "application/pdf", w.Header().Get("content-type"))
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+}
+
+func TestInlineSniffsContentTypeForUnknownExtensions(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+	require.NoError(t, c.Inline(bytes.NewBufferString("<html><body>hi</body></html>"), "page"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, `inline; filename=page`, w.Header().Get("content-disposition"))
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("content-type"))
+	assert.Equal(t, "<html><body>hi</body></html>", w.Body.String())
+}
+
+func TestFileShouldServeRangeRequests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boar-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0123456789"), 0644))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("range", "bytes=2-5")
+	w := httptest.NewRecorder()
+	c := newContext(req, w, nil)
+	require.NoError(t, c.File(path))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "bytes 2-5/10", w.Header().Get("content-range"))
+	assert.Equal(t, "bytes", w.Header().Get("accept-ranges"))
+	assert.Equal(t, "2345", w.Body.String())
+}
+
+func TestAttachmentShouldIgnoreStaleIfRange(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("range", "bytes=0-3")
+	req.Header.Set("if-range", `"stale-etag"`)
+	w := httptest.NewRecorder()
+	c := newContext(req, w, nil)
+	require.NoError(t, c.Attachment(bytes.NewReader([]byte("%PDF-1.4 data")), "report.pdf"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("content-type"))
+	assert.Equal(t, "%PDF-1.4 data", w.Body.String())
+}
+
+func TestAttachmentShouldStreamWithoutBuffering(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+	require.NoError(t, c.Attachment(bytes.NewBufferString("%PDF-1.4"), "report.pdf"))
+
+	// nothing is held in the response buffer and the body was sent before the Router flushes
+	assert.Empty(t, c.Response().(*BufferedResponseWriter).Body())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func TestFileShouldStreamRangeRequestsWithoutBuffering(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boar-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0123456789"), 0644))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("range", "bytes=2-5")
+	w := httptest.NewRecorder()
+	c := newContext(req, w, nil)
+	require.NoError(t, c.File(path))
+
+	assert.Empty(t, c.Response().(*BufferedResponseWriter).Body())
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "2345", w.Body.String())
+}
+
+func TestAttachmentShouldReturnErrorWhenTheResponseCannotBeUnbuffered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	w := NewMockResponseWriter(ctrl)
+	w.EXPECT().Header().Return(http.Header{})
+
+	c := newContext(nil, nil, nil)
+	c.response = w
+	err := c.Attachment(bytes.NewBufferString("%PDF-1.4"), "report.pdf")
+	assert.Equal(t, errCannotUnbuffer, err)
+}
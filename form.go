@@ -0,0 +1,69 @@
+package boar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// parseMultipartForm parses multipart requests using MultiPartFormMaxMemory. Requests
+// which are not multipart are parsed as regular forms
+func (r *requestContext) parseMultipartForm() error {
+	if r.request.MultipartForm != nil {
+		return nil
+	}
+	if r.ContentType() != contentTypeMultipartForm {
+		return r.request.ParseForm()
+	}
+	return r.request.ParseMultipartForm(MultiPartFormMaxMemory)
+}
+
+func (r *requestContext) FormValue(name string) string {
+	if err := r.parseMultipartForm(); err != nil {
+		return ""
+	}
+	return r.request.FormValue(name)
+}
+
+func (r *requestContext) FormFile(name string) (multipart.File, *multipart.FileHeader, error) {
+	if err := r.parseMultipartForm(); err != nil {
+		if errors.Is(err, ErrRequestEntityTooLarge) {
+			return nil, nil, ErrRequestEntityTooLarge
+		}
+		return nil, nil, NewValidationError(bodyField, err)
+	}
+	f, fh, err := r.request.FormFile(name)
+	if err == http.ErrMissingFile {
+		return nil, nil, NewValidationError(bodyField, fmt.Errorf("%s: %v", name, err))
+	}
+	if err != nil {
+		return nil, nil, NewValidationError(bodyField, err)
+	}
+	return f, fh, nil
+}
+
+func (r *requestContext) SaveUploadedFile(fh *multipart.FileHeader, dst string) error {
+	src, err := fh.Open()
+	if err != nil {
+		return fmt.Errorf("could not open uploaded file: %+v", err)
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("could not create directory for uploaded file: %+v", err)
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("could not create file for uploaded file: %+v", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, src); err != nil {
+		return fmt.Errorf("could not save uploaded file: %+v", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,74 @@
+package boar
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func multipartRequest(t *testing.T) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("title", "avatar"))
+	fw, err := mw.CreateFormFile("file", "avatar.png")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("image data"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/?page=2", &body)
+	req.Header.Set("content-type", mw.FormDataContentType())
+	return req
+}
+
+func TestFormValueShouldReadMultipartAndQueryValues(t *testing.T) {
+	c := NewContext(multipartRequest(t), httptest.NewRecorder(), nil)
+	assert.Equal(t, "avatar", c.FormValue("title"))
+	assert.Equal(t, "2", c.FormValue("page"))
+}
+
+func TestFormFileShouldReturnUploadedFile(t *testing.T) {
+	c := NewContext(multipartRequest(t), httptest.NewRecorder(), nil)
+
+	f, fh, err := c.FormFile("file")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.Equal(t, "avatar.png", fh.Filename)
+
+	b, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "image data", string(b))
+}
+
+func TestFormFileShouldReturnValidationErrorWhenMissing(t *testing.T) {
+	c := NewContext(multipartRequest(t), httptest.NewRecorder(), nil)
+
+	_, _, err := c.FormFile("missing")
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*ValidationError).Status())
+}
+
+func TestSaveUploadedFileShouldWriteToDst(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := NewContext(multipartRequest(t), httptest.NewRecorder(), nil)
+	_, fh, err := c.FormFile("file")
+	require.NoError(t, err)
+
+	dst := filepath.Join(dir, "uploads", fh.Filename)
+	require.NoError(t, c.SaveUploadedFile(fh, dst))
+
+	b, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "image data", string(b))
+}
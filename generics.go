@@ -0,0 +1,75 @@
+//go:build go1.18
+// +build go1.18
+
+package boar
+
+import "net/http"
+
+// TypedHandlerFunc handles a request bound into Req and returns the response which is
+// written as JSON. Req is populated like a Handler: its Query, URLParams, Header, and Body
+// fields are bound from the request and validated, and fields tagged with ctx are set
+// from the Context
+type TypedHandlerFunc[Req, Resp any] func(Context, Req) (Resp, error)
+
+// Handle registers h for method and path on rtr. Resp is written as JSON with
+// StatusOK unless h writes the response itself. Methods cannot have type parameters so
+// this is a function rather than a method of Router. Req and Resp document the Route.
+//
+// Example:
+//
+//	type GetUserReq struct {
+//		URLParams struct {
+//			ID int `url:"id"`
+//		}
+//	}
+//
+//	boar.Handle(rtr, http.MethodGet, "/users/:id", func(c boar.Context, req GetUserReq) (User, error) {
+//		return users.Find(c.Context(), req.URLParams.ID)
+//	})
+func Handle[Req, Resp any](rtr *Router, method, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	route := rtr.MethodFunc(method, path, func(c Context) error {
+		var req Req
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		// don't begin handling requests which were canceled by the client or a
+		// middleware timeout while the request was being read
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
+		resp, err := h(c, req)
+		if err != nil {
+			return err
+		}
+		if c.Response().Status() != 0 {
+			return nil
+		}
+		return c.WriteJSON(http.StatusOK, resp)
+	})
+	return route.Request(new(Req)).Response(new(Resp))
+}
+
+// Get registers h for GET requests of path. See Handle
+func Get[Req, Resp any](rtr *Router, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	return Handle(rtr, http.MethodGet, path, h)
+}
+
+// Post registers h for POST requests of path. See Handle
+func Post[Req, Resp any](rtr *Router, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	return Handle(rtr, http.MethodPost, path, h)
+}
+
+// Put registers h for PUT requests of path. See Handle
+func Put[Req, Resp any](rtr *Router, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	return Handle(rtr, http.MethodPut, path, h)
+}
+
+// Patch registers h for PATCH requests of path. See Handle
+func Patch[Req, Resp any](rtr *Router, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	return Handle(rtr, http.MethodPatch, path, h)
+}
+
+// Delete registers h for DELETE requests of path. See Handle
+func Delete[Req, Resp any](rtr *Router, path string, h TypedHandlerFunc[Req, Resp]) *Route {
+	return Handle(rtr, http.MethodDelete, path, h)
+}
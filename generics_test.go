@@ -0,0 +1,90 @@
+//go:build go1.18
+// +build go1.18
+
+package boar
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type getUserReq struct {
+	URLParams struct {
+		ID int `url:"id"`
+	}
+	Query struct {
+		Fields string `query:"fields"`
+	}
+}
+
+type userResp struct {
+	ID     int    `json:"id"`
+	Fields string `json:"fields"`
+}
+
+type createUserReq struct {
+	Body struct {
+		Name string `json:"name" validate:"required"`
+	}
+}
+
+func TestGenericGetShouldBindRequestAndWriteJSON(t *testing.T) {
+	r := NewRouter()
+	Get(r, "/users/:id", func(c Context, req getUserReq) (userResp, error) {
+		return userResp{ID: req.URLParams.ID, Fields: req.Query.Fields}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/7?fields=name", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), contentTypeJSON)
+	assert.JSONEq(t, `{"id": 7, "fields": "name"}`, rec.Body.String())
+}
+
+func TestGenericHandlerShouldWriteValidationErrors(t *testing.T) {
+	called := false
+	r := NewRouter()
+	Post(r, "/users", func(c Context, req createUserReq) (userResp, error) {
+		called = true
+		return userResp{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", contentTypeJSON)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGenericHandlerShouldWriteErrors(t *testing.T) {
+	r := NewRouter()
+	Delete(r, "/users/:id", func(c Context, req getUserReq) (*userResp, error) {
+		return nil, ErrEntityNotFound
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/7", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"error": "entity not found"}`, rec.Body.String())
+}
+
+func TestGenericHandlerShouldNotWriteWhenHandlerResponded(t *testing.T) {
+	r := NewRouter()
+	Handle(r, http.MethodPut, "/users/:id", func(c Context, req getUserReq) (*userResp, error) {
+		return nil, c.WriteStatus(http.StatusAccepted)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/users/7", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
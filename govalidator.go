@@ -0,0 +1,81 @@
+package boar
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// NewGoValidator creates a StructValidator backed by github.com/asaskevich/govalidator
+// which validates the `valid` struct tag
+func NewGoValidator() StructValidator {
+	return goValidator{}
+}
+
+type goValidator struct{}
+
+func (goValidator) ValidateStruct(v interface{}) error {
+	_, err := govalidator.ValidateStruct(v)
+	return err
+}
+
+func (goValidator) fieldErrors(typ reflect.Type, err error) []FieldError {
+	// jsonFieldPath expects the namespace of named types to begin with the type name
+	t := typ
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	prefix := ""
+	if t.Name() != "" {
+		prefix = t.Name() + "."
+	}
+
+	var fields []FieldError
+	var flatten func(error)
+	flatten = func(err error) {
+		switch e := err.(type) {
+		case govalidator.Errors:
+			for _, inner := range e {
+				flatten(inner)
+			}
+		case govalidator.Error:
+			path := prefix + goValidatorNamespace(t, e.Path, e.Name)
+			fields = append(fields, FieldError{
+				Field:       jsonFieldPath(typ, path),
+				StructField: structFieldPath(typ, path),
+				Tag:         e.Validator,
+				Message:     e.Err.Error(),
+			})
+		}
+	}
+	flatten(err)
+	return fields
+}
+
+// goValidatorNamespace converts the path and name of a govalidator.Error, which names the
+// field by its json tag when it has one, into the struct namespace of the field
+func goValidatorNamespace(typ reflect.Type, path []string, name string) string {
+	names := append(append([]string{}, path...), name)
+	for i, n := range names {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice ||
+			typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			break
+		}
+		sf, ok := typ.FieldByName(n)
+		for j := 0; !ok && j < typ.NumField(); j++ {
+			if jsonName(typ.Field(j)) == n {
+				sf, ok = typ.Field(j), true
+			}
+		}
+		if !ok {
+			break
+		}
+		names[i] = sf.Name
+		typ = sf.Type
+	}
+	return strings.Join(names, ".")
+}
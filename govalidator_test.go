@@ -0,0 +1,46 @@
+package boar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type goValidatorBody struct {
+	Email   string `json:"email" valid:"email"`
+	Address struct {
+		Zip string `json:"zip" valid:"numeric"`
+	} `json:"address"`
+}
+
+func TestGoValidatorReportsFieldErrors(t *testing.T) {
+	defer func(v StructValidator) { Validator = v }(Validator)
+	Validator = NewGoValidator()
+
+	var body goValidatorBody
+	body.Email = "nope"
+	body.Address.Zip = "abc"
+
+	err := validate(bodyField, &body)
+	require.IsType(t, &ValidationError{}, err)
+	fields := err.(*ValidationError).fields
+	require.Len(t, fields, 2)
+
+	byField := map[string]FieldError{}
+	for _, f := range fields {
+		byField[f.Field] = f
+	}
+	assert.Equal(t, "Email", byField["email"].StructField)
+	assert.Equal(t, "email", byField["email"].Tag)
+	assert.NotEmpty(t, byField["email"].Message)
+	assert.Equal(t, "Address.Zip", byField["address.zip"].StructField)
+	assert.Equal(t, "numeric", byField["address.zip"].Tag)
+}
+
+func TestGoValidatorAcceptsValidStructs(t *testing.T) {
+	var body goValidatorBody
+	body.Email = "a@b.co"
+	body.Address.Zip = "12345"
+	assert.NoError(t, NewGoValidator().ValidateStruct(&body))
+}
@@ -0,0 +1,100 @@
+package boar
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	contextType = reflect.TypeOf((*Context)(nil)).Elem()
+	handlerType = reflect.TypeOf((*Handler)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Provide registers dependencies which are passed to the constructors of Inject. Values
+// are matched to constructor parameters by their type or, for interface parameters, by
+// the interfaces they implement. Dependencies are shared by the groups of the Router and
+// should be provided before routes are registered
+func (rtr *Router) Provide(deps ...interface{}) {
+	root := rtr.root()
+	for _, dep := range deps {
+		if dep == nil {
+			panic("cannot provide a nil dependency")
+		}
+		root.dependencies = append(root.dependencies, reflect.ValueOf(dep))
+	}
+}
+
+// Inject creates a HandlerProviderFunc which calls constructor for every request with its
+// dependencies. The parameters of constructor are resolved from the values given to
+// Provide and the Context of the request. constructor must return a Handler and
+// optionally an error. Inject panics when constructor is not a func or when any of its
+// parameters cannot be resolved so that mistakes are found when routes are registered
+// rather than when they are requested.
+//
+// Example:
+//
+//	func NewGetUserHandler(db *sql.DB, log boar.Logger) *GetUserHandler {
+//		return &GetUserHandler{db: db, log: log}
+//	}
+//
+//	rtr.Provide(db, logger)
+//	rtr.Get("/users/:id", rtr.Inject(NewGetUserHandler))
+func (rtr *Router) Inject(constructor interface{}) HandlerProviderFunc {
+	fn := reflect.ValueOf(constructor)
+	typ := fn.Type()
+	if typ.Kind() != reflect.Func {
+		panic(fmt.Sprintf("cannot inject %s: not a func", typ))
+	}
+	if typ.NumOut() == 0 || typ.NumOut() > 2 ||
+		!typ.Out(0).Implements(handlerType) ||
+		(typ.NumOut() == 2 && typ.Out(1) != errorType) {
+		panic(fmt.Sprintf("cannot inject %s: must return a Handler and optionally an error", typ))
+	}
+
+	// every parameter except the Context is resolved once at registration
+	args := make([]reflect.Value, typ.NumIn())
+	ctxArgs := make([]int, 0, 1)
+	for i := range args {
+		in := typ.In(i)
+		if in == contextType {
+			ctxArgs = append(ctxArgs, i)
+			continue
+		}
+		dep, ok := rtr.root().dependency(in)
+		if !ok {
+			panic(fmt.Sprintf("cannot inject %s: no dependency provided for %s", typ, in))
+		}
+		args[i] = dep
+	}
+
+	return func(c Context) (Handler, error) {
+		in := make([]reflect.Value, len(args))
+		copy(in, args)
+		for _, i := range ctxArgs {
+			in[i] = reflect.ValueOf(&c).Elem()
+		}
+		out := fn.Call(in)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+		handler, _ := out[0].Interface().(Handler)
+		return handler, nil
+	}
+}
+
+// dependency returns the provided value of typ. Values of exactly typ are preferred over
+// values which are only assignable to it
+func (rtr *Router) dependency(typ reflect.Type) (reflect.Value, bool) {
+	for _, dep := range rtr.dependencies {
+		if dep.Type() == typ {
+			return dep, true
+		}
+	}
+	for _, dep := range rtr.dependencies {
+		if dep.Type().AssignableTo(typ) {
+			return dep, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,88 @@
+package boar
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type greeter struct {
+	greeting string
+}
+
+type injectedHandler struct {
+	greeter *greeter
+	w       io.Writer
+	path    string
+}
+
+func (h *injectedHandler) Handle(c Context) error {
+	return c.WriteString(http.StatusOK, h.greeter.greeting+" "+h.path)
+}
+
+func newInjectedHandler(g *greeter, c Context, w io.Writer) *injectedHandler {
+	return &injectedHandler{greeter: g, w: w, path: c.Request().URL.Path}
+}
+
+func TestInjectShouldResolveDependencies(t *testing.T) {
+	r := NewRouter()
+	r.Provide(&greeter{greeting: "hello"}, &strings.Builder{})
+	api := r.Group("/api")
+	api.Get("/users", api.Inject(newInjectedHandler))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello /api/users", rec.Body.String())
+}
+
+func TestInjectShouldReturnConstructorErrors(t *testing.T) {
+	r := NewRouter()
+	r.Provide(&greeter{})
+	r.Get("/", r.Inject(func(*greeter) (*injectedHandler, error) {
+		return nil, ErrForbidden
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestInjectShouldPanicWhenConstructorIsInvalid(t *testing.T) {
+	tests := []interface{}{
+		"not a func",
+		func() {},
+		func() error { return nil },
+		func() (*injectedHandler, string) { return nil, "" },
+		func(*greeter) *injectedHandler { return nil },
+	}
+	for _, ctor := range tests {
+		assert.Panics(t, func() {
+			NewRouter().Inject(ctor)
+		})
+	}
+}
+
+func TestProvideShouldPanicWithNilDependency(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouter().Provide(nil)
+	})
+}
+
+func TestDependencyShouldPreferExactTypes(t *testing.T) {
+	r := NewRouter()
+	exact := errors.New("exact")
+	r.Provide(ErrForbidden, exact)
+
+	dep, ok := r.dependency(reflect.TypeOf(exact))
+	assert.True(t, ok)
+	assert.Equal(t, exact, dep.Interface())
+}
@@ -0,0 +1,73 @@
+package boar
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+)
+
+// Logger is a structured logger. keyvals are alternating keys and values. *slog.Logger
+// satisfies Logger and adapters for zap, zerolog, etc only need to forward these methods.
+type Logger interface {
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// defaultLogger is used when a Router has no Logger
+var defaultLogger = NewStdLogger(nil)
+
+// NopLogger is a Logger which discards everything. It silences the Router in tests
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// NewStdLogger creates a Logger which writes key=value lines to a standard library logger.
+// The standard logger is used when l is nil
+func NewStdLogger(l *log.Logger) Logger {
+	return &stdLogger{l: l}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s *stdLogger) Info(msg string, keyvals ...interface{})  { s.log("INFO", msg, keyvals) }
+func (s *stdLogger) Warn(msg string, keyvals ...interface{})  { s.log("WARN", msg, keyvals) }
+func (s *stdLogger) Error(msg string, keyvals ...interface{}) { s.log("ERROR", msg, keyvals) }
+
+func (s *stdLogger) log(level, msg string, keyvals []interface{}) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s: %s", level, msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		fmt.Fprintf(&buf, " %v=%q", keyvals[i], fmt.Sprint(v))
+	}
+	if s.l == nil {
+		log.Print(buf.String())
+		return
+	}
+	s.l.Print(buf.String())
+}
+
+func (r *requestContext) Logger() Logger {
+	if r.router == nil {
+		return defaultLogger
+	}
+	return r.router.logger()
+}
+
+// logger returns the Logger of the root Router or the standard logger when it is nil
+func (rtr *Router) logger() Logger {
+	if l := rtr.root().Logger; l != nil {
+		return l
+	}
+	return defaultLogger
+}
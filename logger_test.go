@@ -0,0 +1,39 @@
+package boar
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdLoggerShouldWriteKeyValues(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0))
+
+	l.Info("request", "method", "GET", "status", 200)
+	l.Error("failed", "odd")
+
+	assert.Equal(t, "INFO: request method=\"GET\" status=\"200\"\nERROR: failed odd=\"MISSING\"\n", buf.String())
+}
+
+func TestContextLoggerShouldUseRouterLogger(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRouter()
+	r.Logger = NewStdLogger(log.New(&buf, "", 0))
+	r.Use(PanicMiddleware)
+	r.Group("/api").MethodFunc(http.MethodGet, "/", func(c Context) error {
+		panic("something broke")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Contains(t, buf.String(), `ERROR: recovered from panic method="GET" path="/api/" error="something broke"`)
+}
+
+func TestContextLoggerShouldDefaultToStdLogger(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	assert.Equal(t, defaultLogger, c.Logger())
+}
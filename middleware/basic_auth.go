@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/blockloop/boar"
+)
+
+// BasicAuthContextKey is the Context key the BasicAuth middleware stores the principal
+// under. Handlers can receive it with a field tagged `ctx:"principal"`
+const BasicAuthContextKey = "principal"
+
+// BasicAuthValidator validates the credentials of a request and returns the principal,
+// such as a user, which they belong to. An error rejects the request
+type BasicAuthValidator func(user, pass string) (interface{}, error)
+
+// BasicAuth creates a middleware which requires requests to have HTTP Basic credentials
+// accepted by validate. The principal returned by validate is stored on the Context.
+// Requests with missing or rejected credentials are rejected with boar.ErrUnauthorized
+// and a WWW-Authenticate header for realm.
+//
+// Example:
+//
+//	rtr.Use(middleware.BasicAuth("admin", func(user, pass string) (interface{}, error) {
+//		return users.Authenticate(user, pass)
+//	}))
+func BasicAuth(realm string, validate BasicAuthValidator) boar.Middleware {
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", realm)
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			user, pass, ok := c.Request().BasicAuth()
+			if !ok {
+				c.Response().Header().Set("WWW-Authenticate", challenge)
+				return boar.ErrUnauthorized
+			}
+			principal, err := validate(user, pass)
+			if err != nil {
+				c.Response().Header().Set("WWW-Authenticate", challenge)
+				return boar.ErrUnauthorized
+			}
+			c.Set(BasicAuthContextKey, principal)
+			return next(c)
+		}
+	}
+}
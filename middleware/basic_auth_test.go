@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name string
+}
+
+type adminHandler struct {
+	User *user `ctx:"principal"`
+}
+
+func (h *adminHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.User.Name)
+}
+
+func serveBasicAuth(setAuth func(*http.Request)) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(BasicAuth("admin", func(name, pass string) (interface{}, error) {
+		if subtle.ConstantTimeCompare([]byte(name+":"+pass), []byte("brett:secret")) != 1 {
+			return nil, errors.New("invalid credentials")
+		}
+		return &user{Name: name}, nil
+	}))
+	r.Get("/", func(boar.Context) (boar.Handler, error) {
+		return &adminHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	setAuth(req)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBasicAuthShouldStoreThePrincipal(t *testing.T) {
+	rec := serveBasicAuth(func(r *http.Request) { r.SetBasicAuth("brett", "secret") })
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "brett", rec.Body.String())
+}
+
+func TestBasicAuthShouldRejectInvalidCredentials(t *testing.T) {
+	rec := serveBasicAuth(func(r *http.Request) { r.SetBasicAuth("brett", "wrong") })
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Basic realm="admin", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestBasicAuthShouldRejectMissingCredentials(t *testing.T) {
+	rec := serveBasicAuth(func(*http.Request) {})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+}
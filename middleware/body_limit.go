@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/blockloop/boar"
+)
+
+// BodyLimit creates a middleware which limits request bodies to n bytes. Requests with a
+// larger Content-Length are rejected before the handler is executed and bodies which are
+// read beyond n bytes return boar.ErrRequestEntityTooLarge so that the ErrorHandler
+// renders a 413. Use it with Router.Group or Router.With to give routes different limits.
+//
+// Example:
+//
+//	api := rtr.Group("/api", middleware.BodyLimit(1<<20))
+//	uploads := rtr.Group("/uploads", middleware.BodyLimit(100<<20))
+func BodyLimit(n int64) boar.Middleware {
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			r := c.Request()
+			if r.ContentLength > n {
+				return boar.ErrRequestEntityTooLarge
+			}
+			if r.Body != nil {
+				r.Body = &limitedBody{ReadCloser: r.Body, remaining: n}
+			}
+			return next(c)
+		}
+	}
+}
+
+// limitedBody reads at most remaining bytes from a request body. Unlike io.LimitReader it
+// returns an error rather than io.EOF when the body is larger than the limit
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, boar.ErrRequestEntityTooLarge
+	}
+	// read one byte past the limit to tell a body of exactly n bytes from a larger one
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), boar.ErrRequestEntityTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+type bodyLimitHandler struct {
+	Body struct {
+		Name string
+	}
+}
+
+func (h *bodyLimitHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Body.Name)
+}
+
+func newBodyLimitRouter() *boar.Router {
+	r := boar.NewRouter()
+	provider := func(boar.Context) (boar.Handler, error) { return &bodyLimitHandler{}, nil }
+	r.Group("/api", BodyLimit(16)).Post("/", provider)
+	r.Group("/uploads", BodyLimit(1024)).Post("/", provider)
+	return r
+}
+
+func postJSON(r http.Handler, path, body string, chunked bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if chunked {
+		req.ContentLength = -1
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBodyLimitShouldRejectLargeContentLength(t *testing.T) {
+	rec := postJSON(newBodyLimitRouter(), "/api/", `{"Name":"a long enough name"}`, false)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestBodyLimitShouldRejectLargeBodiesWithoutContentLength(t *testing.T) {
+	rec := postJSON(newBodyLimitRouter(), "/api/", `{"Name":"a long enough name"}`, true)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestBodyLimitShouldAllowBodiesWithinLimit(t *testing.T) {
+	rec := postJSON(newBodyLimitRouter(), "/api/", `{"Name":"bob"}`, true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "bob", rec.Body.String())
+}
+
+func TestBodyLimitShouldApplyLimitsPerGroup(t *testing.T) {
+	rec := postJSON(newBodyLimitRouter(), "/uploads/", `{"Name":"a long enough name"}`, true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLimitedBodyShouldReadBodiesOfExactlyTheLimit(t *testing.T) {
+	b := &limitedBody{ReadCloser: ioutil.NopCloser(strings.NewReader("abcd")), remaining: 4}
+	data, err := ioutil.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcd", string(data))
+}
+
+func TestLimitedBodyShouldErrorPastTheLimit(t *testing.T) {
+	b := &limitedBody{ReadCloser: ioutil.NopCloser(strings.NewReader("abcde")), remaining: 4}
+	data, err := ioutil.ReadAll(b)
+	assert.Equal(t, boar.ErrRequestEntityTooLarge, err)
+	assert.Equal(t, "abcd", string(data))
+}
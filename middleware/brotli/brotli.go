@@ -0,0 +1,28 @@
+// Package brotli registers a br encoder for middleware.Compress. It is a separate package
+// so that only applications which serve brotli compile the encoder. Import it for its side
+// effect:
+//
+//	import _ "github.com/blockloop/boar/middleware/brotli"
+package brotli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/blockloop/boar/middleware"
+)
+
+func init() {
+	middleware.RegisterEncoder("br", newWriter)
+}
+
+func newWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == middleware.DefaultCompression {
+		level = brotli.DefaultCompression
+	}
+	if level < brotli.BestSpeed || level > brotli.BestCompression {
+		return nil, fmt.Errorf("brotli: invalid compression level: %d", level)
+	}
+	return brotli.NewWriterLevel(w, level), nil
+}
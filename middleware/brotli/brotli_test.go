@@ -0,0 +1,54 @@
+package brotli
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/blockloop/boar"
+	"github.com/blockloop/boar/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressShouldEncodeBrotli(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+	r := boar.NewRouter()
+	r.Use(middleware.Compress())
+	r.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+		return c.Write(http.StatusOK, "text/plain", []byte(body))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.5")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+	b, err := ioutil.ReadAll(brotli.NewReader(rec.Body))
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+}
+
+func TestNewWriterShouldRejectInvalidLevels(t *testing.T) {
+	_, err := newWriter(ioutil.Discard, 12)
+	assert.Error(t, err)
+	_, err = newWriter(ioutil.Discard, -2)
+	assert.Error(t, err)
+
+	for _, level := range []int{middleware.DefaultCompression, brotli.BestSpeed, brotli.BestCompression} {
+		w, err := newWriter(ioutil.Discard, level)
+		require.NoError(t, err)
+		assert.NoError(t, w.Close())
+	}
+}
+
+func TestCompressWithConfigShouldPanicOnLevelInvalidForBrotli(t *testing.T) {
+	level := 15
+	assert.Panics(t, func() {
+		middleware.CompressWithConfig(middleware.CompressConfig{Level: &level, Encodings: []string{"gzip", "br"}})
+	})
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"net/http"
+
+	"github.com/blockloop/boar"
+)
+
+// ClientCertContextKey is the Context key the ClientCert middleware stores the verified
+// *x509.Certificate under. Handlers can receive it with a field tagged `ctx:"client_cert"`
+const ClientCertContextKey = "client_cert"
+
+// ClientCertAuthorizer decides whether the client with the verified certificate may make
+// the request, for example by its subject or SANs. An error rejects the request
+type ClientCertAuthorizer func(cert *x509.Certificate) error
+
+// ClientCert creates a middleware which requires requests to be sent over TLS with a
+// client certificate verified by the server. See boar.MutualTLSConfig. Requests without
+// one are rejected with boar.ErrUnauthorized and certificates rejected by authorize are
+// rejected with a 403 HTTPError, which matches boar.ErrForbidden with errors.Is, whose
+// cause is the error of authorize. Like other 4xx errors, the cause is sent to the client
+// so it should not include sensitive details. Every verified certificate is accepted when
+// authorize is nil. The certificate is stored on the Context.
+//
+// Example:
+//
+//	rtr.Use(middleware.ClientCert(func(cert *x509.Certificate) error {
+//		for _, ou := range cert.Subject.OrganizationalUnit {
+//			if ou == "billing" {
+//				return nil
+//			}
+//		}
+//		return errors.New("not a billing service")
+//	}))
+func ClientCert(authorize ClientCertAuthorizer) boar.Middleware {
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			cert := c.ClientCertificate()
+			if cert == nil {
+				return boar.ErrUnauthorized
+			}
+			if authorize != nil {
+				if err := authorize(cert); err != nil {
+					return boar.NewHTTPError(http.StatusForbidden, err)
+				}
+			}
+			c.Set(ClientCertContextKey, cert)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+type serviceHandler struct {
+	Cert *x509.Certificate `ctx:"client_cert"`
+}
+
+func (h *serviceHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Cert.Subject.CommonName)
+}
+
+func serveClientCert(state *tls.ConnectionState) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(ClientCert(func(cert *x509.Certificate) error {
+		if cert.Subject.CommonName != "billing" {
+			return errors.New("not a billing service")
+		}
+		return nil
+	}))
+	r.Get("/", func(boar.Context) (boar.Handler, error) {
+		return &serviceHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = state
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func verifiedState(cn string) *tls.ConnectionState {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	return &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}
+}
+
+func TestClientCertShouldStoreVerifiedCertificate(t *testing.T) {
+	rec := serveClientCert(verifiedState("billing"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "billing", rec.Body.String())
+}
+
+func TestClientCertShouldRejectRequestsWithoutCertificate(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveClientCert(nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveClientCert(&tls.ConnectionState{}).Code)
+}
+
+func TestClientCertShouldRejectUnverifiedCertificates(t *testing.T) {
+	state := verifiedState("billing")
+	state.VerifiedChains = nil
+	assert.Equal(t, http.StatusUnauthorized, serveClientCert(state).Code)
+}
+
+func TestClientCertShouldRejectUnauthorizedCertificates(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, serveClientCert(verifiedState("marketing")).Code)
+}
+
+func TestClientCertShouldReturnTheAuthorizerErrorAsCause(t *testing.T) {
+	expected := errors.New("not a billing service")
+	c := boar.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	c.Request().TLS = verifiedState("marketing")
+
+	err := ClientCert(func(*x509.Certificate) error { return expected })(func(boar.Context) error {
+		return nil
+	})(c)
+
+	assert.True(t, errors.Is(err, boar.ErrForbidden))
+	herr, ok := err.(boar.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, expected, herr.Cause())
+}
+
+func TestClientCertShouldAcceptEveryVerifiedCertificateWithoutAuthorizer(t *testing.T) {
+	r := boar.NewRouter()
+	r.Use(ClientCert(nil))
+	r.Get("/", func(boar.Context) (boar.Handler, error) {
+		return &serviceHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = verifiedState("marketing")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "marketing", rec.Body.String())
+}
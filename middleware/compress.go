@@ -0,0 +1,259 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blockloop/boar"
+)
+
+// EncoderFunc creates a compressing writer for a content encoding. level is
+// DefaultCompression when no level was configured
+type EncoderFunc func(w io.Writer, level int) (io.WriteCloser, error)
+
+// DefaultCompression is the level passed to an EncoderFunc when CompressConfig.Level is nil.
+// Encoders should use the default level of their encoding
+const DefaultCompression = -1
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]EncoderFunc{
+		"gzip": newGzipWriter,
+	}
+)
+
+// RegisterEncoder adds an encoder for a content encoding to be used by Compress. gzip is
+// registered by default. Import middleware/brotli or middleware/zstd to register br or zstd
+func RegisterEncoder(encoding string, fn EncoderFunc) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[strings.ToLower(encoding)] = fn
+}
+
+func encoder(encoding string) (EncoderFunc, bool) {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	fn, ok := encoders[encoding]
+	return fn, ok
+}
+
+func newGzipWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, level)
+}
+
+// CompressConfig configures the Compress middleware
+type CompressConfig struct {
+	// Level is the compression level passed to the encoder. It is a pointer so that a level
+	// of zero, such as gzip.NoCompression, can be selected. Default is nil which uses the
+	// default level of each encoding
+	Level *int
+	// MinSize is the smallest response body, in bytes, which will be compressed. Default is 1024
+	MinSize int
+	// ContentTypes are the media types which will be compressed. Entries such as text/*
+	// match every subtype. Default is DefaultGzipContentTypes
+	ContentTypes []string
+	// Encodings are the content encodings which may be used in order of preference when the
+	// client accepts several equally. Encodings without a registered encoder are ignored.
+	// Default is DefaultEncodings
+	Encodings []string
+}
+
+// GzipConfig configures the Gzip middleware
+type GzipConfig struct {
+	// Level is the gzip compression level. It is a pointer so that gzip.NoCompression can be
+	// selected. Default is nil which uses gzip.DefaultCompression
+	Level *int
+	// MinSize is the smallest response body, in bytes, which will be compressed. Default is 1024
+	MinSize int
+	// ContentTypes are the media types which will be compressed. Entries such as text/*
+	// match every subtype. Default is DefaultGzipContentTypes
+	ContentTypes []string
+}
+
+// DefaultGzipContentTypes are the media types compressed by Compress and Gzip when no
+// ContentTypes are configured
+var DefaultGzipContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/problem+json",
+	"image/svg+xml",
+}
+
+// DefaultEncodings are the content encodings used by Compress in order of preference. br
+// and zstd are skipped unless their packages are imported. See RegisterEncoder
+var DefaultEncodings = []string{"zstd", "br", "gzip"}
+
+// Gzip creates a middleware which compresses responses for clients that accept gzip using
+// the default GzipConfig
+func Gzip() boar.Middleware {
+	return GzipWithConfig(GzipConfig{})
+}
+
+// GzipWithConfig creates a middleware which compresses responses for clients that accept
+// gzip. See CompressWithConfig
+//
+// Example:
+//
+//	level := gzip.BestSpeed
+//	rtr.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+//		Level:   &level,
+//		MinSize: 512,
+//	}))
+func GzipWithConfig(cfg GzipConfig) boar.Middleware {
+	return CompressWithConfig(CompressConfig{
+		Level:        cfg.Level,
+		MinSize:      cfg.MinSize,
+		ContentTypes: cfg.ContentTypes,
+		Encodings:    []string{"gzip"},
+	})
+}
+
+// Compress creates a middleware which compresses responses with the encoding preferred by
+// the client's Accept-Encoding header using the default CompressConfig
+func Compress() boar.Middleware {
+	return CompressWithConfig(CompressConfig{})
+}
+
+// CompressWithConfig creates a middleware which compresses responses with the encoding
+// preferred by the client's Accept-Encoding header. The buffered response is compressed once
+// the handler returns, so Response().Len() reports the compressed size to outer middleware.
+// Responses which have already been sent, such as streams, are not compressed.
+func CompressWithConfig(cfg CompressConfig) boar.Middleware {
+	if cfg.MinSize == 0 {
+		cfg.MinSize = 1024
+	}
+	if len(cfg.ContentTypes) == 0 {
+		cfg.ContentTypes = DefaultGzipContentTypes
+	}
+	if len(cfg.Encodings) == 0 {
+		cfg.Encodings = DefaultEncodings
+	}
+	level := DefaultCompression
+	if cfg.Level != nil {
+		level = *cfg.Level
+	}
+	for _, enc := range cfg.Encodings {
+		if fn, ok := encoder(enc); ok {
+			zw, err := fn(ioutil.Discard, level)
+			if err != nil {
+				panic(fmt.Sprintf("invalid compression level %d for %s: %v", level, enc, err))
+			}
+			zw.Close()
+		}
+	}
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			err := next(c)
+
+			bw, ok := c.Response().(*boar.BufferedResponseWriter)
+			if !ok {
+				return err
+			}
+			h := bw.Header()
+			if !cfg.compressible(c.Request(), bw) {
+				return err
+			}
+			h.Add("Vary", "Accept-Encoding")
+
+			encoding := cfg.negotiate(c.Request().Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				return err
+			}
+			fn, _ := encoder(encoding)
+
+			var buf bytes.Buffer
+			zw, zerr := fn(&buf, level)
+			if zerr != nil {
+				return err
+			}
+			if _, zerr := zw.Write(bw.Body()); zerr != nil {
+				return err
+			}
+			if zerr := zw.Close(); zerr != nil {
+				return err
+			}
+			if bw.SetBody(buf.Bytes()) == nil {
+				h.Set("Content-Encoding", encoding)
+				h.Del("Content-Length")
+			}
+			return err
+		}
+	}
+}
+
+func (cfg CompressConfig) compressible(r *http.Request, bw *boar.BufferedResponseWriter) bool {
+	if r.Method == http.MethodHead || bw.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+	switch bw.Status() {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	if len(bw.Body()) < cfg.MinSize {
+		return false
+	}
+	mt, _, err := mime.ParseMediaType(bw.Header().Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	for _, allowed := range cfg.ContentTypes {
+		if allowed == mt || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mt, allowed[:len(allowed)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiate returns the registered encoding with the highest quality in an Accept-Encoding
+// header. Ties are broken by the order of cfg.Encodings
+func (cfg CompressConfig) negotiate(header string) string {
+	accepted := parseAcceptEncoding(header)
+	best, bestQ := "", 0.0
+	for _, enc := range cfg.Encodings {
+		if _, ok := encoder(enc); !ok {
+			continue
+		}
+		q, ok := accepted[enc]
+		if !ok {
+			q = accepted["*"]
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// parseAcceptEncoding returns the quality of each encoding in an Accept-Encoding header
+func parseAcceptEncoding(header string) map[string]float64 {
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				if f, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		accepted[name] = q
+	}
+	return accepted
+}
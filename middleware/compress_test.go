@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveGzip(t *testing.T, mw boar.Middleware, acceptEncoding, contentType, body string) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(mw)
+	r.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+		return c.Write(http.StatusOK, contentType, []byte(body))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGzipShouldCompressLargeResponses(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+	rec := serveGzip(t, Gzip(), "gzip, deflate", "text/plain; charset=utf-8", body)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+}
+
+func TestGzipShouldAllowSelectingNoCompression(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+	level := gzip.NoCompression
+	rec := serveGzip(t, GzipWithConfig(GzipConfig{Level: &level}), "gzip", "text/plain", body)
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	// stored blocks are larger than the body because of their framing
+	assert.True(t, rec.Body.Len() > len(body))
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+}
+
+func TestCompressShouldPassDefaultCompressionWhenNoLevelIsConfigured(t *testing.T) {
+	var levels []int
+	RegisterEncoder("reverse", func(w io.Writer, level int) (io.WriteCloser, error) {
+		levels = append(levels, level)
+		return &reverseWriter{w: w}, nil
+	})
+	defer func() {
+		encodersMu.Lock()
+		delete(encoders, "reverse")
+		encodersMu.Unlock()
+	}()
+
+	CompressWithConfig(CompressConfig{Encodings: []string{"reverse"}})
+	level := 0
+	CompressWithConfig(CompressConfig{Level: &level, Encodings: []string{"reverse"}})
+	assert.Equal(t, []int{DefaultCompression, 0}, levels)
+}
+
+func TestGzipShouldSkipSmallResponses(t *testing.T) {
+	rec := serveGzip(t, Gzip(), "gzip", "application/json", `{"a":1}`)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, `{"a":1}`, rec.Body.String())
+}
+
+func TestGzipShouldSkipClientsWhichDoNotAcceptGzip(t *testing.T) {
+	body := strings.Repeat("a", 2048)
+	rec := serveGzip(t, Gzip(), "gzip;q=0, br", "text/plain", body)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	assert.Equal(t, body, rec.Body.String())
+}
+
+func TestGzipShouldOnlyCompressAllowedContentTypes(t *testing.T) {
+	body := strings.Repeat("a", 2048)
+	mw := GzipWithConfig(GzipConfig{MinSize: 10, ContentTypes: []string{"application/json"}})
+
+	rec := serveGzip(t, mw, "gzip", "image/png", body)
+	assert.Empty(t, rec.Header().Get("Content-Encoding"))
+
+	rec = serveGzip(t, mw, "*", "application/json", body)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+}
+
+// reverseWriter is a fake encoding for testing negotiation
+type reverseWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (r *reverseWriter) Write(b []byte) (int, error) {
+	r.buf = append(r.buf, b...)
+	return len(b), nil
+}
+
+func (r *reverseWriter) Close() error {
+	for i, j := 0, len(r.buf)-1; i < j; i, j = i+1, j-1 {
+		r.buf[i], r.buf[j] = r.buf[j], r.buf[i]
+	}
+	_, err := r.w.Write(r.buf)
+	return err
+}
+
+func TestCompressShouldSelectEncodingByPreference(t *testing.T) {
+	RegisterEncoder("reverse", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return &reverseWriter{w: w}, nil
+	})
+	defer func() {
+		encodersMu.Lock()
+		delete(encoders, "reverse")
+		encodersMu.Unlock()
+	}()
+
+	body := strings.Repeat("a", 2000) + "b"
+	mw := CompressWithConfig(CompressConfig{Encodings: []string{"reverse", "gzip"}})
+
+	rec := serveGzip(t, mw, "gzip, reverse", "text/plain", body)
+	assert.Equal(t, "reverse", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "b", rec.Body.String()[:1])
+
+	rec = serveGzip(t, mw, "gzip, reverse;q=0.5", "text/plain", body)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+}
+
+func TestCompressShouldIgnoreUnregisteredEncodings(t *testing.T) {
+	body := strings.Repeat("a", 2048)
+	rec := serveGzip(t, CompressWithConfig(CompressConfig{Encodings: []string{"snappy", "gzip"}}), "snappy, gzip;q=0.1", "text/plain", body)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+}
+
+func TestCompressWithConfigShouldCloseTheLevelProbe(t *testing.T) {
+	var opened, closed int
+	RegisterEncoder("reverse", func(w io.Writer, level int) (io.WriteCloser, error) {
+		opened++
+		return &closeCounter{WriteCloser: &reverseWriter{w: w}, closed: &closed}, nil
+	})
+	defer func() {
+		encodersMu.Lock()
+		delete(encoders, "reverse")
+		encodersMu.Unlock()
+	}()
+
+	CompressWithConfig(CompressConfig{Encodings: []string{"reverse"}})
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 1, closed)
+}
+
+type closeCounter struct {
+	io.WriteCloser
+	closed *int
+}
+
+func (c *closeCounter) Close() error {
+	*c.closed++
+	return c.WriteCloser.Close()
+}
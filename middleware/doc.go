@@ -0,0 +1,3 @@
+// Package middleware provides common boar.Middleware such as access logging. Any of them
+// can be bypassed for some requests with boar.Skip
+package middleware
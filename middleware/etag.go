@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/blockloop/boar"
+)
+
+// ETagConfig configures the ETag middleware
+type ETagConfig struct {
+	// Weak generates weak validators (W/"...") which only claim that responses are
+	// semantically equivalent. Default is strong validators
+	Weak bool
+}
+
+// ETag creates a middleware which generates strong ETags using the default ETagConfig
+func ETag() boar.Middleware {
+	return ETagWithConfig(ETagConfig{})
+}
+
+// ETagWithConfig creates a middleware which sets an ETag computed from the buffered body of
+// successful GET and HEAD responses and answers requests whose If-None-Match header matches
+// it with 304 Not Modified. Responses which already have an ETag or have already been sent,
+// such as streams, are not changed. The ETag is computed over the body as it is when the
+// handler returns, so the middleware should be added after Compress to tag the
+// compressed representation.
+//
+// Example:
+//
+//	rtr.Use(middleware.ETag())
+func ETagWithConfig(cfg ETagConfig) boar.Middleware {
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			err := next(c)
+			if err != nil {
+				return err
+			}
+
+			r := c.Request()
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				return nil
+			}
+			bw, ok := c.Response().(*boar.BufferedResponseWriter)
+			if !ok {
+				return nil
+			}
+			if status := bw.Status(); status != 0 && (status < 200 || status > 299) {
+				return nil
+			}
+			h := bw.Header()
+			if h.Get("ETag") != "" {
+				return nil
+			}
+
+			etag := cfg.generate(bw.Body())
+			h.Set("ETag", etag)
+			if !etagMatch(r.Header.Get("If-None-Match"), etag) {
+				return nil
+			}
+			if bw.SetBody(nil) != nil {
+				return nil
+			}
+			h.Del("Content-Length")
+			bw.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+}
+
+func (cfg ETagConfig) generate(body []byte) string {
+	sum := sha1.Sum(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	if cfg.Weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// etagMatch reports whether an If-None-Match header matches etag using the weak comparison
+// required for If-None-Match by RFC 7232
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveETag(mw boar.Middleware, method, ifNoneMatch string, h boar.HandlerFunc) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(mw)
+	r.MethodFunc(method, "/", h)
+	req := httptest.NewRequest(method, "/", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestETagShouldSetStrongETag(t *testing.T) {
+	rec := serveETag(ETag(), http.MethodGet, "", okHandler)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"7a85f4764bbd6daf1c3545efbbf0f279a6dc0beb"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "ok", rec.Body.String())
+}
+
+func TestETagShouldSetWeakETag(t *testing.T) {
+	rec := serveETag(ETagWithConfig(ETagConfig{Weak: true}), http.MethodGet, "", okHandler)
+	assert.Equal(t, `W/"7a85f4764bbd6daf1c3545efbbf0f279a6dc0beb"`, rec.Header().Get("ETag"))
+}
+
+func TestETagShouldRespondNotModifiedWhenMatched(t *testing.T) {
+	tests := []string{
+		`"7a85f4764bbd6daf1c3545efbbf0f279a6dc0beb"`,
+		`W/"7a85f4764bbd6daf1c3545efbbf0f279a6dc0beb"`,
+		`"other", "7a85f4764bbd6daf1c3545efbbf0f279a6dc0beb"`,
+		`*`,
+	}
+	for _, ifNoneMatch := range tests {
+		rec := serveETag(ETag(), http.MethodGet, ifNoneMatch, okHandler)
+		assert.Equal(t, http.StatusNotModified, rec.Code, ifNoneMatch)
+		assert.Empty(t, rec.Body.String(), ifNoneMatch)
+		assert.NotEmpty(t, rec.Header().Get("ETag"), ifNoneMatch)
+	}
+}
+
+func TestETagShouldRespondNormallyWhenNotMatched(t *testing.T) {
+	rec := serveETag(ETag(), http.MethodGet, `"other"`, okHandler)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+}
+
+func TestETagShouldNotReplaceExistingETag(t *testing.T) {
+	rec := serveETag(ETag(), http.MethodGet, "", func(c boar.Context) error {
+		c.Response().Header().Set("ETag", `"v1"`)
+		return okHandler(c)
+	})
+	assert.Equal(t, `"v1"`, rec.Header().Get("ETag"))
+}
+
+func TestETagShouldIgnoreUnsafeMethodsAndErrors(t *testing.T) {
+	rec := serveETag(ETag(), http.MethodPost, "*", okHandler)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	rec = serveETag(ETag(), http.MethodGet, "*", func(c boar.Context) error {
+		return c.WriteString(http.StatusNotFound, "missing")
+	})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
@@ -0,0 +1,430 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// DefaultJWTContextKey is the Context key the JWT middleware stores Claims under when no
+// ContextKey is configured. Handlers can receive them with a field tagged `ctx:"jwt"`
+const DefaultJWTContextKey = "jwt"
+
+var (
+	errMissingToken     = errors.New("missing bearer token")
+	errMalformedToken   = errors.New("malformed token")
+	errInvalidSignature = errors.New("invalid signature")
+	errTokenExpired     = errors.New("token is expired")
+	errTokenNotYetValid = errors.New("token is not valid yet")
+	errInvalidIssuer    = errors.New("invalid issuer")
+	errInvalidAudience  = errors.New("invalid audience")
+)
+
+// Claims are the claims of a validated JWT
+type Claims map[string]interface{}
+
+// Subject returns the sub claim
+func (c Claims) Subject() string {
+	s, _ := c["sub"].(string)
+	return s
+}
+
+// Issuer returns the iss claim
+func (c Claims) Issuer() string {
+	s, _ := c["iss"].(string)
+	return s
+}
+
+// Audience returns the aud claim which may be a single string or a list
+func (c Claims) Audience() []string {
+	switch aud := c["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []interface{}:
+		auds := make([]string, 0, len(aud))
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				auds = append(auds, s)
+			}
+		}
+		return auds
+	}
+	return nil
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	f, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+// KeyFunc returns the key used to verify a token signed with alg by the key with the
+// given kid. HMAC algorithms require a []byte and RSA algorithms an *rsa.PublicKey
+type KeyFunc func(alg, kid string) (interface{}, error)
+
+// HMACKey creates a KeyFunc for tokens signed with a shared secret
+func HMACKey(secret []byte) KeyFunc {
+	return func(string, string) (interface{}, error) {
+		return secret, nil
+	}
+}
+
+// RSAKey creates a KeyFunc for tokens signed by the private key of pub
+func RSAKey(pub *rsa.PublicKey) KeyFunc {
+	return func(string, string) (interface{}, error) {
+		return pub, nil
+	}
+}
+
+// JWTConfig configures the JWT middleware
+type JWTConfig struct {
+	// KeyFunc returns the key used to verify each token. See HMACKey, RSAKey, and JWKS
+	KeyFunc KeyFunc
+	// Algorithms are the accepted signing algorithms. Default is HS256, HS384, HS512,
+	// RS256, RS384, and RS512
+	Algorithms []string
+	// Issuer is the required iss claim when it is not empty
+	Issuer string
+	// Audience is a required entry of the aud claim when it is not empty
+	Audience string
+	// Leeway is the clock skew allowed when validating exp and nbf
+	Leeway time.Duration
+	// ContextKey is the key the Claims are stored under with Context.Set. Default is
+	// DefaultJWTContextKey
+	ContextKey string
+	// Realm is the realm of the WWW-Authenticate header sent with rejected requests
+	Realm string
+}
+
+// JWT creates a middleware which requires requests to have a bearer token signed with the
+// HMAC secret. See JWTWithConfig
+func JWT(secret []byte) boar.Middleware {
+	return JWTWithConfig(JWTConfig{KeyFunc: HMACKey(secret)})
+}
+
+// JWTWithConfig creates a middleware which validates the bearer token of each request and
+// stores its Claims on the Context. Requests without a valid token are rejected with
+// boar.ErrUnauthorized and a WWW-Authenticate header.
+//
+// Example:
+//
+//	rtr.Use(middleware.JWTWithConfig(middleware.JWTConfig{
+//		KeyFunc:  middleware.JWKS("https://example.com/.well-known/jwks.json"),
+//		Audience: "api",
+//	}))
+//	...
+//	type ProfileHandler struct {
+//		Claims middleware.Claims `ctx:"jwt"`
+//	}
+func JWTWithConfig(cfg JWTConfig) boar.Middleware {
+	if cfg.KeyFunc == nil {
+		panic("JWT middleware requires a KeyFunc")
+	}
+	if len(cfg.Algorithms) == 0 {
+		cfg.Algorithms = []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512"}
+	}
+	if cfg.ContextKey == "" {
+		cfg.ContextKey = DefaultJWTContextKey
+	}
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			claims, err := cfg.authenticate(c.Request())
+			if err != nil {
+				c.Response().Header().Set("WWW-Authenticate", cfg.challenge(err))
+				return boar.ErrUnauthorized
+			}
+			c.Set(cfg.ContextKey, claims)
+			return next(c)
+		}
+	}
+}
+
+func (cfg JWTConfig) challenge(err error) string {
+	var b bytes.Buffer
+	b.WriteString("Bearer")
+	if cfg.Realm != "" {
+		fmt.Fprintf(&b, " realm=%q,", cfg.Realm)
+	}
+	if err == errMissingToken {
+		return strings.TrimSuffix(b.String(), ",")
+	}
+	fmt.Fprintf(&b, " error=\"invalid_token\", error_description=%q", challengeDescription(err))
+	return b.String()
+}
+
+// challengeDescription is the error_description sent to the client. Only fixed messages are
+// used so that internal errors, such as those of a KeyFunc, are not disclosed
+func challengeDescription(err error) string {
+	switch err {
+	case errMalformedToken, errInvalidSignature, errTokenExpired, errTokenNotYetValid,
+		errInvalidIssuer, errInvalidAudience:
+		return err.Error()
+	}
+	return "invalid token"
+}
+
+func (cfg JWTConfig) authenticate(r *http.Request) (Claims, error) {
+	auth := r.Header.Get("Authorization")
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		return nil, errMissingToken
+	}
+	return cfg.parse(strings.TrimSpace(auth[7:]))
+}
+
+// parse verifies the signature of the token and validates its registered claims
+func (cfg JWTConfig) parse(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errMalformedToken
+	}
+	if !cfg.allowed(header.Alg) {
+		return nil, fmt.Errorf("unexpected signing algorithm %q", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	key, err := cfg.KeyFunc(header.Alg, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errMalformedToken
+	}
+	return claims, cfg.validate(claims)
+}
+
+func (cfg JWTConfig) allowed(alg string) bool {
+	for _, a := range cfg.Algorithms {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg JWTConfig) validate(claims Claims) error {
+	now := time.Now()
+	if exp, ok := claims.time("exp"); ok && now.After(exp.Add(cfg.Leeway)) {
+		return errTokenExpired
+	}
+	if nbf, ok := claims.time("nbf"); ok && now.Before(nbf.Add(-cfg.Leeway)) {
+		return errTokenNotYetValid
+	}
+	if cfg.Issuer != "" && claims.Issuer() != cfg.Issuer {
+		return errInvalidIssuer
+	}
+	if cfg.Audience != "" {
+		for _, aud := range claims.Audience() {
+			if aud == cfg.Audience {
+				return nil
+			}
+		}
+		return errInvalidAudience
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func verify(alg string, key interface{}, signed string, sig []byte) error {
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%s requires a []byte key", alg)
+		}
+		var mac = hmac.New(sha256.New, secret)
+		switch hash {
+		case crypto.SHA384:
+			mac = hmac.New(sha512.New384, secret)
+		case crypto.SHA512:
+			mac = hmac.New(sha512.New, secret)
+		}
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return errInvalidSignature
+		}
+		return nil
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an *rsa.PublicKey", alg)
+		}
+		h := hash.New()
+		h.Write([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), sig); err != nil {
+			return errInvalidSignature
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+// JWKSRefreshInterval is the minimum time between fetches of a JWKS when a token has an
+// unknown kid
+var JWKSRefreshInterval = time.Minute
+
+// JWKS creates a KeyFunc which verifies tokens with the RSA keys of a JSON Web Key Set.
+// The set is fetched on first use and fetched again, at most every JWKSRefreshInterval,
+// when a token is signed by an unknown key
+func JWKS(url string) KeyFunc {
+	set := &jwks{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	return set.key
+}
+
+type jwks struct {
+	url     string
+	client  *http.Client
+	m       sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+	// pending is the fetch in progress, if any, which concurrent lookups wait for
+	pending *jwksFetch
+}
+
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+func (j *jwks) key(alg, kid string) (interface{}, error) {
+	j.m.Lock()
+	if key, ok := j.keys[kid]; ok {
+		j.m.Unlock()
+		return key, nil
+	}
+	f := j.pending
+	if f == nil {
+		if time.Since(j.fetched) < JWKSRefreshInterval {
+			j.m.Unlock()
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		// the set is fetched without holding the lock so that lookups of known keys are
+		// not blocked by the request
+		f = &jwksFetch{done: make(chan struct{})}
+		j.pending = f
+		j.fetched = time.Now()
+		j.m.Unlock()
+
+		keys, err := j.fetch()
+		j.m.Lock()
+		if err == nil {
+			j.keys = keys
+		}
+		f.err = err
+		j.pending = nil
+		close(f.done)
+	}
+	j.m.Unlock()
+
+	<-f.done
+	if f.err != nil {
+		return nil, f.err
+	}
+	j.m.Lock()
+	defer j.m.Unlock()
+	if key, ok := j.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+func (j *jwks) fetch() (map[string]*rsa.PublicKey, error) {
+	resp, err := j.client.Get(j.url)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch JWKS: %+v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("could not decode JWKS: %+v", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
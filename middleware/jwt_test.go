@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func segment(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hs256Token(t *testing.T, secret []byte, claims Claims) string {
+	signed := segment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func rs256Token(t *testing.T, key *rsa.PrivateKey, kid string, claims Claims) string {
+	signed := segment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + segment(t, claims)
+	h := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+type profileHandler struct {
+	Claims Claims `ctx:"jwt"`
+}
+
+func (h *profileHandler) Handle(c boar.Context) error {
+	return c.WriteString(http.StatusOK, h.Claims.Subject())
+}
+
+func serveJWT(mw boar.Middleware, token string) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(mw)
+	r.Get("/", func(boar.Context) (boar.Handler, error) {
+		return &profileHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestJWTShouldBindClaimsIntoHandlers(t *testing.T) {
+	secret := []byte("secret")
+	token := hs256Token(t, secret, Claims{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()})
+
+	rec := serveJWT(JWT(secret), token)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user-1", rec.Body.String())
+}
+
+func TestJWTShouldRejectMissingTokens(t *testing.T) {
+	rec := serveJWT(JWTWithConfig(JWTConfig{KeyFunc: HMACKey([]byte("secret")), Realm: "api"}), "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="api"`, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestJWTShouldRejectInvalidTokens(t *testing.T) {
+	secret := []byte("secret")
+	cases := map[string]string{
+		"bad signature": hs256Token(t, []byte("other"), Claims{"sub": "a"}),
+		"expired":       hs256Token(t, secret, Claims{"exp": time.Now().Add(-time.Hour).Unix()}),
+		"not yet valid": hs256Token(t, secret, Claims{"nbf": time.Now().Add(time.Hour).Unix()}),
+		"wrong issuer":  hs256Token(t, secret, Claims{"iss": "someone-else", "aud": "api"}),
+		"malformed":     "abc.def",
+		"alg none":      segment(t, map[string]string{"alg": "none"}) + "." + segment(t, Claims{}) + ".",
+	}
+	mw := JWTWithConfig(JWTConfig{KeyFunc: HMACKey(secret), Issuer: "boar"})
+	for name, token := range cases {
+		rec := serveJWT(mw, token)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, name)
+		assert.Contains(t, rec.Header().Get("WWW-Authenticate"), `error="invalid_token"`, name)
+	}
+}
+
+func TestJWTShouldValidateAudience(t *testing.T) {
+	secret := []byte("secret")
+	mw := JWTWithConfig(JWTConfig{KeyFunc: HMACKey(secret), Audience: "api"})
+
+	rec := serveJWT(mw, hs256Token(t, secret, Claims{"sub": "a", "aud": []string{"web", "api"}}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJWT(mw, hs256Token(t, secret, Claims{"sub": "a", "aud": "web"}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestJWTShouldVerifyRSAKeysFromJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	mw := JWTWithConfig(JWTConfig{KeyFunc: JWKS(srv.URL)})
+	rec := serveJWT(mw, rs256Token(t, key, "key-1", Claims{"sub": "rsa-user"}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "rsa-user", rec.Body.String())
+
+	rec = serveJWT(mw, rs256Token(t, key, "key-2", Claims{"sub": "rsa-user"}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, 1, fetches)
+}
+
+func TestJWTShouldRejectHMACTokensForRSAKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	token := hs256Token(t, key.PublicKey.N.Bytes(), Claims{"sub": "attacker"})
+	rec := serveJWT(JWTWithConfig(JWTConfig{KeyFunc: RSAKey(&key.PublicKey)}), token)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestJWKSShouldNotHoldTheLockWhileFetching(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var fetches int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{}})
+	}))
+	defer srv.Close()
+
+	set := &jwks{url: srv.URL, client: srv.Client()}
+	set.keys = map[string]*rsa.PublicKey{"known": &key.PublicKey}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := set.key("RS256", "unknown")
+			assert.Error(t, err)
+		}()
+	}
+
+	for atomic.LoadInt32(&fetches) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// known keys are returned while the set is being fetched
+	done := make(chan struct{})
+	go func() {
+		k, err := set.key("RS256", "known")
+		assert.NoError(t, err)
+		assert.Equal(t, &key.PublicKey, k)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lookup of a known key was blocked by the fetch")
+	}
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
+
+func TestJWTShouldNotDiscloseKeyErrors(t *testing.T) {
+	mw := JWTWithConfig(JWTConfig{KeyFunc: func(alg, kid string) (interface{}, error) {
+		return nil, errors.New("dial tcp 10.0.0.5:443: connection refused")
+	}})
+
+	rec := serveJWT(mw, hs256Token(t, []byte("secret"), Claims{"sub": "a"}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer error="invalid_token", error_description="invalid token"`, rec.Header().Get("WWW-Authenticate"))
+}
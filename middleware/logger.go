@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// RequestIDHeader is the header used to find the ID of a request. The response header is
+// preferred over the request header so that IDs generated by the server are logged
+var RequestIDHeader = "X-Request-Id"
+
+// Logger creates a middleware which logs the method, path, route pattern, status, latency,
+// bytes written, and request ID of every request. Server errors are logged with Error and everything else
+// with Info. The middleware should be added after the error handling middleware so that the
+// status written by the ErrorHandler is logged. The Router's Logger is used when l is nil.
+//
+// Example:
+//
+//	rtr.Use(middleware.Logger(slog.Default()))
+func Logger(l boar.Logger) boar.Middleware {
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			keyvals := []interface{}{
+				"method", c.Request().Method,
+				"path", c.Request().URL.Path,
+				"route", c.RoutePattern(),
+				"status", status,
+				"latency", time.Since(start),
+				"bytes", c.Response().BytesWritten(),
+				"request_id", requestID(c),
+			}
+			if err != nil {
+				keyvals = append(keyvals, "error", err.Error())
+			}
+
+			logger := l
+			if logger == nil {
+				logger = c.Logger()
+			}
+			if status >= http.StatusInternalServerError {
+				logger.Error("request", keyvals...)
+			} else {
+				logger.Info("request", keyvals...)
+			}
+			return err
+		}
+	}
+}
+
+func requestID(c boar.Context) string {
+	if id := c.Response().Header().Get(RequestIDHeader); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(RequestIDHeader)
+}
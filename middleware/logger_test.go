@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entry struct {
+	level   string
+	msg     string
+	keyvals map[string]interface{}
+}
+
+type recordingLogger struct {
+	entries []entry
+}
+
+func (r *recordingLogger) record(level, msg string, keyvals []interface{}) {
+	e := entry{level: level, msg: msg, keyvals: map[string]interface{}{}}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		e.keyvals[keyvals[i].(string)] = keyvals[i+1]
+	}
+	r.entries = append(r.entries, e)
+}
+
+func (r *recordingLogger) Info(msg string, keyvals ...interface{})  { r.record("info", msg, keyvals) }
+func (r *recordingLogger) Warn(msg string, keyvals ...interface{})  { r.record("warn", msg, keyvals) }
+func (r *recordingLogger) Error(msg string, keyvals ...interface{}) { r.record("error", msg, keyvals) }
+
+func TestLoggerShouldLogRequests(t *testing.T) {
+	l := &recordingLogger{}
+	r := boar.NewRouter()
+	r.Use(Logger(l))
+	r.MethodFunc(http.MethodPost, "/users/:id", func(c boar.Context) error {
+		return c.WriteString(http.StatusCreated, "created")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/7", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, l.entries, 1)
+	e := l.entries[0]
+	assert.Equal(t, "info", e.level)
+	assert.Equal(t, http.MethodPost, e.keyvals["method"])
+	assert.Equal(t, "/users/7", e.keyvals["path"])
+	assert.Equal(t, "/users/:id", e.keyvals["route"])
+	assert.Equal(t, http.StatusCreated, e.keyvals["status"])
+	assert.Equal(t, len("created"), e.keyvals["bytes"])
+	assert.Equal(t, "abc", e.keyvals["request_id"])
+	assert.IsType(t, time.Duration(0), e.keyvals["latency"])
+}
+
+func TestLoggerShouldLogServerErrorsAsErrors(t *testing.T) {
+	l := &recordingLogger{}
+	r := boar.NewRouter()
+	r.Use(Logger(l))
+	r.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+		return errors.New("boom")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Len(t, l.entries, 1)
+	assert.Equal(t, "error", l.entries[0].level)
+	assert.Equal(t, http.StatusInternalServerError, l.entries[0].keyvals["status"])
+	assert.Equal(t, "boom", l.entries[0].keyvals["error"])
+}
+
+func TestLoggerShouldUseRouterLoggerWhenNil(t *testing.T) {
+	l := &recordingLogger{}
+	r := boar.NewRouter()
+	r.Logger = l
+	r.Use(Logger(nil))
+	r.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Len(t, l.entries, 1)
+	assert.Equal(t, "info", l.entries[0].level)
+}
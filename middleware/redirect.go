@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// HTTPSRedirectConfig configures the HTTPSRedirect middleware
+type HTTPSRedirectConfig struct {
+	// Code is the status code of redirects. Default is http.StatusMovedPermanently.
+	// http.StatusPermanentRedirect preserves the method and body of requests
+	Code int
+	// Host replaces the host of the request in redirects when it is not empty. The port of
+	// the request is otherwise dropped, so Host is needed to redirect to a non-default port
+	Host string
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header sent with https
+	// responses. Zero disables the header
+	HSTSMaxAge time.Duration
+	// HSTSIncludeSubdomains adds includeSubDomains to the Strict-Transport-Security header
+	HSTSIncludeSubdomains bool
+	// HSTSPreload adds preload to the Strict-Transport-Security header
+	HSTSPreload bool
+}
+
+// DefaultHTTPSRedirectConfig is the HTTPSRedirectConfig used by HTTPSRedirect
+var DefaultHTTPSRedirectConfig = HTTPSRedirectConfig{
+	Code:                  http.StatusMovedPermanently,
+	HSTSMaxAge:            365 * 24 * time.Hour,
+	HSTSIncludeSubdomains: true,
+}
+
+// HTTPSRedirect creates a middleware which redirects http requests to https with
+// DefaultHTTPSRedirectConfig
+func HTTPSRedirect() boar.Middleware {
+	return HTTPSRedirectWithConfig(DefaultHTTPSRedirectConfig)
+}
+
+// HTTPSRedirectWithConfig creates a middleware which redirects http requests to the same
+// URL with https and sets the Strict-Transport-Security header on https responses. The
+// scheme is determined by Context.BaseURL, so applications behind a proxy which terminates
+// TLS must add the proxy to the Router's TrustedProxies for its X-Forwarded-Proto or
+// Forwarded header to be used. Otherwise every request is redirected.
+//
+// Example:
+//
+//	rtr.TrustedProxies, _ = boar.ParseCIDRs("10.0.0.0/8")
+//	rtr.UseBefore(middleware.HTTPSRedirect())
+func HTTPSRedirectWithConfig(cfg HTTPSRedirectConfig) boar.Middleware {
+	if cfg.Code == 0 {
+		cfg.Code = http.StatusMovedPermanently
+	}
+	hsts := hstsHeader(cfg.HSTSMaxAge, cfg.HSTSIncludeSubdomains, cfg.HSTSPreload)
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			base := c.BaseURL()
+			if strings.HasPrefix(base, "https://") {
+				if hsts != "" {
+					c.Response().Header().Set("Strict-Transport-Security", hsts)
+				}
+				return next(c)
+			}
+
+			host := cfg.Host
+			if host == "" {
+				host = stripPort(strings.TrimPrefix(base, "http://"))
+			}
+			return c.Redirect(cfg.Code, "https://"+host+c.Request().URL.RequestURI())
+		}
+	}
+}
+
+func stripPort(host string) string {
+	h, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	if strings.Contains(h, ":") {
+		return "[" + h + "]"
+	}
+	return h
+}
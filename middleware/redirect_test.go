@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveRedirect(r *boar.Router, req *http.Request) *httptest.ResponseRecorder {
+	r.MethodFunc(http.MethodGet, "/*path", okHandler)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHTTPSRedirectShouldRedirectHTTPRequests(t *testing.T) {
+	tests := []struct {
+		host string
+		cfg  HTTPSRedirectConfig
+		code int
+		want string
+	}{
+		{"example.com", DefaultHTTPSRedirectConfig, http.StatusMovedPermanently, "https://example.com/users?page=2"},
+		{"example.com:8080", DefaultHTTPSRedirectConfig, http.StatusMovedPermanently, "https://example.com/users?page=2"},
+		{"[::1]:8080", DefaultHTTPSRedirectConfig, http.StatusMovedPermanently, "https://[::1]/users?page=2"},
+		{"example.com", HTTPSRedirectConfig{Code: http.StatusPermanentRedirect, Host: "example.com:8443"}, http.StatusPermanentRedirect, "https://example.com:8443/users?page=2"},
+	}
+	for _, tt := range tests {
+		r := boar.NewRouter()
+		r.Use(HTTPSRedirectWithConfig(tt.cfg))
+		req := httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+		req.Host = tt.host
+		rec := serveRedirect(r, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.host)
+		assert.Equal(t, tt.want, rec.Header().Get("Location"), tt.host)
+		assert.Empty(t, rec.Header().Get("Strict-Transport-Security"), tt.host)
+	}
+}
+
+func TestHTTPSRedirectShouldSetHSTSForHTTPSRequests(t *testing.T) {
+	r := boar.NewRouter()
+	r.Use(HTTPSRedirect())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{}
+	rec := serveRedirect(r, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "max-age=31536000; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+}
+
+func TestHTTPSRedirectShouldTrustForwardedProtoFromTrustedProxies(t *testing.T) {
+	proxies, err := boar.ParseCIDRs("10.0.0.0/8")
+	require.NoError(t, err)
+
+	r := boar.NewRouter()
+	r.TrustedProxies = proxies
+	r.Use(HTTPSRedirect())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rec := serveRedirect(r, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.168.0.1:1234"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+}
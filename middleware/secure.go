@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// SecureConfig configures the security headers set by the Secure middleware. Empty values
+// are not sent
+type SecureConfig struct {
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header which is only sent
+	// for https requests. Zero disables the header
+	HSTSMaxAge time.Duration
+	// HSTSIncludeSubdomains adds includeSubDomains to the Strict-Transport-Security header
+	HSTSIncludeSubdomains bool
+	// HSTSPreload adds preload to the Strict-Transport-Security header
+	HSTSPreload bool
+	// ContentTypeOptions is the X-Content-Type-Options header
+	ContentTypeOptions string
+	// FrameOptions is the X-Frame-Options header
+	FrameOptions string
+	// ReferrerPolicy is the Referrer-Policy header
+	ReferrerPolicy string
+	// ContentSecurityPolicy is the Content-Security-Policy header
+	ContentSecurityPolicy string
+}
+
+// DefaultSecureConfig is the SecureConfig used by Secure
+var DefaultSecureConfig = SecureConfig{
+	HSTSMaxAge:            365 * 24 * time.Hour,
+	HSTSIncludeSubdomains: true,
+	ContentTypeOptions:    "nosniff",
+	FrameOptions:          "DENY",
+	ReferrerPolicy:        "strict-origin-when-cross-origin",
+	ContentSecurityPolicy: "default-src 'self'",
+}
+
+// Secure creates a middleware which sets security headers with DefaultSecureConfig
+func Secure() boar.Middleware {
+	return SecureWithConfig(DefaultSecureConfig)
+}
+
+// SecureWithConfig creates a middleware which sets security headers. The headers are set
+// before the handler is executed so that routes can override them by setting or deleting
+// them from the response headers.
+//
+// Example:
+//
+//	cfg := middleware.DefaultSecureConfig
+//	cfg.ContentSecurityPolicy = "default-src 'self'; img-src *"
+//	rtr.Use(middleware.SecureWithConfig(cfg))
+//	...
+//	// allow a single route to be framed
+//	c.Response().Header().Del("X-Frame-Options")
+func SecureWithConfig(cfg SecureConfig) boar.Middleware {
+	var headers [][2]string
+	add := func(name, value string) {
+		if value != "" {
+			headers = append(headers, [2]string{name, value})
+		}
+	}
+	add("X-Content-Type-Options", cfg.ContentTypeOptions)
+	add("X-Frame-Options", cfg.FrameOptions)
+	add("Referrer-Policy", cfg.ReferrerPolicy)
+	add("Content-Security-Policy", cfg.ContentSecurityPolicy)
+
+	hsts := hstsHeader(cfg.HSTSMaxAge, cfg.HSTSIncludeSubdomains, cfg.HSTSPreload)
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			h := c.Response().Header()
+			for _, header := range headers {
+				h.Set(header[0], header[1])
+			}
+			if hsts != "" && strings.HasPrefix(c.BaseURL(), "https://") {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			return next(c)
+		}
+	}
+}
+
+// hstsHeader returns the value of a Strict-Transport-Security header or an empty string
+// when maxAge is not positive
+func hstsHeader(maxAge time.Duration, includeSubdomains, preload bool) string {
+	if maxAge <= 0 {
+		return ""
+	}
+	hsts := fmt.Sprintf("max-age=%d", int64(maxAge/time.Second))
+	if includeSubdomains {
+		hsts += "; includeSubDomains"
+	}
+	if preload {
+		hsts += "; preload"
+	}
+	return hsts
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveSecure(mw boar.Middleware, req *http.Request, h boar.HandlerFunc) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(mw)
+	r.MethodFunc(http.MethodGet, "/", h)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func okHandler(c boar.Context) error {
+	return c.WriteString(http.StatusOK, "ok")
+}
+
+func TestSecureShouldSetDefaultHeaders(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{}
+	rec := serveSecure(Secure(), req, okHandler)
+
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "strict-origin-when-cross-origin", rec.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "default-src 'self'", rec.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+}
+
+func TestSecureShouldNotSendHSTSOverHTTP(t *testing.T) {
+	rec := serveSecure(Secure(), httptest.NewRequest(http.MethodGet, "/", nil), okHandler)
+	assert.Empty(t, rec.Header().Get("Strict-Transport-Security"))
+}
+
+func TestSecureHeadersCanBeOverriddenByRoutes(t *testing.T) {
+	rec := serveSecure(Secure(), httptest.NewRequest(http.MethodGet, "/", nil), func(c boar.Context) error {
+		c.Response().Header().Set("Content-Security-Policy", "default-src *")
+		c.Response().Header().Del("X-Frame-Options")
+		return okHandler(c)
+	})
+	assert.Equal(t, "default-src *", rec.Header().Get("Content-Security-Policy"))
+	_, ok := rec.Header()["X-Frame-Options"]
+	assert.False(t, ok)
+}
+
+func TestSecureWithConfigShouldSkipEmptyValues(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.TLS = &tls.ConnectionState{}
+	rec := serveSecure(SecureWithConfig(SecureConfig{HSTSMaxAge: time.Hour, HSTSPreload: true, FrameOptions: "SAMEORIGIN"}), req, okHandler)
+
+	assert.Equal(t, "SAMEORIGIN", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "max-age=3600; preload", rec.Header().Get("Strict-Transport-Security"))
+	assert.Empty(t, rec.Header().Get("Content-Security-Policy"))
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// SlowRequestConfig configures the middleware created by SlowRequestsWithConfig
+type SlowRequestConfig struct {
+	// Threshold is the duration after which requests are logged as slow
+	Threshold time.Duration
+	// Logger logs slow requests with Warn. The Router's Logger is used when it is nil
+	Logger boar.Logger
+	// StackSampleRate is the fraction, between 0 and 1, of requests whose goroutine
+	// stacks are captured when they exceed Threshold while still running. The stacks show
+	// where slow requests were blocked. Capturing stacks stops the world briefly so the
+	// rate should be low in production. No stacks are captured when it is zero
+	StackSampleRate float64
+}
+
+// SlowRequests creates a middleware which logs requests that take longer than threshold.
+// See SlowRequestsWithConfig
+func SlowRequests(threshold time.Duration) boar.Middleware {
+	return SlowRequestsWithConfig(SlowRequestConfig{Threshold: threshold})
+}
+
+// SlowRequestsWithConfig creates a middleware which logs the method, path, route pattern,
+// status, and latency of requests that take longer than the Threshold, along with the
+// goroutine stacks of sampled requests, to surface tail latency offenders.
+//
+// Example:
+//
+//	rtr.Use(middleware.SlowRequestsWithConfig(middleware.SlowRequestConfig{
+//		Threshold:       time.Second,
+//		StackSampleRate: 0.01,
+//	}))
+func SlowRequestsWithConfig(cfg SlowRequestConfig) boar.Middleware {
+	if cfg.Threshold <= 0 {
+		panic("SlowRequests middleware requires a positive Threshold")
+	}
+
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			start := time.Now()
+
+			var (
+				m     sync.Mutex
+				stack []byte
+			)
+			if cfg.StackSampleRate > 0 && rand.Float64() < cfg.StackSampleRate {
+				timer := time.AfterFunc(cfg.Threshold, func() {
+					s := captureStacks()
+					m.Lock()
+					stack = s
+					m.Unlock()
+				})
+				defer timer.Stop()
+			}
+
+			err := next(c)
+			latency := time.Since(start)
+			if latency < cfg.Threshold {
+				return err
+			}
+
+			status := c.Response().Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			keyvals := []interface{}{
+				"method", c.Request().Method,
+				"path", c.Request().URL.Path,
+				"route", c.RoutePattern(),
+				"status", status,
+				"latency", latency,
+				"threshold", cfg.Threshold,
+			}
+			m.Lock()
+			if stack != nil {
+				keyvals = append(keyvals, "stack", string(stack))
+			}
+			m.Unlock()
+
+			logger := cfg.Logger
+			if logger == nil {
+				logger = c.Logger()
+			}
+			logger.Warn("slow request", keyvals...)
+			return err
+		}
+	}
+}
+
+// captureStacks returns the stacks of every goroutine
+func captureStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
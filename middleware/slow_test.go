@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveSlow(cfg SlowRequestConfig, delay time.Duration) *recordingLogger {
+	l := &recordingLogger{}
+	cfg.Logger = l
+	r := boar.NewRouter()
+	r.Use(SlowRequestsWithConfig(cfg))
+	r.MethodFunc(http.MethodGet, "/reports/:id", func(c boar.Context) error {
+		time.Sleep(delay)
+		return c.WriteStatus(http.StatusAccepted)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/reports/7", nil))
+	return l
+}
+
+func TestSlowRequestsShouldLogSlowRequests(t *testing.T) {
+	l := serveSlow(SlowRequestConfig{Threshold: 10 * time.Millisecond}, 20*time.Millisecond)
+
+	require.Len(t, l.entries, 1)
+	e := l.entries[0]
+	assert.Equal(t, "warn", e.level)
+	assert.Equal(t, "slow request", e.msg)
+	assert.Equal(t, "/reports/7", e.keyvals["path"])
+	assert.Equal(t, "/reports/:id", e.keyvals["route"])
+	assert.Equal(t, http.StatusAccepted, e.keyvals["status"])
+	assert.NotContains(t, e.keyvals, "stack")
+}
+
+func TestSlowRequestsShouldNotLogFastRequests(t *testing.T) {
+	l := serveSlow(SlowRequestConfig{Threshold: time.Second}, 0)
+	assert.Empty(t, l.entries)
+}
+
+func TestSlowRequestsShouldSampleStacks(t *testing.T) {
+	l := serveSlow(SlowRequestConfig{Threshold: 10 * time.Millisecond, StackSampleRate: 1}, 50*time.Millisecond)
+
+	require.Len(t, l.entries, 1)
+	assert.Contains(t, l.entries[0].keyvals["stack"], "goroutine")
+}
+
+func TestSlowRequestsShouldRequireThreshold(t *testing.T) {
+	assert.Panics(t, func() {
+		SlowRequests(0)
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/blockloop/boar"
+)
+
+// ErrTimeout is returned by the Timeout middleware when a request exceeds its deadline
+var ErrTimeout = boar.NewHTTPError(http.StatusServiceUnavailable, errors.New("request timed out"))
+
+// Timeout creates a middleware which cancels the request context after d. Requests which
+// exceed the deadline without writing a response return ErrTimeout so that the
+// ErrorHandler renders a 503. Unlike http.TimeoutHandler the handler is not abandoned,
+// so handlers must pass c.Ctx() to blocking calls for the deadline to take effect.
+//
+// Example:
+//
+//	rtr.Use(middleware.Timeout(5 * time.Second))
+func Timeout(d time.Duration) boar.Middleware {
+	return func(next boar.HandlerFunc) boar.HandlerFunc {
+		return func(c boar.Context) error {
+			cancel := c.WithTimeout(d)
+			defer cancel()
+
+			err := next(c)
+			if c.Ctx().Err() != context.DeadlineExceeded {
+				return err
+			}
+			if err == nil && c.Response().Len() > 0 {
+				return nil
+			}
+			if err == context.DeadlineExceeded || err == nil {
+				return ErrTimeout
+			}
+			return err
+		}
+	}
+}
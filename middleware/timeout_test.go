@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveTimeout(h boar.HandlerFunc) *httptest.ResponseRecorder {
+	r := boar.NewRouter()
+	r.Use(Timeout(10 * time.Millisecond))
+	r.MethodFunc(http.MethodGet, "/", h)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestTimeoutShouldReturnServiceUnavailable(t *testing.T) {
+	rec := serveTimeout(func(c boar.Context) error {
+		<-c.Ctx().Done()
+		return c.Ctx().Err()
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestTimeoutShouldReturnServiceUnavailableWhenNothingWasWritten(t *testing.T) {
+	rec := serveTimeout(func(c boar.Context) error {
+		<-c.Ctx().Done()
+		return nil
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "request timed out")
+}
+
+func TestTimeoutShouldKeepOtherErrors(t *testing.T) {
+	rec := serveTimeout(func(c boar.Context) error {
+		<-c.Ctx().Done()
+		return boar.NewHTTPError(http.StatusConflict, errors.New("conflict"))
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestTimeoutShouldNotAffectFastRequests(t *testing.T) {
+	rec := serveTimeout(func(c boar.Context) error {
+		_, ok := c.Ctx().Deadline()
+		assert.True(t, ok)
+		return c.WriteString(http.StatusOK, "fast")
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "fast", rec.Body.String())
+}
@@ -0,0 +1,28 @@
+// Package zstd registers a zstd encoder for middleware.Compress. It is a separate package
+// so that only applications which serve zstd compile the encoder. Import it for its side
+// effect:
+//
+//	import _ "github.com/blockloop/boar/middleware/zstd"
+package zstd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/blockloop/boar/middleware"
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	middleware.RegisterEncoder("zstd", newWriter)
+}
+
+func newWriter(w io.Writer, level int) (io.WriteCloser, error) {
+	if level == middleware.DefaultCompression {
+		return zstd.NewWriter(w)
+	}
+	if level < 1 || level > 22 {
+		return nil, fmt.Errorf("zstd: invalid compression level: %d", level)
+	}
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+}
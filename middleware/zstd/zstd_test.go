@@ -0,0 +1,60 @@
+package zstd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/blockloop/boar/middleware"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressShouldEncodeZstd(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+	r := boar.NewRouter()
+	r.Use(middleware.Compress())
+	r.MethodFunc(http.MethodGet, "/", func(c boar.Context) error {
+		return c.Write(http.StatusOK, "text/plain", []byte(body))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "zstd, gzip")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, "zstd", rec.Header().Get("Content-Encoding"))
+	zr, err := zstd.NewReader(rec.Body)
+	require.NoError(t, err)
+	defer zr.Close()
+	b, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+}
+
+func TestNewWriterShouldRejectInvalidLevels(t *testing.T) {
+	_, err := newWriter(ioutil.Discard, 23)
+	assert.Error(t, err)
+	_, err = newWriter(ioutil.Discard, 0)
+	assert.Error(t, err)
+
+	for _, level := range []int{middleware.DefaultCompression, 1, 19} {
+		w, err := newWriter(ioutil.Discard, level)
+		require.NoError(t, err)
+		assert.NoError(t, w.Close())
+	}
+}
+
+func TestCompressWithConfigShouldPanicOnLevelInvalidForZstd(t *testing.T) {
+	level := 0
+	assert.Panics(t, func() {
+		middleware.CompressWithConfig(middleware.CompressConfig{Level: &level, Encodings: []string{"gzip", "zstd"}})
+	})
+	assert.NotPanics(t, func() {
+		middleware.CompressWithConfig(middleware.CompressConfig{Level: &level, Encodings: []string{"gzip"}})
+	})
+}
@@ -5,11 +5,15 @@ package boar
 
 import (
 	context "context"
+	x509 "crypto/x509"
 	
 	httprouter "github.com/julienschmidt/httprouter"
 	gomock "github.com/golang/mock/gomock"
+	io "io"
+	multipart "mime/multipart"
 	http "net/http"
 	reflect "reflect"
+	time "time"
 )
 
 // MockHTTPError is a mock of HTTPError interface
@@ -107,6 +111,95 @@ func (m *MockContext) EXPECT() *MockContextMockRecorder {
 	return m.recorder
 }
 
+// Accepts mocks base method
+func (m *MockContext) Accepts(arg0 ...string) string {
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Accepts", varargs...)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Accepts indicates an expected call of Accepts
+func (mr *MockContextMockRecorder) Accepts(arg0 ...interface{}) *gomock.Call {
+	varargs := arg0
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accepts", reflect.TypeOf((*MockContext)(nil).Accepts), varargs...)
+}
+
+// Attachment mocks base method
+func (m *MockContext) Attachment(arg0 io.Reader, arg1 string) error {
+	ret := m.ctrl.Call(m, "Attachment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attachment indicates an expected call of Attachment
+func (mr *MockContextMockRecorder) Attachment(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attachment", reflect.TypeOf((*MockContext)(nil).Attachment), arg0, arg1)
+}
+
+// BaseURL mocks base method
+func (m *MockContext) BaseURL() string {
+	ret := m.ctrl.Call(m, "BaseURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURL indicates an expected call of BaseURL
+func (mr *MockContextMockRecorder) BaseURL() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURL", reflect.TypeOf((*MockContext)(nil).BaseURL))
+}
+
+// Bind mocks base method
+func (m *MockContext) Bind(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "Bind", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bind indicates an expected call of Bind
+func (mr *MockContextMockRecorder) Bind(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bind", reflect.TypeOf((*MockContext)(nil).Bind), arg0)
+}
+
+// ClientCertificate mocks base method
+func (m *MockContext) ClientCertificate() *x509.Certificate {
+	ret := m.ctrl.Call(m, "ClientCertificate")
+	ret0, _ := ret[0].(*x509.Certificate)
+	return ret0
+}
+
+// ClientCertificate indicates an expected call of ClientCertificate
+func (mr *MockContextMockRecorder) ClientCertificate() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientCertificate", reflect.TypeOf((*MockContext)(nil).ClientCertificate))
+}
+
+// ClientIP mocks base method
+func (m *MockContext) ClientIP() string {
+	ret := m.ctrl.Call(m, "ClientIP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientIP indicates an expected call of ClientIP
+func (mr *MockContextMockRecorder) ClientIP() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientIP", reflect.TypeOf((*MockContext)(nil).ClientIP))
+}
+
+// ContentType mocks base method
+func (m *MockContext) ContentType() string {
+	ret := m.ctrl.Call(m, "ContentType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ContentType indicates an expected call of ContentType
+func (mr *MockContextMockRecorder) ContentType() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentType", reflect.TypeOf((*MockContext)(nil).ContentType))
+}
+
 // Context mocks base method
 func (m *MockContext) Context() context.Context {
 	ret := m.ctrl.Call(m, "Context")
@@ -119,6 +212,250 @@ func (mr *MockContextMockRecorder) Context() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockContext)(nil).Context))
 }
 
+// Ctx mocks base method
+func (m *MockContext) Ctx() context.Context {
+	ret := m.ctrl.Call(m, "Ctx")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Ctx indicates an expected call of Ctx
+func (mr *MockContextMockRecorder) Ctx() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ctx", reflect.TypeOf((*MockContext)(nil).Ctx))
+}
+
+// Defer mocks base method
+func (m *MockContext) Defer(arg0 func(ctx context.Context) error) {
+	m.ctrl.Call(m, "Defer", arg0)
+}
+
+// Defer indicates an expected call of Defer
+func (mr *MockContextMockRecorder) Defer(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Defer", reflect.TypeOf((*MockContext)(nil).Defer), arg0)
+}
+
+// File mocks base method
+func (m *MockContext) File(arg0 string) error {
+	ret := m.ctrl.Call(m, "File", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// File indicates an expected call of File
+func (mr *MockContextMockRecorder) File(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "File", reflect.TypeOf((*MockContext)(nil).File), arg0)
+}
+
+// FormFile mocks base method
+func (m *MockContext) FormFile(arg0 string) (multipart.File, *multipart.FileHeader, error) {
+	ret := m.ctrl.Call(m, "FormFile", arg0)
+	ret0, _ := ret[0].(multipart.File)
+	ret1, _ := ret[1].(*multipart.FileHeader)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FormFile indicates an expected call of FormFile
+func (mr *MockContextMockRecorder) FormFile(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormFile", reflect.TypeOf((*MockContext)(nil).FormFile), arg0)
+}
+
+// FormValue mocks base method
+func (m *MockContext) FormValue(arg0 string) string {
+	ret := m.ctrl.Call(m, "FormValue", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// FormValue indicates an expected call of FormValue
+func (mr *MockContextMockRecorder) FormValue(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormValue", reflect.TypeOf((*MockContext)(nil).FormValue), arg0)
+}
+
+// FullURL mocks base method
+func (m *MockContext) FullURL() string {
+	ret := m.ctrl.Call(m, "FullURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// FullURL indicates an expected call of FullURL
+func (mr *MockContextMockRecorder) FullURL() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FullURL", reflect.TypeOf((*MockContext)(nil).FullURL))
+}
+
+// Get mocks base method
+func (m *MockContext) Get(arg0 string) (interface{}, bool) {
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockContextMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockContext)(nil).Get), arg0)
+}
+
+// Inline mocks base method
+func (m *MockContext) Inline(arg0 io.Reader, arg1 string) error {
+	ret := m.ctrl.Call(m, "Inline", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Inline indicates an expected call of Inline
+func (mr *MockContextMockRecorder) Inline(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inline", reflect.TypeOf((*MockContext)(nil).Inline), arg0, arg1)
+}
+
+// IsForm mocks base method
+func (m *MockContext) IsForm() bool {
+	ret := m.ctrl.Call(m, "IsForm")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsForm indicates an expected call of IsForm
+func (mr *MockContextMockRecorder) IsForm() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsForm", reflect.TypeOf((*MockContext)(nil).IsForm))
+}
+
+// IsJSON mocks base method
+func (m *MockContext) IsJSON() bool {
+	ret := m.ctrl.Call(m, "IsJSON")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsJSON indicates an expected call of IsJSON
+func (mr *MockContextMockRecorder) IsJSON() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsJSON", reflect.TypeOf((*MockContext)(nil).IsJSON))
+}
+
+// Logger mocks base method
+func (m *MockContext) Logger() Logger {
+	ret := m.ctrl.Call(m, "Logger")
+	ret0, _ := ret[0].(Logger)
+	return ret0
+}
+
+// Logger indicates an expected call of Logger
+func (mr *MockContextMockRecorder) Logger() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logger", reflect.TypeOf((*MockContext)(nil).Logger))
+}
+
+// Negotiate mocks base method
+func (m *MockContext) Negotiate(arg0 int, arg1 interface{}) error {
+	ret := m.ctrl.Call(m, "Negotiate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Negotiate indicates an expected call of Negotiate
+func (mr *MockContextMockRecorder) Negotiate(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Negotiate", reflect.TypeOf((*MockContext)(nil).Negotiate), arg0, arg1)
+}
+
+// NoContent mocks base method
+func (m *MockContext) NoContent() error {
+	ret := m.ctrl.Call(m, "NoContent")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NoContent indicates an expected call of NoContent
+func (mr *MockContextMockRecorder) NoContent() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoContent", reflect.TypeOf((*MockContext)(nil).NoContent))
+}
+
+// Param mocks base method
+func (m *MockContext) Param(arg0 string) string {
+	ret := m.ctrl.Call(m, "Param", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Param indicates an expected call of Param
+func (mr *MockContextMockRecorder) Param(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Param", reflect.TypeOf((*MockContext)(nil).Param), arg0)
+}
+
+// ParamBool mocks base method
+func (m *MockContext) ParamBool(arg0 string) (bool, error) {
+	ret := m.ctrl.Call(m, "ParamBool", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParamBool indicates an expected call of ParamBool
+func (mr *MockContextMockRecorder) ParamBool(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParamBool", reflect.TypeOf((*MockContext)(nil).ParamBool), arg0)
+}
+
+// ParamInt mocks base method
+func (m *MockContext) ParamInt(arg0 string) (int, error) {
+	ret := m.ctrl.Call(m, "ParamInt", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParamInt indicates an expected call of ParamInt
+func (mr *MockContextMockRecorder) ParamInt(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParamInt", reflect.TypeOf((*MockContext)(nil).ParamInt), arg0)
+}
+
+// ParamInt64 mocks base method
+func (m *MockContext) ParamInt64(arg0 string) (int64, error) {
+	ret := m.ctrl.Call(m, "ParamInt64", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParamInt64 indicates an expected call of ParamInt64
+func (mr *MockContextMockRecorder) ParamInt64(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParamInt64", reflect.TypeOf((*MockContext)(nil).ParamInt64), arg0)
+}
+
+// QueryBool mocks base method
+func (m *MockContext) QueryBool(arg0 string, arg1 bool) bool {
+	ret := m.ctrl.Call(m, "QueryBool", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// QueryBool indicates an expected call of QueryBool
+func (mr *MockContextMockRecorder) QueryBool(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBool", reflect.TypeOf((*MockContext)(nil).QueryBool), arg0, arg1)
+}
+
+// QueryInt mocks base method
+func (m *MockContext) QueryInt(arg0 string, arg1 int) int {
+	ret := m.ctrl.Call(m, "QueryInt", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// QueryInt indicates an expected call of QueryInt
+func (mr *MockContextMockRecorder) QueryInt(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryInt", reflect.TypeOf((*MockContext)(nil).QueryInt), arg0, arg1)
+}
+
+// QueryString mocks base method
+func (m *MockContext) QueryString(arg0 string, arg1 string) string {
+	ret := m.ctrl.Call(m, "QueryString", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// QueryString indicates an expected call of QueryString
+func (mr *MockContextMockRecorder) QueryString(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryString", reflect.TypeOf((*MockContext)(nil).QueryString), arg0, arg1)
+}
+
 // ReadForm mocks base method
 func (m *MockContext) ReadForm(arg0 interface{}) error {
 	ret := m.ctrl.Call(m, "ReadForm", arg0)
@@ -167,6 +504,30 @@ func (mr *MockContextMockRecorder) ReadURLParams(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadURLParams", reflect.TypeOf((*MockContext)(nil).ReadURLParams), arg0)
 }
 
+// Redirect mocks base method
+func (m *MockContext) Redirect(arg0 int, arg1 string) error {
+	ret := m.ctrl.Call(m, "Redirect", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redirect indicates an expected call of Redirect
+func (mr *MockContextMockRecorder) Redirect(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockContext)(nil).Redirect), arg0, arg1)
+}
+
+// Render mocks base method
+func (m *MockContext) Render(arg0 int, arg1 string, arg2 interface{}) error {
+	ret := m.ctrl.Call(m, "Render", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Render indicates an expected call of Render
+func (mr *MockContextMockRecorder) Render(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockContext)(nil).Render), arg0, arg1, arg2)
+}
+
 // Request mocks base method
 func (m *MockContext) Request() *http.Request {
 	ret := m.ctrl.Call(m, "Request")
@@ -191,6 +552,77 @@ func (mr *MockContextMockRecorder) Response() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Response", reflect.TypeOf((*MockContext)(nil).Response))
 }
 
+// RoutePattern mocks base method
+func (m *MockContext) RoutePattern() string {
+	ret := m.ctrl.Call(m, "RoutePattern")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RoutePattern indicates an expected call of RoutePattern
+func (mr *MockContextMockRecorder) RoutePattern() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoutePattern", reflect.TypeOf((*MockContext)(nil).RoutePattern))
+}
+
+// SSE mocks base method
+func (m *MockContext) SSE() (*EventStream, error) {
+	ret := m.ctrl.Call(m, "SSE")
+	ret0, _ := ret[0].(*EventStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSE indicates an expected call of SSE
+func (mr *MockContextMockRecorder) SSE() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSE", reflect.TypeOf((*MockContext)(nil).SSE))
+}
+
+// SaveUploadedFile mocks base method
+func (m *MockContext) SaveUploadedFile(arg0 *multipart.FileHeader, arg1 string) error {
+	ret := m.ctrl.Call(m, "SaveUploadedFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUploadedFile indicates an expected call of SaveUploadedFile
+func (mr *MockContextMockRecorder) SaveUploadedFile(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUploadedFile", reflect.TypeOf((*MockContext)(nil).SaveUploadedFile), arg0, arg1)
+}
+
+// Set mocks base method
+func (m *MockContext) Set(arg0 string, arg1 interface{}) {
+	m.ctrl.Call(m, "Set", arg0, arg1)
+}
+
+// Set indicates an expected call of Set
+func (mr *MockContextMockRecorder) Set(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockContext)(nil).Set), arg0, arg1)
+}
+
+// Status mocks base method
+func (m *MockContext) Status(arg0 int) error {
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockContextMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockContext)(nil).Status), arg0)
+}
+
+// Stream mocks base method
+func (m *MockContext) Stream(arg0 int, arg1 string, arg2 io.Reader) error {
+	ret := m.ctrl.Call(m, "Stream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream
+func (mr *MockContextMockRecorder) Stream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockContext)(nil).Stream), arg0, arg1, arg2)
+}
+
 // URLParams mocks base method
 func (m *MockContext) URLParams() httprouter.Params {
 	ret := m.ctrl.Call(m, "URLParams")
@@ -203,6 +635,67 @@ func (mr *MockContextMockRecorder) URLParams() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLParams", reflect.TypeOf((*MockContext)(nil).URLParams))
 }
 
+// Unbuffer mocks base method
+func (m *MockContext) Unbuffer() error {
+	ret := m.ctrl.Call(m, "Unbuffer")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unbuffer indicates an expected call of Unbuffer
+func (mr *MockContextMockRecorder) Unbuffer() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unbuffer", reflect.TypeOf((*MockContext)(nil).Unbuffer))
+}
+
+// Upgrade mocks base method
+func (m *MockContext) Upgrade() (WebSocket, error) {
+	ret := m.ctrl.Call(m, "Upgrade")
+	ret0, _ := ret[0].(WebSocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upgrade indicates an expected call of Upgrade
+func (mr *MockContextMockRecorder) Upgrade() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockContext)(nil).Upgrade))
+}
+
+// WithCancel mocks base method
+func (m *MockContext) WithCancel() context.CancelFunc {
+	ret := m.ctrl.Call(m, "WithCancel")
+	ret0, _ := ret[0].(context.CancelFunc)
+	return ret0
+}
+
+// WithCancel indicates an expected call of WithCancel
+func (mr *MockContextMockRecorder) WithCancel() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithCancel", reflect.TypeOf((*MockContext)(nil).WithCancel))
+}
+
+// WithTimeout mocks base method
+func (m *MockContext) WithTimeout(arg0 time.Duration) context.CancelFunc {
+	ret := m.ctrl.Call(m, "WithTimeout", arg0)
+	ret0, _ := ret[0].(context.CancelFunc)
+	return ret0
+}
+
+// WithTimeout indicates an expected call of WithTimeout
+func (mr *MockContextMockRecorder) WithTimeout(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTimeout", reflect.TypeOf((*MockContext)(nil).WithTimeout), arg0)
+}
+
+// Write mocks base method
+func (m *MockContext) Write(arg0 int, arg1 string, arg2 []byte) error {
+	ret := m.ctrl.Call(m, "Write", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write
+func (mr *MockContextMockRecorder) Write(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockContext)(nil).Write), arg0, arg1, arg2)
+}
+
 // WriteJSON mocks base method
 func (m *MockContext) WriteJSON(arg0 int, arg1 interface{}) error {
 	ret := m.ctrl.Call(m, "WriteJSON", arg0, arg1)
@@ -215,6 +708,54 @@ func (mr *MockContextMockRecorder) WriteJSON(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteJSON", reflect.TypeOf((*MockContext)(nil).WriteJSON), arg0, arg1)
 }
 
+// WriteJSONP mocks base method
+func (m *MockContext) WriteJSONP(arg0 int, arg1 string, arg2 interface{}) error {
+	ret := m.ctrl.Call(m, "WriteJSONP", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteJSONP indicates an expected call of WriteJSONP
+func (mr *MockContextMockRecorder) WriteJSONP(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteJSONP", reflect.TypeOf((*MockContext)(nil).WriteJSONP), arg0, arg1, arg2)
+}
+
+// WriteJSONStream mocks base method
+func (m *MockContext) WriteJSONStream(arg0 int, arg1 interface{}) error {
+	ret := m.ctrl.Call(m, "WriteJSONStream", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteJSONStream indicates an expected call of WriteJSONStream
+func (mr *MockContextMockRecorder) WriteJSONStream(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteJSONStream", reflect.TypeOf((*MockContext)(nil).WriteJSONStream), arg0, arg1)
+}
+
+// WriteProblem mocks base method
+func (m *MockContext) WriteProblem(arg0 int, arg1 string, arg2 string) error {
+	ret := m.ctrl.Call(m, "WriteProblem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteProblem indicates an expected call of WriteProblem
+func (mr *MockContextMockRecorder) WriteProblem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteProblem", reflect.TypeOf((*MockContext)(nil).WriteProblem), arg0, arg1, arg2)
+}
+
+// WriteProblemDetails mocks base method
+func (m *MockContext) WriteProblemDetails(arg0 *Problem) error {
+	ret := m.ctrl.Call(m, "WriteProblemDetails", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteProblemDetails indicates an expected call of WriteProblemDetails
+func (mr *MockContextMockRecorder) WriteProblemDetails(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteProblemDetails", reflect.TypeOf((*MockContext)(nil).WriteProblemDetails), arg0)
+}
+
 // WriteStatus mocks base method
 func (m *MockContext) WriteStatus(arg0 int) error {
 	ret := m.ctrl.Call(m, "WriteStatus", arg0)
@@ -227,6 +768,30 @@ func (mr *MockContextMockRecorder) WriteStatus(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteStatus", reflect.TypeOf((*MockContext)(nil).WriteStatus), arg0)
 }
 
+// WriteString mocks base method
+func (m *MockContext) WriteString(arg0 int, arg1 string) error {
+	ret := m.ctrl.Call(m, "WriteString", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteString indicates an expected call of WriteString
+func (mr *MockContextMockRecorder) WriteString(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteString", reflect.TypeOf((*MockContext)(nil).WriteString), arg0, arg1)
+}
+
+// WriteXML mocks base method
+func (m *MockContext) WriteXML(arg0 int, arg1 interface{}) error {
+	ret := m.ctrl.Call(m, "WriteXML", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteXML indicates an expected call of WriteXML
+func (mr *MockContextMockRecorder) WriteXML(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteXML", reflect.TypeOf((*MockContext)(nil).WriteXML), arg0, arg1)
+}
+
 // MockResponseWriter is a mock of ResponseWriter interface
 type MockResponseWriter struct {
 	ctrl     *gomock.Controller
@@ -250,6 +815,18 @@ func (m *MockResponseWriter) EXPECT() *MockResponseWriterMockRecorder {
 	return m.recorder
 }
 
+// BytesWritten mocks base method
+func (m *MockResponseWriter) BytesWritten() int {
+	ret := m.ctrl.Call(m, "BytesWritten")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// BytesWritten indicates an expected call of BytesWritten
+func (mr *MockResponseWriterMockRecorder) BytesWritten() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesWritten", reflect.TypeOf((*MockResponseWriter)(nil).BytesWritten))
+}
+
 // Close mocks base method
 func (m *MockResponseWriter) Close() error {
 	ret := m.ctrl.Call(m, "Close")
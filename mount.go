@@ -0,0 +1,62 @@
+package boar
+
+import (
+	"context"
+	"net/http"
+)
+
+// mountMethods are the HTTP methods routed to the handlers added with Mount
+var mountMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+// Mount serves every request under prefix with h after the middlewares of rtr and mw. It
+// is the integration point for handlers which do their own routing, such as the ServeMux
+// of grpc-gateway, so that middlewares like authentication and logging are shared by
+// native and transcoded endpoints. Paths are passed to h unchanged and no other routes
+// of rtr can be added under prefix. The Context of each request is available to h with
+// ContextOf. The routes are not documented by OpenAPI.
+//
+// Example:
+//
+//	gw := runtime.NewServeMux(runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+//		c, _ := boar.ContextOf(r)
+//		claims, _ := c.Get("jwt")
+//		return metadata.Pairs("user", claims.(middleware.Claims).Subject())
+//	}))
+//	pb.RegisterUserServiceHandlerFromEndpoint(ctx, gw, "localhost:9090", opts)
+//
+//	rtr.Use(middleware.Logger)
+//	rtr.Mount("/v1", gw, middleware.JWT(secret))
+func (rtr *Router) Mount(prefix string, h http.Handler, mw ...Middleware) {
+	if h == nil {
+		panic("cannot mount a nil http.Handler")
+	}
+	mounted := rtr.Group(prefix, mw...)
+	serve := serveMounted(h)
+	for _, method := range mountMethods {
+		mounted.MethodFunc(method, "/*path", serve).hidden = true
+	}
+}
+
+// serveMounted creates a HandlerFunc which serves requests with h. The Context is stored
+// in the context of the request for ContextOf
+func serveMounted(h http.Handler) HandlerFunc {
+	return func(c Context) error {
+		r := c.Request()
+		r = r.WithContext(context.WithValue(r.Context(), mountedContextKey{}, c))
+		h.ServeHTTP(c.Response(), r)
+		return nil
+	}
+}
+
+type mountedContextKey struct{}
+
+// ContextOf returns the Context of a request served by a handler added with Mount. It
+// allows the handler, or hooks of the handler such as the metadata annotators of
+// grpc-gateway, to read the values stored by middlewares with Context.Set
+func ContextOf(r *http.Request) (Context, bool) {
+	c, ok := r.Context().Value(mountedContextKey{}).(Context)
+	return c, ok
+}
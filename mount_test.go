@@ -0,0 +1,103 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatewayMux stands in for a grpc-gateway ServeMux which routes requests itself
+func gatewayMux() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/users", func(w http.ResponseWriter, r *http.Request) {
+		user := ""
+		if c, ok := ContextOf(r); ok {
+			v, _ := c.Get("user")
+			user, _ = v.(string)
+		}
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"method":"` + r.Method + `","user":"` + user + `"}`))
+	})
+	return mux
+}
+
+func TestMountServesRequestsWithHandler(t *testing.T) {
+	r := NewRouter()
+	r.Mount("/v1", gatewayMux())
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(method, "/v1/users", nil))
+		assert.Equal(t, http.StatusOK, rec.Code, method)
+		assert.JSONEq(t, `{"method":"`+method+`","user":""}`, rec.Body.String(), method)
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMountSharesMiddlewares(t *testing.T) {
+	r := NewRouter()
+	var logged []string
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			logged = append(logged, c.Request().URL.Path)
+			return next(c)
+		}
+	})
+	auth := func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			if c.Request().Header.Get("Authorization") == "" {
+				return ErrUnauthorized
+			}
+			c.Set("user", "brett")
+			return next(c)
+		}
+	}
+	r.Mount("/v1", gatewayMux(), auth)
+	r.MethodFunc(http.MethodGet, "/native", func(c Context) error {
+		return c.NoContent()
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"method":"GET","user":"brett"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/native", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	assert.Equal(t, []string{"/v1/users", "/v1/users", "/native"}, logged)
+}
+
+func TestMountRoutesAreHidden(t *testing.T) {
+	r := NewRouter()
+	r.Mount("/v1", gatewayMux())
+
+	require.Len(t, r.routes, len(mountMethods))
+	for _, route := range r.routes {
+		assert.Equal(t, "/v1/*path", route.info.Path)
+		assert.True(t, route.hidden, route.info.Method)
+	}
+}
+
+func TestMountPanicsForNilHandler(t *testing.T) {
+	assert.Panics(t, func() { NewRouter().Mount("/v1", nil) })
+}
+
+func TestContextOfReturnsFalseOutsideMount(t *testing.T) {
+	c, ok := ContextOf(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.False(t, ok)
+	assert.Nil(t, c)
+}
@@ -0,0 +1,120 @@
+package boar
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var _ HTTPError = (*MultiError)(nil)
+
+// MultiError is an HTTPError which aggregates several errors into a single response. The
+// request parser returns a MultiError when more than one of the Query, Header, and Body
+// fields of a handler fail validation so that clients see every problem at once.
+//
+// Example:
+//
+//	var errs []error
+//	if err := c.ReadQuery(&query); err != nil {
+//		errs = append(errs, err)
+//	}
+//	if err := c.ReadJSON(&body); err != nil {
+//		errs = append(errs, err)
+//	}
+//	if err := boar.NewMultiError(errs...); err != nil {
+//		return err
+//	}
+type MultiError struct {
+	Errors []error
+}
+
+// NewMultiError aggregates the non-nil errs into a MultiError. nil is returned when there
+// are no errors and the error itself is returned when there is only one
+func NewMultiError(errs ...error) error {
+	var m MultiError
+	for _, err := range errs {
+		if err != nil {
+			m.Errors = append(m.Errors, err)
+		}
+	}
+	switch len(m.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return m.Errors[0]
+	}
+	return &m
+}
+
+// Status returns the highest status of the aggregated errors. Errors which are not
+// HTTPErrors are considered to be http.StatusInternalServerError
+func (m *MultiError) Status() int {
+	status := http.StatusBadRequest
+	for _, err := range m.Errors {
+		s := http.StatusInternalServerError
+		if httperr, ok := err.(HTTPError); ok {
+			s = httperr.Status()
+		}
+		if s > status {
+			status = s
+		}
+	}
+	return status
+}
+
+// Cause returns an error with the messages of every aggregated error
+func (m *MultiError) Cause() error {
+	return errors.New(m.Error())
+}
+
+func (m *MultiError) Error() string {
+	s := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Unwrap returns the aggregated errors for errors.Is and errors.As
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Is reports whether target is an error created by NewHTTPErrorStatus with the same status
+func (m *MultiError) Is(target error) bool {
+	return statusIs(m.Status(), target)
+}
+
+// MarshalJSON merges the messages of the aggregated errors into a single errors object.
+// Messages of ValidationErrors are keyed by the area which failed validation, such as
+// query or body, and the messages of other errors are listed under error. When
+// MarshalValidationError is set, errors is instead a list with its value for each
+// ValidationError and {"error": message} for other errors
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	if MarshalValidationError != nil {
+		errs := make([]interface{}, len(m.Errors))
+		for i, err := range m.Errors {
+			if verr, ok := err.(*ValidationError); ok {
+				errs[i] = MarshalValidationError(verr)
+			} else {
+				errs[i] = JSON{"error": err.Error()}
+			}
+		}
+		return json.Marshal(JSON{"errors": errs})
+	}
+
+	errs := JSON{}
+	for _, err := range m.Errors {
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			msgs, _ := errs["error"].([]string)
+			errs["error"] = append(msgs, err.Error())
+			continue
+		}
+		key := strings.ToLower(verr.fieldName)
+		existing, _ := errs[key].([]string)
+		errs[key] = append(existing, verr.messages()...)
+	}
+	return json.Marshal(JSON{"errors": errs})
+}
@@ -0,0 +1,109 @@
+package boar
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiError(t *testing.T) {
+	assert.Nil(t, NewMultiError())
+	assert.Nil(t, NewMultiError(nil, nil))
+	assert.Equal(t, io.EOF, NewMultiError(nil, io.EOF))
+
+	err := NewMultiError(io.EOF, nil, io.ErrUnexpectedEOF)
+	require.IsType(t, &MultiError{}, err)
+	assert.Len(t, err.(*MultiError).Errors, 2)
+	assert.Equal(t, "EOF; unexpected EOF", err.Error())
+}
+
+func TestMultiErrorStatusShouldBeHighestStatus(t *testing.T) {
+	validation := NewValidationError(queryField, io.EOF)
+	assert.Equal(t, http.StatusBadRequest, (&MultiError{Errors: []error{validation, validation}}).Status())
+	assert.Equal(t, http.StatusNotFound, (&MultiError{Errors: []error{validation, ErrNotFound}}).Status())
+	assert.Equal(t, http.StatusInternalServerError, (&MultiError{Errors: []error{validation, io.EOF}}).Status())
+}
+
+func TestMultiErrorShouldSupportErrorsIs(t *testing.T) {
+	err := NewMultiError(NewValidationError(queryField, io.EOF), NewValidationError(bodyField, io.ErrUnexpectedEOF))
+	assert.True(t, errors.Is(err, io.EOF))
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	assert.True(t, errors.Is(err, NewHTTPErrorStatus(http.StatusBadRequest)))
+
+	var verr *ValidationError
+	assert.True(t, errors.As(err, &verr))
+}
+
+func TestMultiErrorShouldMergeMessages(t *testing.T) {
+	err := NewMultiError(
+		NewValidationError(queryField, errors.New("page must be a number")),
+		NewValidationErrors(bodyField, []error{errors.New("name is required")}),
+		NewValidationError(bodyField, errors.New("age is required")),
+		errors.New("something else"),
+	)
+	b, merr := json.Marshal(err)
+	require.NoError(t, merr)
+	assert.JSONEq(t, `{"errors":{
+		"query":["page must be a number"],
+		"body":["name is required","age is required"],
+		"error":["something else"]
+	}}`, string(b))
+}
+
+func TestMultiErrorShouldUseMarshalValidationError(t *testing.T) {
+	defer func() { MarshalValidationError = nil }()
+	MarshalValidationError = func(e *ValidationError) interface{} {
+		return JSON{"code": "INVALID_" + strings.ToUpper(e.FieldName())}
+	}
+
+	err := NewMultiError(
+		NewValidationError(queryField, errors.New("page must be a number")),
+		NewValidationError(bodyField, errors.New("name is required")),
+		errors.New("something else"),
+	)
+	b, merr := json.Marshal(err)
+	require.NoError(t, merr)
+	assert.JSONEq(t, `{"errors":[
+		{"code":"INVALID_QUERY"},
+		{"code":"INVALID_BODY"},
+		{"error":"something else"}
+	]}`, string(b))
+}
+
+type multiErrorHandler struct {
+	Query struct {
+		Page int `validate:"required"`
+	}
+	Body struct {
+		Name string `validate:"required"`
+	}
+}
+
+func (*multiErrorHandler) Handle(Context) error { return nil }
+
+func TestRequestParserShouldReturnEveryValidationError(t *testing.T) {
+	r := NewRouter()
+	r.Post("/", func(Context) (Handler, error) {
+		return &multiErrorHandler{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", contentTypeJSON)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body struct {
+		Errors map[string][]string
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Errors["query"], 1)
+	assert.Len(t, body.Errors["body"], 1)
+}
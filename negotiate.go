@@ -0,0 +1,199 @@
+package boar
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NegotiateFunc writes v to the response of c in the media type it was registered for
+type NegotiateFunc func(c Context, status int, v interface{}) error
+
+// Templated can be implemented by values passed to Context.Negotiate so they can be
+// rendered as html with the Router's Renderer
+type Templated interface {
+	TemplateName() string
+}
+
+type negotiator struct {
+	mediaType string
+	write     NegotiateFunc
+	// supports reports whether v can be written with this media type. nil supports all values
+	supports func(c Context, v interface{}) bool
+}
+
+var (
+	negotiatorsMu sync.RWMutex
+	negotiators   = []negotiator{
+		{mediaType: contentTypeJSON, write: func(c Context, status int, v interface{}) error {
+			return c.WriteJSON(status, v)
+		}},
+		{mediaType: contentTypeXML, write: func(c Context, status int, v interface{}) error {
+			return c.WriteXML(status, v)
+		}},
+		{mediaType: "text/xml", write: func(c Context, status int, v interface{}) error {
+			return c.WriteXML(status, v)
+		}},
+		{mediaType: "text/html", write: negotiateHTML, supports: func(c Context, v interface{}) bool {
+			rtr := routerOf(c)
+			_, templated := v.(Templated)
+			return templated && rtr != nil && rtr.Renderer != nil
+		}},
+	}
+)
+
+// RegisterNegotiator adds, or replaces, the NegotiateFunc used by Context.Negotiate when
+// the client accepts mediaType. Media types are preferred in the order they were
+// registered when the client accepts several equally. RegisterNegotiator should be called
+// before the Router is served.
+//
+// Example:
+//
+//	boar.RegisterNegotiator("application/msgpack", func(c boar.Context, status int, v interface{}) error {
+//		b, err := msgpack.Marshal(v)
+//		if err != nil {
+//			return err
+//		}
+//		return c.Write(status, "application/msgpack", b)
+//	})
+func RegisterNegotiator(mediaType string, fn NegotiateFunc) {
+	negotiatorsMu.Lock()
+	defer negotiatorsMu.Unlock()
+	mediaType = strings.ToLower(mediaType)
+	for i, n := range negotiators {
+		if n.mediaType == mediaType {
+			negotiators[i] = negotiator{mediaType: mediaType, write: fn}
+			return
+		}
+	}
+	negotiators = append(negotiators, negotiator{mediaType: mediaType, write: fn})
+}
+
+func negotiateHTML(c Context, status int, v interface{}) error {
+	return c.Render(status, v.(Templated).TemplateName(), v)
+}
+
+func (r *requestContext) Negotiate(status int, v interface{}) error {
+	negotiatorsMu.RLock()
+	offers := make([]negotiator, 0, len(negotiators))
+	for _, n := range negotiators {
+		if n.supports == nil || n.supports(r, v) {
+			offers = append(offers, n)
+		}
+	}
+	negotiatorsMu.RUnlock()
+
+	accept := parseAccept(r.request.Header.Get("accept"))
+	best, bestQ := -1, 0.0
+	for i, offer := range offers {
+		if q := accept.quality(offer.mediaType); q > bestQ {
+			best, bestQ = i, q
+		}
+	}
+	if best < 0 {
+		return ErrNotAcceptable
+	}
+	return offers[best].write(r, status, v)
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+type acceptRanges []acceptRange
+
+// parseAccept parses an Accept header into its media ranges. An empty header accepts
+// everything
+func parseAccept(header string) acceptRanges {
+	if strings.TrimSpace(header) == "" {
+		return acceptRanges{{mediaType: "*/*", q: 1}}
+	}
+	var ranges acceptRanges
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		mt := strings.ToLower(strings.TrimSpace(params[0]))
+		if mt == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && strings.ToLower(kv[0]) == "q" {
+				if f, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mt, q: q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+	return ranges
+}
+
+// quality returns the q value of the most specific range matching mediaType or zero
+// when mediaType is not acceptable
+func (a acceptRanges) quality(mediaType string) float64 {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	typ := strings.SplitN(mediaType, "/", 2)[0]
+	q, specificity := 0.0, -1
+	for _, r := range a {
+		var s int
+		switch {
+		case r.mediaType == mediaType:
+			s = 2
+		case r.mediaType == typ+"/*":
+			s = 1
+		case r.mediaType == "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+func (r *requestContext) Accepts(types ...string) string {
+	accept := parseAccept(r.request.Header.Get("accept"))
+	best, bestQ := "", 0.0
+	for _, typ := range types {
+		if q := accept.quality(typ); q > bestQ {
+			best, bestQ = typ, q
+		}
+	}
+	return best
+}
+
+func (r *requestContext) ContentType() string {
+	return mediaType(r.request.Header.Get("content-type"))
+}
+
+func (r *requestContext) IsJSON() bool {
+	ct := r.ContentType()
+	return ct == contentTypeJSON || strings.HasSuffix(ct, "+json")
+}
+
+func (r *requestContext) IsForm() bool {
+	ct := r.ContentType()
+	return ct == contentTypeFormEncoded || ct == contentTypeMultipartForm
+}
+
+// mediaType returns the lowercase media type of a Content-Type header without its
+// parameters or an empty string when the header is empty or malformed
+func mediaType(header string) string {
+	if header == "" {
+		return ""
+	}
+	mt, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+	return mt
+}
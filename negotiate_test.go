@@ -0,0 +1,137 @@
+package boar
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type negotiateItem struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func (negotiateItem) TemplateName() string { return "item.html" }
+
+type renderFunc func(w io.Writer, name string, data interface{}) error
+
+func (fn renderFunc) Render(w io.Writer, name string, data interface{}) error {
+	return fn(w, name, data)
+}
+
+func negotiate(t *testing.T, accept string, v interface{}, renderer Renderer) (*httptest.ResponseRecorder, error) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("accept", accept)
+	c := newContext(req, w, nil)
+	c.router = NewRouter()
+	c.router.Renderer = renderer
+	err := c.Negotiate(http.StatusOK, v)
+	if err == nil {
+		require.NoError(t, c.Response().Flush())
+	}
+	return w, err
+}
+
+func TestNegotiateShouldDefaultToJSON(t *testing.T) {
+	w, err := negotiate(t, "", negotiateItem{Name: "boar"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeJSON, w.Header().Get("content-type"))
+	assert.JSONEq(t, `{"name":"boar"}`, w.Body.String())
+}
+
+func TestNegotiateShouldPreferHighestQuality(t *testing.T) {
+	w, err := negotiate(t, "application/json;q=0.5, application/xml", negotiateItem{Name: "boar"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeXML, w.Header().Get("content-type"))
+	assert.Contains(t, w.Body.String(), "<name>boar</name>")
+}
+
+func TestNegotiateShouldMatchWildcardSubtypes(t *testing.T) {
+	w, err := negotiate(t, "text/*", negotiateItem{Name: "boar"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeXML, w.Header().Get("content-type"))
+}
+
+func TestNegotiateShouldRenderTemplatedValuesAsHTML(t *testing.T) {
+	renderer := renderFunc(func(w io.Writer, name string, data interface{}) error {
+		_, err := io.WriteString(w, "<p>"+name+"</p>")
+		return err
+	})
+	w, err := negotiate(t, "text/html,application/json;q=0.9", negotiateItem{Name: "boar"}, renderer)
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeHTML, w.Header().Get("content-type"))
+	assert.Equal(t, "<p>item.html</p>", w.Body.String())
+}
+
+func TestNegotiateShouldNotOfferHTMLWithoutRenderer(t *testing.T) {
+	_, err := negotiate(t, "text/html", negotiateItem{Name: "boar"}, nil)
+	assert.Equal(t, ErrNotAcceptable, err)
+}
+
+func TestNegotiateShouldReturnNotAcceptable(t *testing.T) {
+	_, err := negotiate(t, "image/png, application/json;q=0", negotiateItem{}, nil)
+	assert.Equal(t, ErrNotAcceptable, err)
+}
+
+func TestNegotiateShouldUseRegisteredNegotiators(t *testing.T) {
+	RegisterNegotiator("application/x-test", func(c Context, status int, v interface{}) error {
+		return c.Write(status, "application/x-test", []byte("test"))
+	})
+	defer func() {
+		negotiatorsMu.Lock()
+		negotiators = negotiators[:len(negotiators)-1]
+		negotiatorsMu.Unlock()
+	}()
+
+	w, err := negotiate(t, "application/x-test", negotiateItem{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test", w.Body.String())
+}
+
+func TestParseAcceptShouldSortByQuality(t *testing.T) {
+	ranges := parseAccept("text/plain;q=0.2, application/json, */*;q=0.1")
+	assert.Equal(t, acceptRanges{
+		{mediaType: "application/json", q: 1},
+		{mediaType: "text/plain", q: 0.2},
+		{mediaType: "*/*", q: 0.1},
+	}, ranges)
+}
+
+func TestAcceptsShouldReturnThePreferredType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("accept", "text/html;q=0.8, application/json")
+	c := NewContext(req, httptest.NewRecorder(), nil)
+
+	assert.Equal(t, "application/json", c.Accepts("text/html", "application/json"))
+	assert.Equal(t, "text/html", c.Accepts("text/html", "image/png"))
+	assert.Equal(t, "", c.Accepts("image/png"))
+}
+
+func TestContentTypeHelpersShouldParseMediaTypes(t *testing.T) {
+	cases := []struct {
+		header string
+		ct     string
+		json   bool
+		form   bool
+	}{
+		{header: "Application/JSON; charset=utf-8", ct: "application/json", json: true},
+		{header: "application/merge-patch+json", ct: "application/merge-patch+json", json: true},
+		{header: "application/x-www-form-urlencoded", ct: contentTypeFormEncoded, form: true},
+		{header: "multipart/form-data; boundary=abc", ct: contentTypeMultipartForm, form: true},
+		{header: "application/jsonp", ct: "application/jsonp"},
+		{header: "", ct: ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("content-type", tc.header)
+		c := NewContext(req, httptest.NewRecorder(), nil)
+
+		assert.Equal(t, tc.ct, c.ContentType(), tc.header)
+		assert.Equal(t, tc.json, c.IsJSON(), tc.header)
+		assert.Equal(t, tc.form, c.IsForm(), tc.header)
+	}
+}
@@ -0,0 +1,155 @@
+package boar
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// OpenAPIVersion is the version of the documents created by Router.OpenAPI
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIInfo is the info object of an OpenAPI document
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPI creates an OpenAPI document of the routes of rtr. Operations are documented
+// with the annotations of their Route. Parameters and request bodies are documented from
+// the Query, URLParams, Header, and Body fields of the Route's Request, and responses
+// from its Response. See ServeDocs
+func (rtr *Router) OpenAPI(info OpenAPIInfo) JSON {
+	paths := JSON{}
+	for _, route := range rtr.root().routes {
+		if route.hidden {
+			continue
+		}
+		path, params := openAPIPath(route.info.Path)
+		item, ok := paths[path].(JSON)
+		if !ok {
+			item = JSON{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.info.Method)] = openAPIOperation(route.info, params)
+	}
+	return JSON{
+		"openapi": OpenAPIVersion,
+		"info":    info,
+		"paths":   paths,
+	}
+}
+
+// openAPIPath converts an httprouter path such as /users/:id to /users/{id} and returns
+// the names of its parameters
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, seg := range segments {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			params = append(params, seg[1:])
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func openAPIOperation(info RouteInfo, pathParams []string) JSON {
+	op := JSON{}
+	if info.Summary != "" {
+		op["summary"] = info.Summary
+	}
+	if info.Description != "" {
+		op["description"] = info.Description
+	}
+	if len(info.Tags) > 0 {
+		op["tags"] = info.Tags
+	}
+	if info.Deprecated {
+		op["deprecated"] = true
+	}
+
+	req := structType(info.Request)
+	var params []JSON
+	urlParams := openAPIParams(req, urlParamsField, "url", "path")
+	for _, name := range pathParams {
+		param := JSON{"name": name, "in": "path", "required": true, "schema": &Schema{Type: "string"}}
+		for _, p := range urlParams {
+			if p["name"] == name {
+				param = p
+				param["required"] = true
+			}
+		}
+		params = append(params, param)
+	}
+	params = append(params, openAPIParams(req, queryField, "query", "query")...)
+	params = append(params, openAPIParams(req, headerField, "header", "header")...)
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if req != nil {
+		if sf, ok := req.FieldByName(bodyField); ok && !hasTagOption(sf, tagNoBind) {
+			op["requestBody"] = JSON{
+				"required": true,
+				"content": JSON{
+					contentTypeJSON: JSON{"schema": schemaOf(sf.Type, map[reflect.Type]bool{})},
+				},
+			}
+		}
+	}
+
+	resp := JSON{"description": http.StatusText(http.StatusOK)}
+	if info.Response != nil {
+		resp["content"] = JSON{
+			contentTypeJSON: JSON{"schema": schemaOf(reflect.TypeOf(info.Response), map[reflect.Type]bool{})},
+		}
+	}
+	op["responses"] = JSON{"200": resp}
+	return op
+}
+
+// openAPIParams documents the fields of the field of req as parameters in location. The
+// fields are named by their tagKey tag or their name as they are by the binder
+func openAPIParams(req reflect.Type, field, tagKey, location string) []JSON {
+	if req == nil {
+		return nil
+	}
+	sf, ok := req.FieldByName(field)
+	if !ok || sf.Type.Kind() != reflect.Struct || hasTagOption(sf, tagNoBind) {
+		return nil
+	}
+	var params []JSON
+	for i := 0; i < sf.Type.NumField(); i++ {
+		f := sf.Type.Field(i)
+		name := f.Name
+		if tag, ok := f.Tag.Lookup(tagKey); ok {
+			name = tag
+		}
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+		schema := schemaOf(f.Type, map[reflect.Type]bool{})
+		required := applyValidateTags(schema, f.Tag.Get("validate"))
+		param := JSON{"name": name, "in": location, "schema": schema}
+		if required {
+			param["required"] = true
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// structType returns the struct type of v or nil when v is not a struct or a pointer to
+// one
+func structType(v interface{}) reflect.Type {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return typ
+}
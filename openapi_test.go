@@ -0,0 +1,90 @@
+package boar
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIHandler struct {
+	URLParams struct {
+		ID int `url:"id"`
+	}
+	Query struct {
+		Fields string `query:"fields" validate:"required"`
+	}
+	Header struct {
+		RequestID string `header:"X-Request-Id"`
+	}
+	Body struct {
+		Name string `json:"name" validate:"required"`
+	}
+}
+
+func (*openAPIHandler) Handle(Context) error { return nil }
+
+func TestOpenAPIShouldDocumentRoutes(t *testing.T) {
+	r := NewRouter()
+	r.Group("/api").Put("/users/:id/*path", func(Context) (Handler, error) { return &openAPIHandler{}, nil }).
+		Doc("Update a user", "Replaces the user").
+		Tags("users").
+		Deprecated().
+		Request(&openAPIHandler{}).
+		Response(&userResponse{})
+	r.MethodFunc(http.MethodGet, "/debug/vars", func(Context) error { return nil }).Hidden()
+
+	b, err := json.Marshal(r.OpenAPI(OpenAPIInfo{Title: "Users", Version: "1.0.0"}))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Users", "version": "1.0.0"},
+		"paths": {
+			"/api/users/{id}/{path}": {
+				"put": {
+					"summary": "Update a user",
+					"description": "Replaces the user",
+					"tags": ["users"],
+					"deprecated": true,
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}},
+						{"name": "path", "in": "path", "required": true, "schema": {"type": "string"}},
+						{"name": "fields", "in": "query", "required": true, "schema": {"type": "string"}},
+						{"name": "X-Request-Id", "in": "header", "schema": {"type": "string"}}
+					],
+					"requestBody": {
+						"required": true,
+						"content": {"application/json": {"schema": {
+							"type": "object",
+							"required": ["name"],
+							"properties": {"name": {"type": "string"}}
+						}}}
+					},
+					"responses": {"200": {
+						"description": "OK",
+						"content": {"application/json": {"schema": {
+							"type": "object",
+							"properties": {"name": {"type": "string"}}
+						}}}
+					}}
+				}
+			}
+		}
+	}`, string(b))
+}
+
+func TestOpenAPIShouldDocumentRoutesWithoutRequests(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/health", func(Context) error { return nil })
+
+	b, err := json.Marshal(r.OpenAPI(OpenAPIInfo{}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "", "version": ""},
+		"paths": {"/health": {"get": {"responses": {"200": {"description": "OK"}}}}}
+	}`, string(b))
+}
@@ -0,0 +1,49 @@
+package boar
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/blockloop/boar/bind"
+)
+
+func (r *requestContext) Param(name string) string {
+	return r.urlParams.ByName(name)
+}
+
+func (r *requestContext) ParamInt(name string) (int, error) {
+	i, err := r.paramInt(name, reflect.Int, strconv.IntSize)
+	return int(i), err
+}
+
+func (r *requestContext) ParamInt64(name string) (int64, error) {
+	return r.paramInt(name, reflect.Int64, 64)
+}
+
+func (r *requestContext) ParamBool(name string) (bool, error) {
+	val := r.Param(name)
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, paramError(name, reflect.Bool, val, err)
+	}
+	return b, nil
+}
+
+func (r *requestContext) paramInt(name string, kind reflect.Kind, bits int) (int64, error) {
+	val := r.Param(name)
+	i, err := strconv.ParseInt(val, 10, bits)
+	if err != nil {
+		return 0, paramError(name, kind, val, err)
+	}
+	return i, nil
+}
+
+// paramError creates the same ValidationError that binding a URLParams field would
+func paramError(name string, kind reflect.Kind, val string, err error) error {
+	return NewValidationError(urlParamsField, &bind.TypeMismatchError{
+		Kind:      kind,
+		Val:       val,
+		Cause:     err,
+		FieldName: name,
+	})
+}
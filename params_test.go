@@ -0,0 +1,56 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paramContext(ps ...httprouter.Param) Context {
+	return NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), ps)
+}
+
+func TestParamShouldReturnTheValue(t *testing.T) {
+	c := paramContext(httprouter.Param{Key: "name", Value: "boar"})
+	assert.Equal(t, "boar", c.Param("name"))
+	assert.Equal(t, "", c.Param("missing"))
+}
+
+func TestParamIntShouldParseInts(t *testing.T) {
+	c := paramContext(httprouter.Param{Key: "id", Value: "42"})
+
+	i, err := c.ParamInt("id")
+	require.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	i64, err := c.ParamInt64("id")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), i64)
+}
+
+func TestParamIntShouldReturnBadRequestForInvalidValues(t *testing.T) {
+	c := paramContext(httprouter.Param{Key: "id", Value: "abc"})
+
+	_, err := c.ParamInt("id")
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*ValidationError).Status())
+	assert.Contains(t, err.Error(), "abc")
+
+	_, err = c.ParamInt64("missing")
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestParamBoolShouldParseBools(t *testing.T) {
+	c := paramContext(httprouter.Param{Key: "active", Value: "true"}, httprouter.Param{Key: "bad", Value: "maybe"})
+
+	b, err := c.ParamBool("active")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = c.ParamBool("bad")
+	assert.IsType(t, &ValidationError{}, err)
+}
@@ -0,0 +1,104 @@
+package boar
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/schema"
+	"github.com/julienschmidt/httprouter"
+)
+
+// errContextReleased is the panic of pooled Contexts which are used after their request
+// completed
+const errContextReleased = "boar: Context used after its request completed. Use boar.Detach " +
+	"to keep a Context after the handler returns"
+
+var contextPool = sync.Pool{
+	New: func() interface{} {
+		return &requestContext{
+			response:   NewBufferedResponseWriter(nil),
+			formParser: schema.NewDecoder(),
+			pooled:     true,
+		}
+	},
+}
+
+// acquireContext returns a Context from contextPool prepared for the request
+func acquireContext(rtr *Router, r *http.Request, w http.ResponseWriter, ps httprouter.Params) *requestContext {
+	c := contextPool.Get().(*requestContext)
+	c.response.(*BufferedResponseWriter).reset(w)
+	c.router = rtr
+	c.request = r
+	c.urlParams = ps
+	c.released = false
+	return c
+}
+
+// release returns a pooled Context to contextPool. Contexts whose connection was hijacked
+// are not reused since the handler may still hold the connection
+func (r *requestContext) release() {
+	if !r.pooled {
+		return
+	}
+	w, ok := r.response.(*BufferedResponseWriter)
+	if !ok || w.hijacked {
+		return
+	}
+	w.reset(nil)
+	r.router = nil
+	r.request = nil
+	r.urlParams = nil
+	r.pattern = ""
+	r.clientCtx = nil
+	for key := range r.store {
+		delete(r.store, key)
+	}
+	r.deferred = nil
+	r.released = true
+	contextPool.Put(r)
+}
+
+// mustNotBeReleased panics when r has been returned to contextPool so that handlers which
+// retain their Context fail loudly rather than reading the request of another client.
+// Retained Contexts which are already reused are not detected, run tests with -race to
+// find them
+func (r *requestContext) mustNotBeReleased() {
+	if r.released {
+		panic(errContextReleased)
+	}
+}
+
+// Detach returns a copy of c which can be used after the handler returns, such as in a
+// goroutine, when the Router pools Contexts. The copy has the request, URL params, and
+// values of c but writes to its Response are discarded. See Router.PoolContexts
+//
+// Example:
+//
+//	dc := boar.Detach(c)
+//	go func() {
+//		audit(dc.Request().Context(), dc.Param("id"))
+//	}()
+func Detach(c Context) Context {
+	rc := requestContextOf(c)
+	if rc == nil || !rc.pooled {
+		return c
+	}
+	dc := newContext(rc.Request(), discardResponseWriter{header: http.Header{}}, append(httprouter.Params(nil), rc.urlParams...))
+	dc.router = rc.router
+	dc.pattern = rc.pattern
+	rc.storeMu.RLock()
+	for key, v := range rc.store {
+		dc.Set(key, v)
+	}
+	rc.storeMu.RUnlock()
+	return dc
+}
+
+// discardResponseWriter is the response of detached Contexts
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w discardResponseWriter) Header() http.Header         { return w.header }
+func (w discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w discardResponseWriter) WriteHeader(int)             {}
@@ -0,0 +1,121 @@
+package boar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPooledContextShouldPanicWhenUsedAfterRequest(t *testing.T) {
+	r := NewRouter()
+	r.PoolContexts = true
+	var retained Context
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		retained = c
+		return c.WriteString(http.StatusOK, "ok")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "ok", rec.Body.String())
+	assert.Equal(t, errContextReleased, panicValue(func() { retained.Request() }))
+	assert.Equal(t, errContextReleased, panicValue(func() { retained.Response() }))
+}
+
+func panicValue(fn func()) (v interface{}) {
+	defer func() { v = recover() }()
+	fn()
+	return nil
+}
+
+func TestContextShouldBeUsableAfterRequestWithoutPooling(t *testing.T) {
+	r := NewRouter()
+	var retained Context
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		retained = c
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.NotPanics(t, func() { retained.Request() })
+}
+
+func TestPooledContextShouldNotBeReleasedBeforeDeferredFuncs(t *testing.T) {
+	r := NewRouter()
+	r.PoolContexts = true
+	paths := make(chan string, 1)
+	r.MethodFunc(http.MethodGet, "/users", func(c Context) error {
+		c.Defer(func(context.Context) error {
+			paths <- c.Request().URL.Path
+			return nil
+		})
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, "/users", <-paths)
+}
+
+func TestReleaseShouldResetContext(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := acquireContext(NewRouter(), httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+	c.pattern = "/"
+	c.Set("user", "brett")
+	require.NoError(t, c.WriteString(http.StatusCreated, "created"))
+
+	c.release()
+
+	assert.True(t, c.released)
+	assert.Nil(t, c.router)
+	assert.Nil(t, c.request)
+	assert.Empty(t, c.pattern)
+	assert.Empty(t, c.store)
+	assert.Equal(t, 0, c.response.Status())
+	assert.Equal(t, 0, c.response.Len())
+	assert.Equal(t, "", w.Body.String(), "released contexts should not flush")
+}
+
+func TestReleaseShouldNotReuseHijackedContext(t *testing.T) {
+	c := acquireContext(NewRouter(), httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	c.response.(*BufferedResponseWriter).setHijacked()
+
+	c.release()
+
+	assert.False(t, c.released)
+}
+
+func TestDetachShouldCopyPooledContext(t *testing.T) {
+	r := NewRouter()
+	r.PoolContexts = true
+	var detached Context
+	r.MethodFunc(http.MethodGet, "/users/:id", func(c Context) error {
+		c.Set("user", "brett")
+		detached = Detach(c)
+		return c.WriteString(http.StatusOK, "ok")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	require.NotNil(t, detached)
+	assert.Equal(t, "42", detached.Param("id"))
+	assert.Equal(t, "/users/:id", detached.RoutePattern())
+	v, _ := detached.Get("user")
+	assert.Equal(t, "brett", v)
+	assert.NoError(t, detached.WriteString(http.StatusTeapot, "discarded"))
+	assert.NoError(t, detached.Response().Flush())
+	assert.Equal(t, "ok", rec.Body.String())
+}
+
+func TestDetachShouldReturnUnpooledContext(t *testing.T) {
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	assert.Equal(t, c, Detach(c))
+}
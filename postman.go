@@ -0,0 +1,157 @@
+package boar
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// PostmanSchema is the schema of the collections created by Router.PostmanCollection
+const PostmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection creates a Postman collection of the routes of rtr which can also be
+// imported by Insomnia. Routes are grouped in folders by their first tag and their
+// requests are filled with the parameters, headers, and an example body derived from the
+// Route's Request. URLs begin with the baseUrl variable of the collection which defaults
+// to baseURL.
+//
+// Example:
+//
+//	json.NewEncoder(f).Encode(rtr.PostmanCollection("Users API", "http://localhost:8080"))
+func (rtr *Router) PostmanCollection(name, baseURL string) JSON {
+	var items []JSON
+	folders := map[string]JSON{}
+	for _, route := range rtr.root().routes {
+		if route.hidden {
+			continue
+		}
+		item := postmanItem(route.info)
+		if len(route.info.Tags) == 0 {
+			items = append(items, item)
+			continue
+		}
+		tag := route.info.Tags[0]
+		folder, ok := folders[tag]
+		if !ok {
+			folder = JSON{"name": tag, "item": []JSON{}}
+			folders[tag] = folder
+			items = append(items, folder)
+		}
+		folder["item"] = append(folder["item"].([]JSON), item)
+	}
+
+	return JSON{
+		"info":     JSON{"name": name, "schema": PostmanSchema},
+		"item":     items,
+		"variable": []JSON{{"key": "baseUrl", "value": baseURL}},
+	}
+}
+
+func postmanItem(info RouteInfo) JSON {
+	name := info.Summary
+	if name == "" {
+		name = info.Method + " " + info.Path
+	}
+
+	path := strings.Split(strings.Trim(info.Path, "/"), "/")
+	url := JSON{
+		"raw":  "{{baseUrl}}" + info.Path,
+		"host": []string{"{{baseUrl}}"},
+		"path": path,
+	}
+	var variables []JSON
+	for _, seg := range path {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			variables = append(variables, JSON{"key": seg[1:], "value": ""})
+		}
+	}
+	if len(variables) > 0 {
+		url["variable"] = variables
+	}
+
+	req := structType(info.Request)
+	var query []JSON
+	for _, p := range openAPIParams(req, queryField, "query", "query") {
+		query = append(query, JSON{"key": p["name"], "value": ""})
+	}
+	if len(query) > 0 {
+		url["query"] = query
+	}
+
+	headers := []JSON{}
+	for _, p := range openAPIParams(req, headerField, "header", "header") {
+		headers = append(headers, JSON{"key": p["name"], "value": ""})
+	}
+
+	request := JSON{
+		"method": info.Method,
+		"header": headers,
+		"url":    url,
+	}
+	if info.Description != "" {
+		request["description"] = info.Description
+	}
+	if req != nil {
+		if sf, ok := req.FieldByName(bodyField); ok && !hasTagOption(sf, tagNoBind) {
+			body, _ := json.MarshalIndent(exampleOf(schemaOf(sf.Type, map[reflect.Type]bool{})), "", "  ")
+			request["header"] = append(headers, JSON{"key": "Content-Type", "value": contentTypeJSON})
+			request["body"] = JSON{
+				"mode":    "raw",
+				"raw":     string(body),
+				"options": JSON{"raw": JSON{"language": "json"}},
+			}
+		}
+	}
+	return JSON{"name": name, "request": request}
+}
+
+// exampleOf creates an example value which satisfies s
+func exampleOf(s *Schema) interface{} {
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	switch s.Type {
+	case "object":
+		obj := map[string]interface{}{}
+		for name, prop := range s.Properties {
+			obj[name] = exampleOf(prop)
+		}
+		return obj
+	case "array":
+		n := 1
+		if s.MinItems != nil && *s.MinItems > n {
+			n = *s.MinItems
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i] = exampleOf(s.Items)
+		}
+		return items
+	case "string":
+		switch s.Format {
+		case "email":
+			return "user@example.com"
+		case "uri":
+			return "https://example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		}
+		if s.MinLength != nil {
+			return strings.Repeat("a", *s.MinLength)
+		}
+		return ""
+	case "integer", "number":
+		switch {
+		case s.Minimum != nil:
+			return *s.Minimum
+		case s.ExclusiveMinimum != nil:
+			return *s.ExclusiveMinimum + 1
+		}
+		return 0
+	case "boolean":
+		return false
+	}
+	return nil
+}
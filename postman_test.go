@@ -0,0 +1,68 @@
+package boar
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postmanHandler struct {
+	Query struct {
+		Notify bool `query:"notify"`
+	}
+	Body struct {
+		Name  string   `json:"name" validate:"required,min=3"`
+		Email string   `json:"email" validate:"email"`
+		Role  string   `json:"role" validate:"oneof=admin user"`
+		Age   int      `json:"age" validate:"gt=17"`
+		Tags  []string `json:"tags"`
+	}
+}
+
+func TestPostmanCollectionShouldExportRoutes(t *testing.T) {
+	r := NewRouter()
+	r.Post("/users/:id", func(Context) (Handler, error) { return &openAPIHandler{}, nil }).
+		Doc("Create a user", "Creates a user").
+		Tags("users").
+		Request(&postmanHandler{})
+	r.MethodFunc(http.MethodGet, "/health", func(Context) error { return nil })
+	r.ServeDocs("/docs", OpenAPIInfo{})
+
+	b, err := json.Marshal(r.PostmanCollection("Users", "http://localhost:8080"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"info": {"name": "Users", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "http://localhost:8080"}],
+		"item": [
+			{"name": "users", "item": [{
+				"name": "Create a user",
+				"request": {
+					"method": "POST",
+					"description": "Creates a user",
+					"header": [{"key": "Content-Type", "value": "application/json"}],
+					"url": {
+						"raw": "{{baseUrl}}/users/:id",
+						"host": ["{{baseUrl}}"],
+						"path": ["users", ":id"],
+						"variable": [{"key": "id", "value": ""}],
+						"query": [{"key": "notify", "value": ""}]
+					},
+					"body": {
+						"mode": "raw",
+						"raw": "{\n  \"age\": 18,\n  \"email\": \"user@example.com\",\n  \"name\": \"aaa\",\n  \"role\": \"admin\",\n  \"tags\": [\n    \"\"\n  ]\n}",
+						"options": {"raw": {"language": "json"}}
+					}
+				}
+			}]},
+			{"name": "GET /health", "request": {
+				"method": "GET",
+				"header": [],
+				"url": {"raw": "{{baseUrl}}/health", "host": ["{{baseUrl}}"], "path": ["health"]}
+			}}
+		]
+	}`, string(b))
+}
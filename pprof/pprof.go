@@ -0,0 +1,60 @@
+// Package pprof serves the net/http/pprof profiles and the expvar variables with a boar
+// Router. It is a separate package because importing net/http/pprof and expvar registers
+// their handlers on http.DefaultServeMux, which must not happen to every application that
+// imports boar. Importing this package does the same.
+package pprof
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/blockloop/boar"
+)
+
+// Enable adds the net/http/pprof profiles under prefix/pprof/ and the expvar variables at
+// prefix/vars to rtr. mw are added to the routes so that they can be guarded, for example
+// with middleware.BasicAuth, since they expose the internals of the application. The
+// routes are not documented by OpenAPI.
+//
+// Example:
+//
+//	pprof.Enable(rtr, "/debug", middleware.BasicAuth("debug", admins.Authenticate))
+//	// go tool pprof http://localhost:8080/debug/pprof/heap
+func Enable(rtr *boar.Router, prefix string, mw ...boar.Middleware) {
+	debug := rtr.Group(prefix, mw...)
+	debug.MethodFunc(http.MethodGet, "/pprof/", serveHandler(http.HandlerFunc(pprof.Index))).Hidden()
+	debug.MethodFunc(http.MethodGet, "/pprof/:name", serveProfile).Hidden()
+	debug.MethodFunc(http.MethodPost, "/pprof/:name", serveProfile).Hidden()
+	debug.MethodFunc(http.MethodGet, "/vars", serveHandler(expvar.Handler())).Hidden()
+}
+
+// serveProfile serves the profile named by the URL parameter name
+func serveProfile(c boar.Context) error {
+	var h http.Handler
+	switch name := c.URLParams().ByName("name"); name {
+	case "cmdline":
+		h = http.HandlerFunc(pprof.Cmdline)
+	case "profile":
+		h = http.HandlerFunc(pprof.Profile)
+	case "symbol":
+		h = http.HandlerFunc(pprof.Symbol)
+	case "trace":
+		h = http.HandlerFunc(pprof.Trace)
+	default:
+		if c.Request().Method != http.MethodGet {
+			return boar.NewHTTPErrorStatus(http.StatusMethodNotAllowed)
+		}
+		h = pprof.Handler(name)
+	}
+	h.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// serveHandler creates a HandlerFunc which serves requests with h
+func serveHandler(h http.Handler) boar.HandlerFunc {
+	return func(c boar.Context) error {
+		h.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
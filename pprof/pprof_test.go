@@ -0,0 +1,55 @@
+package pprof
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blockloop/boar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableShouldServeProfiles(t *testing.T) {
+	r := boar.NewRouter()
+	Enable(r, "/debug")
+
+	tests := []struct {
+		method   string
+		path     string
+		code     int
+		contains string
+	}{
+		{http.MethodGet, "/debug/pprof/", http.StatusOK, "goroutine"},
+		{http.MethodGet, "/debug/pprof/goroutine?debug=1", http.StatusOK, "goroutine profile"},
+		{http.MethodGet, "/debug/pprof/cmdline", http.StatusOK, ""},
+		{http.MethodPost, "/debug/pprof/symbol", http.StatusOK, "num_symbols"},
+		{http.MethodPost, "/debug/pprof/heap", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/debug/vars", http.StatusOK, "memstats"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.code, rec.Code, tt.path)
+		assert.Contains(t, rec.Body.String(), tt.contains, tt.path)
+	}
+}
+
+func TestEnableShouldUseMiddlewares(t *testing.T) {
+	r := boar.NewRouter()
+	Enable(r, "/debug", func(boar.HandlerFunc) boar.HandlerFunc {
+		return func(boar.Context) error {
+			return boar.ErrForbidden
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestEnableShouldHideRoutes(t *testing.T) {
+	r := boar.NewRouter()
+	Enable(r, "/debug")
+
+	assert.NotContains(t, r.OpenAPI(boar.OpenAPIInfo{Title: "t", Version: "1"})["paths"], "/debug/vars")
+}
@@ -0,0 +1,117 @@
+package boar
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const contentTypeProblemJSON = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document. Problems can describe errors as well
+// as non-error responses such as partial failures.
+//
+// Example:
+//
+//	p := boar.NewProblem(http.StatusMultiStatus, "2 of 3 items were saved")
+//	p.Extensions = boar.JSON{"failed": failedIDs}
+//	return c.WriteProblemDetails(p)
+type Problem struct {
+	// Type is a URI identifying the problem type. It defaults to about:blank
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status,omitempty"`
+	// Detail is an explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI identifying this occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+	// Extensions are additional members which are added to the problem document
+	Extensions JSON `json:"-"`
+}
+
+// NewProblem creates a Problem for status with the standard status text as the title
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// BadRequestProblem creates a Problem for StatusBadRequest
+func BadRequestProblem(detail string) *Problem {
+	return NewProblem(http.StatusBadRequest, detail)
+}
+
+// UnauthorizedProblem creates a Problem for StatusUnauthorized
+func UnauthorizedProblem(detail string) *Problem {
+	return NewProblem(http.StatusUnauthorized, detail)
+}
+
+// ForbiddenProblem creates a Problem for StatusForbidden
+func ForbiddenProblem(detail string) *Problem {
+	return NewProblem(http.StatusForbidden, detail)
+}
+
+// NotFoundProblem creates a Problem for StatusNotFound
+func NotFoundProblem(detail string) *Problem {
+	return NewProblem(http.StatusNotFound, detail)
+}
+
+// ConflictProblem creates a Problem for StatusConflict
+func ConflictProblem(detail string) *Problem {
+	return NewProblem(http.StatusConflict, detail)
+}
+
+// UnprocessableEntityProblem creates a Problem for StatusUnprocessableEntity
+func UnprocessableEntityProblem(detail string) *Problem {
+	return NewProblem(http.StatusUnprocessableEntity, detail)
+}
+
+// InternalServerErrorProblem creates a Problem for StatusInternalServerError
+func InternalServerErrorProblem(detail string) *Problem {
+	return NewProblem(http.StatusInternalServerError, detail)
+}
+
+// MarshalJSON marshals the problem members and its Extensions into a single object
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	if len(p.Extensions) == 0 {
+		return json.Marshal((*problem)(p))
+	}
+	b, err := json.Marshal((*problem)(p))
+	if err != nil {
+		return nil, err
+	}
+	doc := JSON{}
+	for k, v := range p.Extensions {
+		doc[k] = v
+	}
+	// the standard members take precedence over extensions with the same name
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+func (r *requestContext) WriteProblem(status int, title, detail string) error {
+	p := NewProblem(status, detail)
+	if title != "" {
+		p.Title = title
+	}
+	return r.WriteProblemDetails(p)
+}
+
+func (r *requestContext) WriteProblemDetails(p *Problem) error {
+	b, err := marshal(r.codec(), p)
+	if err != nil {
+		return err
+	}
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	return r.Write(status, contentTypeProblemJSON, b)
+}
@@ -0,0 +1,58 @@
+package boar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblemShouldWriteProblemDocument(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	require.NoError(t, c.WriteProblem(http.StatusConflict, "", "user already exists"))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, contentTypeProblemJSON, w.Header().Get("content-type"))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Conflict","status":409,"detail":"user already exists"}`, w.Body.String())
+}
+
+func TestWriteProblemDetailsShouldIncludeExtensions(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	p := NewProblem(http.StatusMultiStatus, "1 of 2 items were saved")
+	p.Extensions = JSON{"failed": []int{2}, "status": "ignored"}
+	require.NoError(t, c.WriteProblemDetails(p))
+	require.NoError(t, c.Response().Flush())
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Multi-Status","status":207,"detail":"1 of 2 items were saved","failed":[2]}`, w.Body.String())
+}
+
+func TestProblemConstructorsShouldSetStatus(t *testing.T) {
+	cases := map[int]*Problem{
+		http.StatusBadRequest:          BadRequestProblem(""),
+		http.StatusUnauthorized:        UnauthorizedProblem(""),
+		http.StatusForbidden:           ForbiddenProblem(""),
+		http.StatusNotFound:            NotFoundProblem(""),
+		http.StatusConflict:            ConflictProblem(""),
+		http.StatusUnprocessableEntity: UnprocessableEntityProblem(""),
+		http.StatusInternalServerError: InternalServerErrorProblem(""),
+	}
+	for status, p := range cases {
+		assert.Equal(t, status, p.Status)
+		assert.Equal(t, http.StatusText(status), p.Title)
+	}
+}
+
+func TestProblemMarshalJSONShouldOmitEmptyMembers(t *testing.T) {
+	b, err := json.Marshal(&Problem{Title: "Oops"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":"Oops"}`, string(b))
+}
@@ -0,0 +1,43 @@
+package boar
+
+import (
+	"strconv"
+	"strings"
+)
+
+// queryValue returns the first trimmed value of the named query parameter
+func (r *requestContext) queryValue(name string) (string, bool) {
+	val := strings.TrimSpace(r.request.URL.Query().Get(name))
+	return val, val != ""
+}
+
+func (r *requestContext) QueryString(name, def string) string {
+	if val, ok := r.queryValue(name); ok {
+		return val
+	}
+	return def
+}
+
+func (r *requestContext) QueryInt(name string, def int) int {
+	val, ok := r.queryValue(name)
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return def
+	}
+	return i
+}
+
+func (r *requestContext) QueryBool(name string, def bool) bool {
+	val, ok := r.queryValue(name)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return def
+	}
+	return b
+}
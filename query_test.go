@@ -0,0 +1,34 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queryContext(query string) Context {
+	return NewContext(httptest.NewRequest(http.MethodGet, "/?"+query, nil), httptest.NewRecorder(), nil)
+}
+
+func TestQueryStringShouldReturnValueOrDefault(t *testing.T) {
+	c := queryContext("sort=name&empty=")
+	assert.Equal(t, "name", c.QueryString("sort", "id"))
+	assert.Equal(t, "id", c.QueryString("empty", "id"))
+	assert.Equal(t, "id", c.QueryString("missing", "id"))
+}
+
+func TestQueryIntShouldReturnValueOrDefault(t *testing.T) {
+	c := queryContext("page=3&bad=abc")
+	assert.Equal(t, 3, c.QueryInt("page", 1))
+	assert.Equal(t, 1, c.QueryInt("bad", 1))
+	assert.Equal(t, 1, c.QueryInt("missing", 1))
+}
+
+func TestQueryBoolShouldReturnValueOrDefault(t *testing.T) {
+	c := queryContext("archived=true&bad=maybe")
+	assert.True(t, c.QueryBool("archived", false))
+	assert.True(t, c.QueryBool("bad", true))
+	assert.False(t, c.QueryBool("missing", false))
+}
@@ -0,0 +1,117 @@
+package boar
+
+import (
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicPolicy controls what the middleware created by RecoverWithConfig does with panics
+type PanicPolicy int
+
+const (
+	// PanicRecover returns panics as a PanicError which the ErrorHandler writes as a 500
+	PanicRecover PanicPolicy = iota
+	// PanicRepanic logs panics and then panics again so that they reach net/http, or
+	// another recovery middleware outside of boar, rather than the ErrorHandler
+	PanicRepanic
+	// PanicAbort logs panics and then aborts the connection with http.ErrAbortHandler
+	// without writing an error. It suits streaming and proxied endpoints where a JSON
+	// error in the middle of the response would corrupt it
+	PanicAbort
+)
+
+// decidedPanic is panicked by the recovery middleware when a panic should not be
+// recovered so that recovery middlewares of parent Routers let it through. Method panics
+// again with value once it reaches the top of the middleware chain
+type decidedPanic struct {
+	value interface{}
+}
+
+// RecoverConfig configures the middleware created by RecoverWithConfig
+type RecoverConfig struct {
+	// OnPanic is called with every recovered panic before it is returned to the
+	// ErrorHandler. It is useful for reporting panics to services such as Sentry or Bugsnag
+	OnPanic func(Context, *PanicError)
+	// IncludeStack includes the stack trace of the panic in the response body. It should
+	// only be enabled in development because it exposes the internals of the application
+	IncludeStack bool
+	// Repanic reports whether a recovered value should be panicked again rather than
+	// returned as a PanicError. It allows values such as http.ErrAbortHandler to reach
+	// net/http which aborts the response without logging a stack trace
+	Repanic func(recovered interface{}) bool
+	// Policy controls whether panics are recovered, panicked again, or abort the
+	// connection. Panics are logged and passed to OnPanic with every policy. The
+	// middleware of a group or route takes precedence over that of its parent Routers.
+	//
+	// Example:
+	//
+	//	proxy := rtr.Group("/proxy", boar.RecoverWithConfig(boar.RecoverConfig{Policy: boar.PanicAbort}))
+	Policy PanicPolicy
+}
+
+// RepanicAbortHandler is a RecoverConfig.Repanic func which re-panics http.ErrAbortHandler
+func RepanicAbortHandler(recovered interface{}) bool {
+	return recovered == http.ErrAbortHandler
+}
+
+// RecoverWithConfig creates a middleware which recovers from panics happening in http
+// handlers and returns them as a PanicError to be received by the normal middleware
+// chain. See PanicMiddleware
+//
+// Example:
+//
+//	rtr.Use(boar.RecoverWithConfig(boar.RecoverConfig{
+//		OnPanic: func(c boar.Context, p *boar.PanicError) {
+//			sentry.CaptureException(p.Cause())
+//		},
+//		Repanic: boar.RepanicAbortHandler,
+//	}))
+func RecoverWithConfig(cfg RecoverConfig) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c Context) (err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				if _, ok := r.(decidedPanic); ok {
+					panic(r)
+				}
+				if cfg.Repanic != nil && cfg.Repanic(r) {
+					panic(decidedPanic{r})
+				}
+				perr := NewPanicError(r, debug.Stack())
+				perr.includeStack = cfg.IncludeStack
+				c.Logger().Error("recovered from panic",
+					"method", c.Request().Method,
+					"path", c.Request().URL.Path,
+					"error", perr.Cause(),
+					"stack", string(perr.Stack),
+				)
+				if cfg.OnPanic != nil {
+					cfg.OnPanic(c, perr)
+				}
+				switch cfg.Policy {
+				case PanicRepanic:
+					panic(decidedPanic{r})
+				case PanicAbort:
+					panic(decidedPanic{http.ErrAbortHandler})
+				}
+				err = perr
+			}()
+			err = next(c)
+			return
+		}
+	}
+}
+
+// unwrapDecidedPanic panics with the value of a decidedPanic so that net/http receives the
+// original value
+func unwrapDecidedPanic() {
+	if r := recover(); r != nil {
+		if p, ok := r.(decidedPanic); ok {
+			panic(p.value)
+		}
+		panic(r)
+	}
+}
@@ -0,0 +1,110 @@
+package boar
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRecover(cfg RecoverConfig, h HandlerFunc) *httptest.ResponseRecorder {
+	r := NewRouter()
+	r.Logger = NopLogger
+	r.Use(RecoverWithConfig(cfg))
+	r.MethodFunc(http.MethodGet, "/", h)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestRecoverShouldCallOnPanic(t *testing.T) {
+	var reported *PanicError
+	rec := serveRecover(RecoverConfig{
+		OnPanic: func(c Context, p *PanicError) {
+			reported = p
+		},
+	}, func(Context) error {
+		panic("something broke")
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	if assert.NotNil(t, reported) {
+		assert.EqualError(t, reported.Cause(), "something broke")
+		assert.NotEmpty(t, reported.Stack)
+	}
+}
+
+func TestRecoverShouldNotIncludeStackByDefault(t *testing.T) {
+	rec := serveRecover(RecoverConfig{}, func(Context) error {
+		panic("something broke")
+	})
+	assert.NotContains(t, rec.Body.String(), "stack")
+}
+
+func TestRecoverShouldIncludeStackWhenConfigured(t *testing.T) {
+	rec := serveRecover(RecoverConfig{IncludeStack: true}, func(Context) error {
+		panic("something broke")
+	})
+	assert.Contains(t, rec.Body.String(), `"stack":"goroutine`)
+}
+
+func TestRecoverShouldRepanicMatchingValues(t *testing.T) {
+	defer func() {
+		assert.Equal(t, http.ErrAbortHandler, recover())
+	}()
+	serveRecover(RecoverConfig{Repanic: RepanicAbortHandler}, func(Context) error {
+		panic(http.ErrAbortHandler)
+	})
+	t.Fatal("expected panic")
+}
+
+func TestRecoverShouldRecoverNonMatchingValues(t *testing.T) {
+	rec := serveRecover(RecoverConfig{Repanic: RepanicAbortHandler}, func(Context) error {
+		panic(errors.New("something broke"))
+	})
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func servePanicPolicy(policy PanicPolicy, reported *int) {
+	r := NewRouter()
+	r.Logger = NopLogger
+	r.Use(PanicMiddleware)
+	stream := r.Group("/stream", RecoverWithConfig(RecoverConfig{
+		Policy: policy,
+		OnPanic: func(Context, *PanicError) {
+			*reported++
+		},
+	}))
+	stream.MethodFunc(http.MethodGet, "/", func(Context) error {
+		panic("upstream closed")
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stream/", nil))
+}
+
+func TestRecoverPolicyShouldRepanicPastParentMiddleware(t *testing.T) {
+	var reported int
+	defer func() {
+		assert.Equal(t, "upstream closed", recover())
+		assert.Equal(t, 1, reported)
+	}()
+	servePanicPolicy(PanicRepanic, &reported)
+	t.Fatal("expected panic")
+}
+
+func TestRecoverPolicyShouldAbortConnection(t *testing.T) {
+	var reported int
+	defer func() {
+		assert.Equal(t, http.ErrAbortHandler, recover())
+		assert.Equal(t, 1, reported)
+	}()
+	servePanicPolicy(PanicAbort, &reported)
+	t.Fatal("expected panic")
+}
+
+func TestRecoverPolicyShouldRecoverByDefault(t *testing.T) {
+	var reported int
+	servePanicPolicy(PanicRecover, &reported)
+	assert.Equal(t, 1, reported)
+}
@@ -7,16 +7,23 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/blockloop/boar/bind"
 	"github.com/julienschmidt/httprouter"
-	"gopkg.in/go-playground/validator.v9"
 )
 
 const (
 	queryField     = "Query"
 	urlParamsField = "URLParams"
+	headerField    = "Header"
 	bodyField      = "Body"
+	responseField  = "Response"
+
+	boarTagKey    = "boar"
+	tagNoValidate = "novalidate"
+	tagNoBind     = "-"
+	ctxTagKey     = "ctx"
 )
 
 var (
@@ -29,12 +36,87 @@ var (
 	errNoContentType = errors.New("content-type header was not set on the request")
 
 	contentTypeJSON          = "application/json"
+	contentTypeXML           = "application/xml"
+	contentTypeText          = "text/plain; charset=utf-8"
+	contentTypeHTML          = "text/html; charset=utf-8"
+	contentTypeJavaScript    = "application/javascript; charset=utf-8"
 	contentTypeFormEncoded   = "application/x-www-form-urlencoded"
 	contentTypeMultipartForm = "multipart/form-data"
-
-	validateImpl = validator.New()
 )
 
+// handlerLayout is the layout of a handler type. It is built once per type so that
+// binding does not search the fields of the handler for every request
+type handlerLayout struct {
+	fields    map[string]layoutField
+	ctxFields []ctxField
+}
+
+type layoutField struct {
+	index      []int
+	noValidate bool
+}
+
+type ctxField struct {
+	index int
+	name  string
+	key   string
+}
+
+// layouts caches the handlerLayout of each handler type
+var layouts sync.Map // map[reflect.Type]*handlerLayout
+
+// layoutOf returns the cached layout of the handler struct type typ
+func layoutOf(typ reflect.Type) *handlerLayout {
+	if l, ok := layouts.Load(typ); ok {
+		return l.(*handlerLayout)
+	}
+
+	l := &handlerLayout{fields: make(map[string]layoutField)}
+	for _, name := range []string{queryField, urlParamsField, headerField, bodyField, responseField} {
+		sf, ok := typ.FieldByName(name)
+		if !ok || hasTagOption(sf, tagNoBind) {
+			continue
+		}
+		// a Header which is not a struct, such as an http.Header, belongs to the handler
+		if name == headerField && sf.Type.Kind() != reflect.Struct {
+			continue
+		}
+		l.fields[name] = layoutField{
+			index:      sf.Index,
+			noValidate: hasTagOption(sf, tagNoValidate),
+		}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		key, ok := sf.Tag.Lookup(ctxTagKey)
+		if !ok || key == "" || key == "-" {
+			continue
+		}
+		l.ctxFields = append(l.ctxFields, ctxField{index: i, name: sf.Name, key: key})
+	}
+
+	actual, _ := layouts.LoadOrStore(typ, l)
+	return actual.(*handlerLayout)
+}
+
+// has reports whether the handler type has the named field
+func (l *handlerLayout) has(name string) bool {
+	_, ok := l.fields[name]
+	return ok
+}
+
+// fieldOf returns the named field of handler, or the zero Value when it has no such field
+func fieldOf(handler reflect.Value, name string) reflect.Value {
+	if handler.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	f, ok := layoutOf(handler.Type()).fields[name]
+	if !ok {
+		return reflect.Value{}
+	}
+	return handler.FieldByIndex(f.index)
+}
+
 func checkField(field reflect.Value) (bool, error) {
 	if !field.IsValid() {
 		return false, nil
@@ -48,8 +130,16 @@ func checkField(field reflect.Value) (bool, error) {
 	return true, nil
 }
 
+// binderOf returns a pointer to handler which may implement QueryBinder or URLParamsBinder
+func binderOf(handler reflect.Value) interface{} {
+	if !handler.CanAddr() {
+		return nil
+	}
+	return handler.Addr().Interface()
+}
+
 func setQuery(handler reflect.Value, qs url.Values) error {
-	field := handler.FieldByName(queryField)
+	field := fieldOf(handler, queryField)
 	ok, err := checkField(field)
 	if !ok {
 		if err == nil {
@@ -61,14 +151,19 @@ func setQuery(handler reflect.Value, qs url.Values) error {
 			err:     err,
 		}
 	}
-	if err := bind.QueryValue(field, qs); err != nil {
+	if b, ok := binderOf(handler).(QueryBinder); ok {
+		err = b.BindQuery(qs)
+	} else {
+		err = bind.QueryValue(field, qs)
+	}
+	if err != nil {
 		return NewValidationError(queryField, err)
 	}
-	return validate(queryField, field.Addr().Interface())
+	return validateField(handler, "", queryField, field)
 }
 
 func setURLParams(handler reflect.Value, params httprouter.Params) error {
-	field := handler.FieldByName(urlParamsField)
+	field := fieldOf(handler, urlParamsField)
 	ok, err := checkField(field)
 	if !ok {
 		if err == nil {
@@ -80,17 +175,150 @@ func setURLParams(handler reflect.Value, params httprouter.Params) error {
 			err:     err,
 		}
 	}
-	if err := bind.ParamsValue(field, params); err != nil {
+	if b, ok := binderOf(handler).(URLParamsBinder); ok {
+		err = b.BindURLParams(params)
+	} else {
+		err = bind.ParamsValue(field, params)
+	}
+	if err != nil {
 		if tme, ok := err.(*bind.TypeMismatchError); ok {
 			return NewValidationError(urlParamsField, tme)
 		}
 		return err
 	}
-	return validate(urlParamsField, field.Addr().Interface())
+	return validateField(handler, "", urlParamsField, field)
+}
+
+func setHeader(handler reflect.Value, h http.Header) error {
+	field := fieldOf(handler, headerField)
+	ok, err := checkField(field)
+	if !ok {
+		if err == nil {
+			return nil
+		}
+		return &badFieldError{
+			field:   headerField,
+			handler: handler,
+			err:     err,
+		}
+	}
+	if err := bind.HeaderValue(field, h); err != nil {
+		return NewValidationError(headerField, err)
+	}
+	return validateField(handler, "", headerField, field)
+}
+
+// bindRequest populates the Query, URLParams, Header, and Body fields of v with the
+// request data and validates them. Validation errors of the Query, Header, and Body
+// fields are returned together as a MultiError. The request body is only parsed when the
+// handler has a Body field
+func bindRequest(v reflect.Value, c Context) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	layout := layoutOf(v.Type())
+	r := c.Request()
+	var errs []error
+	if layout.has(queryField) {
+		if err := setQuery(v, r.URL.Query()); err != nil {
+			if _, ok := err.(*ValidationError); !ok {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	if err := setURLParams(v, c.URLParams()); err != nil {
+		if _, ok := err.(*ValidationError); ok {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if err := setHeader(v, r.Header); err != nil {
+		if _, ok := err.(*ValidationError); !ok {
+			return err
+		}
+		errs = append(errs, err)
+	}
+
+	if err := setContextValues(v, c); err != nil {
+		return err
+	}
+
+	if layout.has(bodyField) {
+		if err := setBody(v, c); err != nil {
+			if _, ok := err.(*ValidationError); !ok {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return NewMultiError(errs...)
+}
+
+// writeResponse writes the Response field of handler with Context.Negotiate and the
+// Router's ResponseStatus when the handler did not respond itself. Handlers which write a
+// status, such as with Context.NoContent or Context.Redirect, or a body have responded.
+// Nil pointers and interfaces are not written
+func writeResponse(handler reflect.Value, c Context) error {
+	if handler.Kind() != reflect.Struct {
+		return nil
+	}
+	field := fieldOf(handler, responseField)
+	if !field.IsValid() || !field.CanInterface() || isNil(field) {
+		return nil
+	}
+	if c.Response().Status() != 0 || c.Response().Len() > 0 {
+		return nil
+	}
+	return c.Negotiate(responseStatus(c), field.Interface())
+}
+
+// responseStatus returns the ResponseStatus of the Router which handled the request
+func responseStatus(c Context) int {
+	if rtr := routerOf(c); rtr != nil && rtr.ResponseStatus != 0 {
+		return rtr.ResponseStatus
+	}
+	return http.StatusOK
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// setContextValues sets fields tagged with `ctx:"key"` to the value stored under key with
+// Context.Set. This allows middleware, such as authentication, to provide values to
+// handlers. Fields are left empty when there is no value for their key
+func setContextValues(handler reflect.Value, c Context) error {
+	for _, cf := range layoutOf(handler.Type()).ctxFields {
+		v, ok := c.Get(cf.key)
+		if !ok || v == nil {
+			continue
+		}
+		field := handler.Field(cf.index)
+		if !field.CanSet() {
+			return &badFieldError{field: cf.name, handler: handler, err: errNotSettable}
+		}
+		val := reflect.ValueOf(v)
+		if !val.Type().AssignableTo(field.Type()) {
+			return &badFieldError{
+				field:   cf.name,
+				handler: handler,
+				err:     fmt.Errorf("not assignable from %s stored as %q", val.Type(), cf.key),
+			}
+		}
+		field.Set(val)
+	}
+	return nil
 }
 
 func setBody(handler reflect.Value, c Context) error {
-	field := handler.FieldByName(bodyField)
+	field := fieldOf(handler, bodyField)
 	ok, err := checkField(field)
 	if !ok {
 		if err == nil {
@@ -102,41 +330,50 @@ func setBody(handler reflect.Value, c Context) error {
 			err:     err,
 		}
 	}
-	binder, err := getBinder(c)
+	r := c.Request()
+	binder, err := getBinder(c, r)
+	if errors.Is(err, ErrRequestEntityTooLarge) {
+		return ErrRequestEntityTooLarge
+	}
 	if err != nil {
 		return NewHTTPError(http.StatusBadRequest, err)
 	}
 
 	if err := binder(field.Addr().Interface()); err != nil {
+		if errors.Is(err, ErrRequestEntityTooLarge) {
+			return ErrRequestEntityTooLarge
+		}
 		return NewValidationError(bodyField, err)
 	}
-	return validate(bodyField, field.Addr().Interface())
+	return validateField(handler, r.Method, bodyField, field)
 }
 
 type binderFunc func(interface{}) error
 
-func getBinder(c Context) (binderFunc, error) {
-	ct := c.Request().Header.Get("content-type")
-	switch ct {
-	case "":
+func getBinder(c Context, r *http.Request) (binderFunc, error) {
+	ct := r.Header.Get("content-type")
+	if ct == "" {
 		return nil, errNoContentType
+	}
+	switch mediaType(ct) {
 	case contentTypeJSON:
 		return c.ReadJSON, nil
 	case contentTypeFormEncoded:
-		return c.ReadForm, c.Request().ParseForm()
+		return c.ReadForm, r.ParseForm()
+	case contentTypeMultipartForm:
+		return c.ReadForm, r.ParseMultipartForm(MultiPartFormMaxMemory)
 	default:
-		if strings.HasPrefix(ct, contentTypeMultipartForm) {
-			return c.ReadForm, c.Request().ParseMultipartForm(MultiPartFormMaxMemory)
-		}
 		return nil, fmt.Errorf("unknown content type: %q", ct)
 	}
 }
 
-func validate(fieldName string, v interface{}) error {
-	if err := validateImpl.Struct(v); err != nil {
-		return NewValidationErrors(fieldName, []error{err})
+func hasTagOption(sf reflect.StructField, option string) bool {
+	for _, opt := range strings.Split(sf.Tag.Get(boarTagKey), ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
 type badFieldError struct {
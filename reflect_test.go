@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
 
+	"github.com/blockloop/boar/bind"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
@@ -336,3 +338,326 @@ func TestValidateShouldErrorWhenBadValue(t *testing.T) {
 	err := validate("", &f)
 	require.Error(t, err)
 }
+
+func TestValidateShouldKeyFieldErrorsByJSONName(t *testing.T) {
+	var body struct {
+		Email   string `json:"email" validate:"required,email"`
+		Address struct {
+			Zip string `json:"zip_code" validate:"required"`
+		} `json:"address"`
+	}
+	body.Email = "not an email"
+
+	err := validate(bodyField, &body)
+	require.IsType(t, &ValidationError{}, err)
+
+	fields := err.(*ValidationError).fields
+	require.Len(t, fields, 2)
+	assert.Equal(t, FieldError{Field: "email", StructField: "Email", Tag: "email", Message: "must be a valid email"}, fields[0])
+	assert.Equal(t, FieldError{Field: "address.zip_code", StructField: "Address.Zip", Tag: "required", Message: "is required"}, fields[1])
+}
+
+func TestJSONFieldPathUsesStructNameWithoutJSONTag(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	var body struct {
+		Items []item `json:"items"`
+	}
+	assert.Equal(t, "items[1].Name", jsonFieldPath(reflect.TypeOf(&body), "Items[1].Name"))
+}
+
+func TestJSONFieldPathSkipsNamedTypePrefix(t *testing.T) {
+	type Body struct {
+		Email string `json:"email"`
+	}
+	assert.Equal(t, "email", jsonFieldPath(reflect.TypeOf(&Body{}), "Body.Email"))
+}
+
+func TestSetQueryShouldNotValidateWhenTaggedNoValidate(t *testing.T) {
+	var handler struct {
+		Query struct {
+			Age int `validate:"required"`
+		} `boar:"novalidate"`
+	}
+	err := setQuery(reflect.Indirect(reflect.ValueOf(&handler)), url.Values{})
+	assert.NoError(t, err)
+}
+
+type skippedQuery struct {
+	Age int `validate:"required"`
+}
+
+func (skippedQuery) SkipValidation() bool { return true }
+
+func TestSetQueryShouldNotValidateWhenValidationSkipper(t *testing.T) {
+	var handler struct {
+		Query skippedQuery
+	}
+	err := setQuery(reflect.Indirect(reflect.ValueOf(&handler)), url.Values{
+		"Age": []string{"0"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestSetHeaderShouldSetHeaderFields(t *testing.T) {
+	var handler struct {
+		Header struct {
+			Token string `header:"X-Token"`
+		}
+	}
+	err := setHeader(reflect.Indirect(reflect.ValueOf(&handler)), http.Header{
+		"X-Token": []string{"secret"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", handler.Header.Token)
+}
+
+func TestSetHeaderShouldErrorWithMismatchType(t *testing.T) {
+	var handler struct {
+		Header struct {
+			Retries int `header:"X-Retries"`
+		}
+	}
+	err := setHeader(reflect.Indirect(reflect.ValueOf(&handler)), http.Header{
+		"X-Retries": []string{"many"},
+	})
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestSetHeaderShouldIgnoreFieldsTaggedToSkipBinding(t *testing.T) {
+	var handler struct {
+		Header http.Header `boar:"-"`
+	}
+	err := setHeader(reflect.Indirect(reflect.ValueOf(&handler)), http.Header{
+		"X-Token": []string{"secret"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, handler.Header)
+}
+
+func TestSetHeaderShouldIgnoreHeaderFieldsWhichAreNotStructs(t *testing.T) {
+	var handler struct {
+		Header http.Header
+	}
+	err := setHeader(reflect.Indirect(reflect.ValueOf(&handler)), http.Header{
+		"X-Token": []string{"secret"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, handler.Header)
+
+	var str struct {
+		Header string
+	}
+	assert.NoError(t, setHeader(reflect.Indirect(reflect.ValueOf(&str)), http.Header{}))
+}
+
+func TestRouterShouldServeHandlersWithAnHTTPHeaderField(t *testing.T) {
+	rtr := NewRouter()
+	rtr.Method(http.MethodGet, "/", func(Context) (Handler, error) {
+		return &httpHeaderHandler{Header: http.Header{"X-Handler": []string{"set"}}}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Token", "secret")
+	w := httptest.NewRecorder()
+	rtr.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "set", w.Header().Get("X-Handler"))
+}
+
+type httpHeaderHandler struct {
+	Header http.Header
+}
+
+func (h *httpHeaderHandler) Handle(c Context) error {
+	if h.Header.Get("X-Token") != "" {
+		return fmt.Errorf("the request header was bound to the handler")
+	}
+	c.Response().Header().Set("X-Handler", h.Header.Get("X-Handler"))
+	return c.Write(http.StatusOK, "text/plain", nil)
+}
+
+func TestBindShouldIgnoreFieldsTaggedToSkipBinding(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?name=a", nil)
+	req.Header.Set("X-Token", "secret")
+	c := newContext(req, httptest.NewRecorder(), nil)
+
+	var v struct {
+		Header http.Header `boar:"-"`
+		Query  struct {
+			Name string `query:"name"`
+		} `boar:"-"`
+	}
+	require.NoError(t, c.Bind(&v))
+	assert.Nil(t, v.Header)
+	assert.Empty(t, v.Query.Name)
+}
+
+func TestSetBodyShouldAcceptJSONWithCharset(t *testing.T) {
+	var handler struct {
+		Body struct {
+			Age int
+		}
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mc := NewMockContext(ctrl)
+	mc.EXPECT().ReadJSON(gomock.Any()).Return(nil)
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString("{}"))
+	req.Header.Set("content-type", "application/json; charset=utf-8")
+	mc.EXPECT().Request().Return(req)
+
+	err := setBody(reflect.Indirect(reflect.ValueOf(&handler)), mc)
+	assert.NoError(t, err)
+}
+
+func TestSetContextValuesShouldSetTaggedFields(t *testing.T) {
+	var handler struct {
+		User    string `ctx:"user"`
+		Missing string `ctx:"missing"`
+	}
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	c.Set("user", "brett")
+
+	err := setContextValues(reflect.Indirect(reflect.ValueOf(&handler)), c)
+	assert.NoError(t, err)
+	assert.Equal(t, "brett", handler.User)
+	assert.Empty(t, handler.Missing)
+}
+
+func TestSetContextValuesShouldErrorForMismatchedTypes(t *testing.T) {
+	var handler struct {
+		User int `ctx:"user"`
+	}
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	c.Set("user", "brett")
+
+	err := setContextValues(reflect.Indirect(reflect.ValueOf(&handler)), c)
+	assert.IsType(t, &badFieldError{}, err)
+}
+
+type generatedBinderHandler struct {
+	Query struct {
+		Page int `validate:"min=1"`
+	}
+	URLParams struct {
+		ID int
+	}
+}
+
+func (h *generatedBinderHandler) BindQuery(q url.Values) error {
+	h.Query.Page = len(q["page"])
+	return nil
+}
+
+func (h *generatedBinderHandler) BindURLParams(params httprouter.Params) error {
+	if params.ByName("id") == "x" {
+		return &bind.TypeMismatchError{FieldName: "ID"}
+	}
+	h.URLParams.ID = len(params)
+	return nil
+}
+
+func TestBindRequestShouldUseGeneratedBinders(t *testing.T) {
+	var h generatedBinderHandler
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/?page=a&page=b", nil), httptest.NewRecorder(),
+		httprouter.Params{{Key: "id", Value: "1"}})
+
+	require.NoError(t, c.Bind(&h))
+	assert.Equal(t, 2, h.Query.Page)
+	assert.Equal(t, 1, h.URLParams.ID)
+}
+
+func TestBindRequestShouldValidateGeneratedBindings(t *testing.T) {
+	var h generatedBinderHandler
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	err := c.Bind(&h)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Page")
+}
+
+func TestBindRequestShouldReturnNotFoundForGeneratedParamsMismatch(t *testing.T) {
+	var h generatedBinderHandler
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(),
+		httprouter.Params{{Key: "id", Value: "x"}})
+
+	assert.Equal(t, ErrNotFound, c.Bind(&h))
+}
+
+func TestBindRequestShouldNotReadBodyWithoutBodyField(t *testing.T) {
+	var handler struct {
+		Query struct {
+			Page int `query:"page"`
+		}
+	}
+	req := httptest.NewRequest(http.MethodPost, "/?page=2", bytes.NewBufferString("{not json"))
+	req.Header.Set("content-type", contentTypeJSON)
+	c := NewContext(req, httptest.NewRecorder(), nil)
+
+	require.NoError(t, c.Bind(&handler))
+	assert.Equal(t, 2, handler.Query.Page)
+	rest, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, "{not json", string(rest))
+}
+
+func TestBindRequestShouldNotParseFormWithoutBodyField(t *testing.T) {
+	var handler struct{}
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("a=1"))
+	req.Header.Set("content-type", contentTypeFormEncoded)
+	c := NewContext(req, httptest.NewRecorder(), nil)
+
+	require.NoError(t, c.Bind(&handler))
+	assert.Nil(t, req.PostForm)
+}
+
+func TestBindRequestShouldRequireContentTypeWithBodyField(t *testing.T) {
+	var handler struct {
+		Body struct {
+			Name string
+		}
+	}
+	c := NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder(), nil)
+
+	err := c.Bind(&handler)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.(HTTPError).Status())
+}
+
+type layoutBase struct {
+	Body struct {
+		Name string `json:"name"`
+	} `boar:"novalidate"`
+}
+
+func TestLayoutOfShouldFindPromotedFields(t *testing.T) {
+	var handler struct {
+		layoutBase
+		User string `ctx:"user"`
+		Skip string `ctx:"-"`
+	}
+	l := layoutOf(reflect.TypeOf(handler))
+
+	assert.True(t, l.has(bodyField))
+	assert.False(t, l.has(queryField))
+	assert.True(t, l.fields[bodyField].noValidate)
+	assert.Equal(t, []ctxField{{index: 1, name: "User", key: "user"}}, l.ctxFields)
+}
+
+func TestLayoutOfShouldBeCached(t *testing.T) {
+	typ := reflect.TypeOf(layoutBase{})
+	assert.True(t, layoutOf(typ) == layoutOf(typ))
+}
+
+func TestFieldOfShouldReturnZeroValueForMissingFields(t *testing.T) {
+	var handler layoutBase
+	v := reflect.ValueOf(&handler).Elem()
+
+	assert.False(t, fieldOf(v, queryField).IsValid())
+	assert.False(t, fieldOf(reflect.ValueOf(1), queryField).IsValid())
+	fieldOf(v, bodyField).Field(0).SetString("brett")
+	assert.Equal(t, "brett", handler.Body.Name)
+}
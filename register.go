@@ -0,0 +1,126 @@
+package boar
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// registerMethods are the HTTP methods recognized as prefixes of method names by Register
+var registerMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions,
+}
+
+// RouteTable can be implemented by controllers passed to Register to give routes, such as
+// "GET /users/:id", to methods by name rather than deriving them from the method names
+type RouteTable interface {
+	Routes() map[string]string
+}
+
+// Register adds a route for every exported method of controller which is either a
+// HandlerProviderFunc or a HandlerFunc and whose name begins with an HTTP method. The
+// path is derived from the rest of the name where each word is a path segment and the
+// word ID is the parameter :id. Routes of RouteTable take precedence over the names of
+// methods and methods which match neither are ignored.
+//
+// Example:
+//
+//	type UserController struct{}
+//
+//	func (UserController) GetUsers(c boar.Context) (boar.Handler, error)      // GET /users
+//	func (UserController) GetUsersID(c boar.Context) (boar.Handler, error)    // GET /users/:id
+//	func (UserController) PostUsersIDAvatar(c boar.Context) error              // POST /users/:id/avatar
+//
+//	rtr.Group("/api").Register(UserController{})
+func (rtr *Router) Register(controller interface{}) {
+	var table map[string]string
+	if rt, ok := controller.(RouteTable); ok {
+		table = rt.Routes()
+	}
+
+	val := reflect.ValueOf(controller)
+	typ := val.Type()
+	for name, route := range table {
+		if _, ok := typ.MethodByName(name); !ok {
+			panic(fmt.Sprintf("cannot register %T: no method %s for route %q", controller, name, route))
+		}
+	}
+
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+		var method, path string
+		if route, ok := table[name]; ok {
+			parts := strings.Fields(route)
+			if len(parts) != 2 {
+				panic(fmt.Sprintf("cannot register %T.%s: route %q must be a method and path", controller, name, route))
+			}
+			method, path = strings.ToUpper(parts[0]), parts[1]
+		} else if method, path = routeFromName(name); method == "" {
+			continue
+		}
+
+		switch fn := val.Method(i).Interface().(type) {
+		case func(Context) (Handler, error):
+			rtr.Method(method, path, fn)
+		case func(Context) error:
+			rtr.MethodFunc(method, path, fn)
+		default:
+			if _, ok := table[name]; ok {
+				panic(fmt.Sprintf("cannot register %T.%s: %s is not a HandlerProviderFunc or HandlerFunc", controller, name, typ.Method(i).Type))
+			}
+		}
+	}
+}
+
+// routeFromName returns the HTTP method and path for a method name such as GetUsersID
+// or empty strings when the name does not begin with an HTTP method
+func routeFromName(name string) (string, string) {
+	for _, method := range registerMethods {
+		prefix := method[:1] + strings.ToLower(method[1:])
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := name[len(prefix):]
+		if rest != "" && !unicode.IsUpper(rune(rest[0])) {
+			continue
+		}
+		segments := []string{""}
+		for _, word := range splitWords(rest) {
+			if word == "ID" {
+				segments = append(segments, ":id")
+				continue
+			}
+			segments = append(segments, strings.ToLower(word))
+		}
+		if len(segments) == 1 {
+			return method, "/"
+		}
+		return method, strings.Join(segments, "/")
+	}
+	return "", ""
+}
+
+// splitWords splits a camel case name into its words keeping acronyms such as ID and
+// HTTP together
+func splitWords(s string) []string {
+	var words []string
+	start := 0
+	for i := 1; i < len(s); i++ {
+		prevUpper := unicode.IsUpper(rune(s[i-1]))
+		if !unicode.IsUpper(rune(s[i])) {
+			continue
+		}
+		nextLower := i+1 < len(s) && !unicode.IsUpper(rune(s[i+1]))
+		if !prevUpper || nextLower {
+			words = append(words, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
@@ -0,0 +1,110 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteFromName(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{"GetUsers", http.MethodGet, "/users"},
+		{"GetUsersID", http.MethodGet, "/users/:id"},
+		{"PostUsersIDAvatar", http.MethodPost, "/users/:id/avatar"},
+		{"DeleteUsersID", http.MethodDelete, "/users/:id"},
+		{"PatchV2HTTPLogs", http.MethodPatch, "/v2/http/logs"},
+		{"Get", http.MethodGet, "/"},
+		{"Getaway", "", ""},
+		{"ListUsers", "", ""},
+	}
+	for _, tt := range tests {
+		method, path := routeFromName(tt.name)
+		assert.Equal(t, tt.method, method, tt.name)
+		assert.Equal(t, tt.path, path, tt.name)
+	}
+}
+
+type registerController struct{}
+
+func (registerController) GetUsers(c Context) error {
+	return c.WriteString(http.StatusOK, "list")
+}
+
+func (registerController) GetUsersID(c Context) (Handler, error) {
+	return &simpleHandler{handle: func(c Context) error {
+		return c.WriteString(http.StatusOK, "show "+c.URLParams().ByName("id"))
+	}}, nil
+}
+
+func (registerController) PutUsersID(c Context) error {
+	return c.WriteString(http.StatusOK, "update "+c.URLParams().ByName("id"))
+}
+
+func (registerController) Search(c Context) error {
+	return c.WriteString(http.StatusOK, "search")
+}
+
+func (registerController) GetHelper() string { return "ignored" }
+
+func (registerController) Routes() map[string]string {
+	return map[string]string{
+		"PutUsersID": "patch /users/:id",
+		"Search":     "GET /search",
+	}
+}
+
+func TestRegisterShouldAddRoutesForMethods(t *testing.T) {
+	r := NewRouter()
+	r.Group("/api").Register(registerController{})
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{http.MethodGet, "/api/users", http.StatusOK, "list"},
+		{http.MethodGet, "/api/users/7", http.StatusOK, "show 7"},
+		{http.MethodPatch, "/api/users/7", http.StatusOK, "update 7"},
+		{http.MethodPut, "/api/users/7", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/api/search", http.StatusOK, "search"},
+		{http.MethodGet, "/api/helper", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.code, rec.Code, tt.method+" "+tt.path)
+		assert.Equal(t, tt.body, rec.Body.String(), tt.method+" "+tt.path)
+	}
+}
+
+type badRouteController struct{}
+
+func (badRouteController) Helper() string { return "" }
+
+func (badRouteController) Routes() map[string]string {
+	return map[string]string{"Missing": "GET /missing"}
+}
+
+type badSignatureController struct{}
+
+func (badSignatureController) Helper() string { return "" }
+
+func (badSignatureController) Routes() map[string]string {
+	return map[string]string{"Helper": "GET /helper"}
+}
+
+func TestRegisterShouldPanicWithInvalidRoutes(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouter().Register(badRouteController{})
+	})
+	assert.Panics(t, func() {
+		NewRouter().Register(badSignatureController{})
+	})
+}
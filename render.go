@@ -0,0 +1,89 @@
+package boar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+)
+
+var errNoRenderer = errors.New("no Renderer has been set on the Router")
+
+// Renderer renders named templates for Context.Render
+type Renderer interface {
+	Render(w io.Writer, name string, data interface{}) error
+}
+
+var _ Renderer = (*HTMLRenderer)(nil)
+
+// HTMLRenderer is a Renderer for html/template pages which share layouts and partials
+type HTMLRenderer struct {
+	templates map[string]*template.Template
+}
+
+// NewHTMLRenderer parses every page matching pageGlob into its own template set alongside
+// all layouts and partials matching sharedGlobs. Parsing each page separately allows every
+// page to define the same blocks (e.g. "content") for use by a shared layout. Pages are
+// rendered by their file name.
+//
+// Example:
+//
+//	// pages/index.html: {{template "layout" .}}{{define "content"}}Hello{{end}}
+//	// layouts/layout.html: {{define "layout"}}<html>{{template "content" .}}</html>{{end}}
+//	renderer, err := NewHTMLRenderer(nil, "pages/*.html", "layouts/*.html", "partials/*.html")
+//	rtr.Renderer = renderer
+//	...
+//	return c.Render(http.StatusOK, "index.html", data)
+func NewHTMLRenderer(funcs template.FuncMap, pageGlob string, sharedGlobs ...string) (*HTMLRenderer, error) {
+	pages, err := filepath.Glob(pageGlob)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("no templates match %q", pageGlob)
+	}
+
+	var shared []string
+	for _, glob := range sharedGlobs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, err
+		}
+		shared = append(shared, matches...)
+	}
+
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		name := filepath.Base(page)
+		files := append([]string{page}, shared...)
+		tmpl, err := template.New(name).Funcs(funcs).ParseFiles(files...)
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = tmpl
+	}
+
+	return &HTMLRenderer{templates: templates}, nil
+}
+
+// Render executes the page template with the given name
+func (h *HTMLRenderer) Render(w io.Writer, name string, data interface{}) error {
+	tmpl, ok := h.templates[name]
+	if !ok {
+		return fmt.Errorf("template %q does not exist", name)
+	}
+	return tmpl.Execute(w, data)
+}
+
+func (r *requestContext) Render(status int, name string, data interface{}) error {
+	if r.router == nil || r.router.Renderer == nil {
+		return errNoRenderer
+	}
+	var buf bytes.Buffer
+	if err := r.router.Renderer.Render(&buf, name, data); err != nil {
+		return fmt.Errorf("could not render template %q: %+v", name, err)
+	}
+	return r.Write(status, contentTypeHTML, buf.Bytes())
+}
@@ -0,0 +1,83 @@
+package boar
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplates(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "boar-templates")
+	require.NoError(t, err)
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	return dir
+}
+
+func TestHTMLRendererRendersPagesWithLayout(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"pages/index.html":     `{{template "layout" .}}{{define "content"}}Hello {{.}}{{end}}`,
+		"pages/about.html":     `{{template "layout" .}}{{define "content"}}{{template "nav"}}About{{end}}`,
+		"layouts/layout.html":  `{{define "layout"}}<p>{{template "content" .}}</p>{{end}}`,
+		"partials/nav.html":    `{{define "nav"}}<nav></nav>{{end}}`,
+		"partials/unused.html": `{{define "unused"}}{{end}}`,
+	})
+	defer os.RemoveAll(dir)
+
+	renderer, err := NewHTMLRenderer(nil, filepath.Join(dir, "pages/*.html"),
+		filepath.Join(dir, "layouts/*.html"), filepath.Join(dir, "partials/*.html"))
+	require.NoError(t, err)
+
+	r := NewRouter()
+	r.Renderer = renderer
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.Render(http.StatusOK, "index.html", "<brett>")
+	})
+	r.MethodFunc(http.MethodGet, "/about", func(c Context) error {
+		return c.Render(http.StatusOK, "about.html", nil)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "<p>Hello &lt;brett&gt;</p>", rec.Body.String())
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("content-type"))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/about", nil))
+	assert.Equal(t, "<p><nav></nav>About</p>", rec.Body.String())
+}
+
+func TestNewHTMLRendererErrorsWhenNoPagesMatch(t *testing.T) {
+	_, err := NewHTMLRenderer(nil, "/does/not/exist/*.html")
+	assert.Error(t, err)
+}
+
+func TestRenderReturnsErrorWithoutRenderer(t *testing.T) {
+	c := newContext(nil, httptest.NewRecorder(), nil)
+	assert.Equal(t, errNoRenderer, c.Render(http.StatusOK, "index.html", nil))
+}
+
+func TestRenderDoesNotWriteWhenTemplateFails(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"index.html": `{{.Missing.Field}}`,
+	})
+	defer os.RemoveAll(dir)
+
+	renderer, err := NewHTMLRenderer(nil, filepath.Join(dir, "*.html"))
+	require.NoError(t, err)
+
+	c := newContext(nil, httptest.NewRecorder(), nil)
+	c.router = &Router{Renderer: renderer}
+	assert.Error(t, c.Render(http.StatusOK, "index.html", 1))
+	assert.Equal(t, 0, c.Response().Len())
+	assert.Error(t, c.Render(http.StatusOK, "missing.html", 1))
+}
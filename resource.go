@@ -0,0 +1,104 @@
+package boar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultResourceParam is the name of the URL parameter of a resource's member routes
+// unless its controller implements ResourceParam
+const DefaultResourceParam = "id"
+
+// ResourceIndexer is implemented by resource controllers which list the resource
+type ResourceIndexer interface {
+	Index(Context) (Handler, error)
+}
+
+// ResourceShower is implemented by resource controllers which show a member of the
+// resource
+type ResourceShower interface {
+	Show(Context) (Handler, error)
+}
+
+// ResourceCreator is implemented by resource controllers which create members of the
+// resource
+type ResourceCreator interface {
+	Create(Context) (Handler, error)
+}
+
+// ResourceUpdater is implemented by resource controllers which update members of the
+// resource
+type ResourceUpdater interface {
+	Update(Context) (Handler, error)
+}
+
+// ResourceDeleter is implemented by resource controllers which delete members of the
+// resource
+type ResourceDeleter interface {
+	Delete(Context) (Handler, error)
+}
+
+// ResourceParam is implemented by resource controllers to name the URL parameter of their
+// member routes. It is needed for nested resources so that the parameters of the parent
+// and child resources do not collide
+type ResourceParam interface {
+	ResourceParam() string
+}
+
+// Resource registers the standard REST routes for the methods controller implements.
+// Each method is a HandlerProviderFunc:
+//
+//	GET    path        Index
+//	POST   path        Create
+//	GET    path/:id    Show
+//	PUT    path/:id    Update
+//	PATCH  path/:id    Update
+//	DELETE path/:id    Delete
+//
+// mw are added to every route of the resource. The returned Router is the group of the
+// member routes, path/:id, which nested resources are registered with. Resource panics
+// when controller implements none of the methods.
+//
+// Example:
+//
+//	users := rtr.Resource("/users", &UserController{db: db})
+//	users.Resource("/posts", &PostController{db: db})
+//
+// registers /users, /users/:user_id, /users/:user_id/posts, and
+// /users/:user_id/posts/:id when UserController returns "user_id" from ResourceParam
+func (rtr *Router) Resource(path string, controller interface{}, mw ...Middleware) *Router {
+	param := DefaultResourceParam
+	if p, ok := controller.(ResourceParam); ok {
+		param = p.ResourceParam()
+	}
+	path = strings.TrimSuffix(path, "/")
+	collection := rtr.Group(path, mw...)
+	member := collection.Group("/:" + param)
+
+	registered := false
+	if c, ok := controller.(ResourceIndexer); ok {
+		collection.Get("", c.Index)
+		registered = true
+	}
+	if c, ok := controller.(ResourceCreator); ok {
+		collection.Post("", c.Create)
+		registered = true
+	}
+	if c, ok := controller.(ResourceShower); ok {
+		member.Get("", c.Show)
+		registered = true
+	}
+	if c, ok := controller.(ResourceUpdater); ok {
+		member.Put("", c.Update)
+		member.Patch("", c.Update)
+		registered = true
+	}
+	if c, ok := controller.(ResourceDeleter); ok {
+		member.Delete("", c.Delete)
+		registered = true
+	}
+	if !registered {
+		panic(fmt.Sprintf("resource %q: %T does not implement Index, Show, Create, Update, or Delete", path, controller))
+	}
+	return member
+}
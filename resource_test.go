@@ -0,0 +1,91 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type resourceController struct {
+	param string
+}
+
+func (rc *resourceController) handler(action string) (Handler, error) {
+	return &simpleHandler{handle: func(c Context) error {
+		return c.WriteString(http.StatusOK, action+" "+c.URLParams().ByName(rc.ResourceParam()))
+	}}, nil
+}
+
+func (rc *resourceController) ResourceParam() string           { return rc.param }
+func (rc *resourceController) Index(Context) (Handler, error)  { return rc.handler("index") }
+func (rc *resourceController) Show(Context) (Handler, error)   { return rc.handler("show") }
+func (rc *resourceController) Create(Context) (Handler, error) { return rc.handler("create") }
+func (rc *resourceController) Update(Context) (Handler, error) { return rc.handler("update") }
+func (rc *resourceController) Delete(Context) (Handler, error) { return rc.handler("delete") }
+
+type readOnlyController struct{}
+
+func (readOnlyController) Index(Context) (Handler, error) {
+	return &simpleHandler{handle: func(c Context) error {
+		return c.WriteString(http.StatusOK, "index")
+	}}, nil
+}
+
+func TestResourceShouldRegisterRESTRoutes(t *testing.T) {
+	r := NewRouter()
+	users := r.Resource("/users", &resourceController{param: "user_id"})
+	users.Resource("/posts/", &resourceController{param: "id"})
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/users", "index "},
+		{http.MethodPost, "/users", "create "},
+		{http.MethodGet, "/users/1", "show 1"},
+		{http.MethodPut, "/users/1", "update 1"},
+		{http.MethodPatch, "/users/1", "update 1"},
+		{http.MethodDelete, "/users/1", "delete 1"},
+		{http.MethodGet, "/users/1/posts", "index "},
+		{http.MethodGet, "/users/1/posts/2", "show 2"},
+		{http.MethodDelete, "/users/1/posts/2", "delete 2"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, tt.method+" "+tt.path)
+		assert.Equal(t, tt.body, rec.Body.String(), tt.method+" "+tt.path)
+	}
+}
+
+func TestResourceShouldOnlyRegisterImplementedRoutes(t *testing.T) {
+	r := NewRouter()
+	r.Resource("/reports", readOnlyController{}, func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			c.Response().Header().Set("X-Resource", "reports")
+			return next(c)
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reports", nil))
+	assert.Equal(t, "index", rec.Body.String())
+	assert.Equal(t, "reports", rec.Header().Get("X-Resource"))
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reports/1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/reports", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestResourceShouldPanicWithoutActions(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouter().Resource("/nothing", struct{}{})
+	})
+}
@@ -1,8 +1,11 @@
 package boar
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"sync"
 )
@@ -14,46 +17,149 @@ type ResponseWriter interface {
 	io.Closer
 
 	Flush() error
+	// Status returns the status code written by the handler or zero if no status or
+	// body has been written
 	Status() int
 	Len() int
+	// BytesWritten returns the amount of body bytes written by the handler whether or
+	// not they have been flushed to the client
+	BytesWritten() int
 }
 
-var _ ResponseWriter = (*BufferedResponseWriter)(nil)
+var errResponseSent = errors.New("the response has already been sent")
+
+var (
+	// ResponseBufferLimit is the size at which a buffered response is sent to the client
+	// and the rest of it is streamed so that very large responses are not held in memory.
+	// Headers cannot be changed, and middlewares such as compression and ETags are skipped,
+	// once a response has been sent. Zero disables the limit. Default is 8MB
+	ResponseBufferLimit = 8 << 20
+	// MaxPooledBufferSize is the capacity above which response buffers are dropped rather
+	// than reused so that an occasional large response does not stay in memory.
+	// Default is 64KB
+	MaxPooledBufferSize = 64 << 10
+)
+
+// bufferPool holds the buffers of responses which have been sent
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &bytes.Buffer{}
+	},
+}
+
+// emptyBody is the body of writers which have not buffered anything yet or which have
+// returned their buffer to bufferPool. It is shared and must never be written
+var emptyBody = &bytes.Buffer{}
+
+func putBuffer(b *bytes.Buffer) {
+	if b == emptyBody || b.Cap() > MaxPooledBufferSize {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
+
+var (
+	_ ResponseWriter = (*BufferedResponseWriter)(nil)
+	_ http.Hijacker  = (*BufferedResponseWriter)(nil)
+	_ http.Pusher    = (*BufferedResponseWriter)(nil)
+)
 
 // BufferedResponseWriter is an http.ResponseWriter that captures the status code and body
 // written for retrieval after the response has been sent
 type BufferedResponseWriter struct {
-	base      http.ResponseWriter
-	m         *sync.RWMutex
-	body      *bytes.Buffer
-	status    int
-	flushOnce *sync.Once
+	base   http.ResponseWriter
+	m      *sync.RWMutex
+	body   *bytes.Buffer
+	status int
+	sent   bool
+	// unbuffered writes are sent directly to the client. See Unbuffer
+	unbuffered bool
+	// hijacked is set when the connection has been taken over, e.g. by a websocket
+	hijacked bool
+	// flushed is the amount of bytes that have been sent to base
+	flushed int
 }
 
 // NewBufferedResponseWriter creates a new BufferedResponseWriter
 func NewBufferedResponseWriter(base http.ResponseWriter) *BufferedResponseWriter {
 	return &BufferedResponseWriter{
-		base:      base,
-		m:         &sync.RWMutex{},
-		body:      bytes.NewBufferString(""),
-		status:    0,
-		flushOnce: &sync.Once{},
+		base:   base,
+		m:      &sync.RWMutex{},
+		body:   emptyBody,
+		status: 0,
 	}
 }
 
+// buffer returns the body for writing. Buffers are taken from bufferPool when something
+// is first written so that responses without a body never use one
+func (w *BufferedResponseWriter) buffer() *bytes.Buffer {
+	if w.body == emptyBody {
+		w.body = bufferPool.Get().(*bytes.Buffer)
+	}
+	return w.body
+}
+
+// releaseBuffer returns the body to bufferPool once it has been sent
+func (w *BufferedResponseWriter) releaseBuffer() {
+	putBuffer(w.body)
+	w.body = emptyBody
+}
+
+// reset prepares w to buffer the response of another request to base
+func (w *BufferedResponseWriter) reset(base http.ResponseWriter) {
+	w.base = base
+	w.releaseBuffer()
+	w.status = 0
+	w.sent = false
+	w.unbuffered = false
+	w.hijacked = false
+	w.flushed = 0
+}
+
 // Flush flushes the buffer into the write stream and sends the body to the client
-// Once flush has been called, there can be no more headers or anything sent to the client
+// Once flush has been called, there can be no more headers sent to the client and
+// subsequent writes are sent directly to the client rather than being buffered.
 //
 // Flush is called internally by the Router once all middlewares, handlers, and error handlers
 // have completely executed. This allows the middlewares access to writing headers, reading
 // contents, etc.
-func (w *BufferedResponseWriter) Flush() (err error) {
-	w.m.RLock()
-	defer w.m.RUnlock()
-	w.flushOnce.Do(func() {
-		w.base.WriteHeader(w.Status())
-		_, err = w.body.WriteTo(w.base)
-	})
+func (w *BufferedResponseWriter) Flush() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.flush()
+}
+
+func (w *BufferedResponseWriter) flushBase() {
+	if f, ok := w.base.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *BufferedResponseWriter) flush() error {
+	if w.hijacked {
+		return nil
+	}
+	if w.sent {
+		// data written after the first flush may be waiting in base
+		w.flushBase()
+		return nil
+	}
+
+	w.sent = true
+	// a handler that never writes a status or body should still respond
+	// with http.StatusOK just as the default http.ResponseWriter would
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.base.WriteHeader(w.status)
+	if w.body == emptyBody {
+		return nil
+	}
+	n, err := w.body.WriteTo(w.base)
+	w.flushed += int(n)
+	// everything written from now on is sent to base
+	w.releaseBuffer()
 	return err
 }
 
@@ -65,14 +171,22 @@ func (w *BufferedResponseWriter) Close() error {
 
 // Status returns the currently set HTTP status code
 func (w *BufferedResponseWriter) Status() int {
+	w.m.RLock()
+	defer w.m.RUnlock()
 	return w.status
 }
 
+// BytesWritten returns the amount of body bytes that have been written so far. It
+// allows logging and metrics middleware to record the size of the response
+func (w *BufferedResponseWriter) BytesWritten() int {
+	return w.Len()
+}
+
 // Len returns the amount of bytes that have been written so far
 func (w *BufferedResponseWriter) Len() int {
 	w.m.RLock()
 	defer w.m.RUnlock()
-	return w.body.Len()
+	return w.body.Len() + w.flushed
 }
 
 // Header returns the header map that will be sent by WriteHeader. The Header map
@@ -94,12 +208,120 @@ func (w *BufferedResponseWriter) Write(b []byte) (n int, err error) {
 	if w.status == 0 {
 		w.status = http.StatusOK
 	}
-	return w.body.Write(b)
+	if w.unbuffered && !w.sent {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	if w.sent {
+		n, err = w.base.Write(b)
+		w.flushed += n
+		if w.unbuffered {
+			w.flushBase()
+		}
+		return n, err
+	}
+	n, err = w.buffer().Write(b)
+	if ResponseBufferLimit > 0 && w.body.Len() > ResponseBufferLimit {
+		// send what has been buffered and stream the rest of a very large response
+		if ferr := w.flush(); ferr != nil {
+			return n, ferr
+		}
+	}
+	return n, err
+}
+
+// Body returns the buffered body which has not been flushed to the client. Middleware can
+// use it, along with SetBody, to transform a response before it is sent. The returned
+// slice must not be used after the response is flushed since its buffer is reused
+func (w *BufferedResponseWriter) Body() []byte {
+	w.m.RLock()
+	defer w.m.RUnlock()
+	return w.body.Bytes()
+}
+
+// SetBody replaces the buffered body with b. An error is returned when the response has
+// already been sent to the client and can no longer be changed
+func (w *BufferedResponseWriter) SetBody(b []byte) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.sent || w.hijacked {
+		return errResponseSent
+	}
+	buf := w.buffer()
+	buf.Reset()
+	buf.Write(b)
+	return nil
+}
+
+// Unbuffer switches the writer to streaming mode. The status code and headers are sent
+// to the client by the next call to WriteHeader or Write, and every write is sent to
+// the client immediately. Anything that has already been buffered is sent first.
+// Middleware cannot change the response once it has been sent.
+func (w *BufferedResponseWriter) Unbuffer() {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.unbuffered = true
+}
+
+// Hijack takes over the connection of the underlying http.ResponseWriter. Nothing is
+// flushed to the connection once it has been hijacked. http.ErrNotSupported is returned
+// when the underlying writer is not an http.Hijacker
+func (w *BufferedResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.base.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	w.setHijacked()
+	return conn, rw, nil
+}
+
+// Push initiates an HTTP/2 server push using the underlying http.ResponseWriter.
+// http.ErrNotSupported is returned when the underlying writer is not an http.Pusher
+func (w *BufferedResponseWriter) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.base.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
+
+// Unwrap returns the underlying http.ResponseWriter so that http.ResponseController can
+// reach the features of the original writer. Because Flush returns an error the
+// BufferedResponseWriter cannot be an http.Flusher; http.NewResponseController(w).Flush()
+// can be used in its place once the buffered response has been flushed
+func (w *BufferedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.base
+}
+
+func (w *BufferedResponseWriter) setHijacked() {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.hijacked = true
+}
+
+func (w *BufferedResponseWriter) isHijacked() bool {
+	w.m.RLock()
+	defer w.m.RUnlock()
+	return w.hijacked
 }
 
 // WriteHeader sets the http status code. Unlike the default http.ResponseWriter, this does
 // _not_ begin the response transaction. This will simply store the status code until Flush
 // is executed
 func (w *BufferedResponseWriter) WriteHeader(status int) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.sent {
+		return
+	}
 	w.status = status
+	if w.unbuffered {
+		w.flush()
+		w.flushBase()
+	}
 }
@@ -1,10 +1,12 @@
 package boar
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -83,7 +85,7 @@ func TestBodyReturnsWrittenBytes(t *testing.T) {
 	w := NewBufferedResponseWriter(rec)
 
 	exp := "kajshdfalsdf"
-	fmt.Fprintf(w, exp)
+	fmt.Fprint(w, exp)
 
 	body, err := ioutil.ReadAll(w.body)
 	require.NoError(t, err)
@@ -129,3 +131,142 @@ func TestHeaderSetsHeaders(t *testing.T) {
 	val := rec.Result().Header.Get("hello")
 	assert.Equal(t, "world", val)
 }
+
+func TestWriteAfterFlushGoesDirectlyToBase(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := NewBufferedResponseWriter(rec)
+
+	w.Write([]byte("hello"))
+	require.NoError(t, w.Flush())
+	w.Write([]byte(" world"))
+
+	assert.Equal(t, "hello world", rec.Body.String())
+	assert.Equal(t, len("hello world"), w.Len())
+	assert.Equal(t, 0, w.body.Len())
+}
+
+func TestStatusAndBytesWrittenAreVisibleToMiddleware(t *testing.T) {
+	r := NewRouter()
+	var status, written int
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			err := next(c)
+			status, written = c.Response().Status(), c.Response().BytesWritten()
+			return err
+		}
+	})
+	r.MethodFunc(http.MethodPost, "/", func(c Context) error {
+		return c.WriteString(http.StatusCreated, "created")
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, len("created"), written)
+}
+
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (h *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return h.conn, bufio.NewReadWriter(bufio.NewReader(h.conn), bufio.NewWriter(h.conn)), nil
+}
+
+func TestHijackShouldDelegateAndStopFlushing(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server}
+	w := NewBufferedResponseWriter(rec)
+
+	conn, _, err := w.Hijack()
+	require.NoError(t, err)
+	assert.Equal(t, server, conn)
+
+	w.Write([]byte("ignored"))
+	require.NoError(t, w.Flush())
+	assert.False(t, rec.Flushed)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestHijackAndPushShouldReturnErrNotSupported(t *testing.T) {
+	w := NewBufferedResponseWriter(httptest.NewRecorder())
+
+	_, _, err := w.Hijack()
+	assert.Equal(t, http.ErrNotSupported, err)
+	assert.Equal(t, http.ErrNotSupported, w.Push("/app.js", nil))
+}
+
+func TestUnwrapShouldReturnTheBaseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	assert.Equal(t, rec, NewBufferedResponseWriter(rec).Unwrap())
+}
+
+func TestSetBodyShouldReplaceTheBufferedBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := NewBufferedResponseWriter(rec)
+
+	w.Write([]byte("hello"))
+	assert.Equal(t, "hello", string(w.Body()))
+	require.NoError(t, w.SetBody([]byte("goodbye")))
+	assert.Equal(t, len("goodbye"), w.Len())
+
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "goodbye", rec.Body.String())
+	assert.Equal(t, errResponseSent, w.SetBody([]byte("too late")))
+}
+
+func TestWriteShouldStreamResponsesOverBufferLimit(t *testing.T) {
+	defer func(limit int) { ResponseBufferLimit = limit }(ResponseBufferLimit)
+	ResponseBufferLimit = 10
+	rec := httptest.NewRecorder()
+	w := NewBufferedResponseWriter(rec)
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte("hello "))
+	assert.Equal(t, 0, rec.Body.Len(), "responses under the limit should be buffered")
+
+	w.Write([]byte("world"))
+	assert.Equal(t, "hello world", rec.Body.String())
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, errResponseSent, w.SetBody(nil))
+
+	w.Write([]byte("!"))
+	assert.Equal(t, "hello world!", rec.Body.String())
+	assert.Equal(t, len("hello world!"), w.Len())
+}
+
+func TestFlushShouldReleaseBuffer(t *testing.T) {
+	w := NewBufferedResponseWriter(httptest.NewRecorder())
+	w.Write([]byte("hello"))
+	assert.NotEqual(t, emptyBody, w.body)
+
+	require.NoError(t, w.Flush())
+	assert.Equal(t, emptyBody, w.body)
+	assert.Empty(t, w.Body())
+}
+
+func TestResponsesWithoutBodyShouldNotTakeBuffer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := NewBufferedResponseWriter(rec)
+
+	w.WriteHeader(http.StatusNoContent)
+	assert.Empty(t, w.Body())
+	assert.Equal(t, 0, w.Len())
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, emptyBody, w.body)
+	assert.Equal(t, 0, emptyBody.Len())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestPutBufferShouldDropLargeBuffers(t *testing.T) {
+	large := bytes.NewBuffer(make([]byte, MaxPooledBufferSize+1))
+	putBuffer(large)
+	assert.Equal(t, MaxPooledBufferSize+1, large.Len(), "large buffers should not be reset for reuse")
+
+	small := bytes.NewBufferString("hello")
+	putBuffer(small)
+	assert.Equal(t, 0, small.Len())
+}
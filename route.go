@@ -0,0 +1,156 @@
+package boar
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// RouteInfo describes a registered route and the documentation it was annotated with
+type RouteInfo struct {
+	// Method is the HTTP method of the route
+	Method string
+	// Path is the path pattern of the route such as /users/:id
+	Path string
+	// Summary is a short description of the route
+	Summary string
+	// Description is a long description of the route
+	Description string
+	// Tags group the route with related routes in documentation
+	Tags []string
+	// Deprecated marks a route which should no longer be used
+	Deprecated bool
+	// Request is a value of the handler, or request struct, whose Query, URLParams,
+	// Header, and Body fields document the request of the route
+	Request interface{}
+	// Response is a value of the type written by the route
+	Response interface{}
+}
+
+// Route is a registered route. Its methods annotate the route with documentation which is
+// returned by Router.Routes.
+//
+// Example:
+//
+//	rtr.Get("/users/:id", getUser).
+//		Doc("Get a user", "Returns the user with the given id").
+//		Tags("users")
+type Route struct {
+	info RouteInfo
+	// hidden routes, such as those of ServeDocs, are not documented by OpenAPI
+	hidden bool
+	// router is the Router the route was registered with
+	router *Router
+	// handler is the name of the func the route was registered with
+	handler string
+	// serve serves the requests of the route
+	serve httprouter.Handle
+}
+
+// Doc sets the summary and description of the route
+func (r *Route) Doc(summary, description string) *Route {
+	r.info.Summary = summary
+	r.info.Description = description
+	return r
+}
+
+// Tags adds tags to the route
+func (r *Route) Tags(tags ...string) *Route {
+	r.info.Tags = append(r.info.Tags, tags...)
+	return r
+}
+
+// Deprecated marks the route as deprecated
+func (r *Route) Deprecated() *Route {
+	r.info.Deprecated = true
+	return r
+}
+
+// Hidden excludes the route from the OpenAPI and Postman documents of the Router
+func (r *Route) Hidden() *Route {
+	r.hidden = true
+	return r
+}
+
+// Request documents the request of the route with the Query, URLParams, Header, and Body
+// fields of v which is usually the route's Handler
+func (r *Route) Request(v interface{}) *Route {
+	r.info.Request = v
+	return r
+}
+
+// Response documents the type of the value written by the route
+func (r *Route) Response(v interface{}) *Route {
+	r.info.Response = v
+	return r
+}
+
+// Info returns the description of the route
+func (r *Route) Info() RouteInfo {
+	info := r.info
+	info.Tags = append([]string(nil), r.info.Tags...)
+	return info
+}
+
+// Routes returns the routes registered with rtr and every Router created from the same
+// NewRouter in the order they were registered
+func (rtr *Router) Routes() []RouteInfo {
+	routes := rtr.root().routes
+	infos := make([]RouteInfo, len(routes))
+	for i, r := range routes {
+		infos[i] = r.Info()
+	}
+	return infos
+}
+
+// PrintRoutes writes a table of the method, path, handler, and number of middlewares of
+// every route registered with rtr and every Router created from the same NewRouter. The
+// handler is the type of the Route's Request when it is a Handler, otherwise the name of
+// the func the route was registered with.
+//
+// Example:
+//
+//	if *debug {
+//		rtr.PrintRoutes(os.Stdout)
+//	}
+func (rtr *Router) PrintRoutes(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tHANDLER\tMIDDLEWARES")
+	for _, r := range rtr.root().routes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", r.info.Method, r.info.Path, r.handlerName(), r.router.middlewareCount())
+	}
+	return tw.Flush()
+}
+
+func (r *Route) handlerName() string {
+	if h, ok := r.info.Request.(Handler); ok {
+		return fmt.Sprintf("%T", h)
+	}
+	return r.handler
+}
+
+// middlewareCount is the number of middlewares which wrap the routes of rtr
+func (rtr *Router) middlewareCount() int {
+	n := 0
+	for r := rtr; r != nil; r = r.parent {
+		n += len(r.before) + len(r.middlewares) + len(r.after)
+	}
+	return n
+}
+
+// funcName is the name of fn without its package path such as boar.getUser
+func funcName(fn interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func (rtr *Router) addRoute(method, path string) *Route {
+	r := &Route{info: RouteInfo{Method: method, Path: path}}
+	rtr.routes = append(rtr.routes, r)
+	return r
+}
@@ -0,0 +1,68 @@
+package boar
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutesShouldListAnnotatedRoutes(t *testing.T) {
+	r := NewRouter()
+	api := r.Group("/api")
+	api.Get("/users/:id", func(Context) (Handler, error) { return &nopHandler{}, nil }).
+		Doc("Get a user", "Returns the user with the given id").
+		Tags("users")
+	api.MethodFunc(http.MethodDelete, "/users/:id", func(Context) error { return nil }).
+		Tags("users", "admin").
+		Deprecated()
+
+	assert.Equal(t, []RouteInfo{
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/users/:id",
+			Summary:     "Get a user",
+			Description: "Returns the user with the given id",
+			Tags:        []string{"users"},
+		},
+		{
+			Method:     http.MethodDelete,
+			Path:       "/api/users/:id",
+			Tags:       []string{"users", "admin"},
+			Deprecated: true,
+		},
+	}, api.Routes())
+}
+
+func TestRouteInfoShouldBeACopy(t *testing.T) {
+	r := NewRouter()
+	route := r.MethodFunc(http.MethodGet, "/", func(Context) error { return nil }).Tags("a")
+
+	info := route.Info()
+	info.Tags[0] = "b"
+	assert.Equal(t, []string{"a"}, r.Routes()[0].Tags)
+}
+
+func listUsers(Context) error { return nil }
+
+func nopMiddleware(next HandlerFunc) HandlerFunc { return next }
+
+func TestPrintRoutesShouldWriteAlignedTable(t *testing.T) {
+	r := NewRouter()
+	r.Use(nopMiddleware)
+	api := r.Group("/api", nopMiddleware)
+	api.MethodFunc(http.MethodGet, "/users", listUsers)
+	api.Post("/users/:id", func(Context) (Handler, error) { return &nopHandler{}, nil }).
+		Request(&nopHandler{})
+	r.MethodFunc(http.MethodGet, "/", listUsers)
+
+	var buf bytes.Buffer
+	assert.NoError(t, r.PrintRoutes(&buf))
+	assert.Equal(t, ""+
+		"METHOD  PATH            HANDLER           MIDDLEWARES\n"+
+		"GET     /api/users      boar.listUsers    2\n"+
+		"POST    /api/users/:id  *boar.nopHandler  2\n"+
+		"GET     /               boar.listUsers    1\n",
+		buf.String())
+}
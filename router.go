@@ -1,10 +1,16 @@
 package boar
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"reflect"
-	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -18,6 +24,9 @@ type Middleware func(HandlerFunc) HandlerFunc
 // HandlerFunc is a function that handles an HTTP request
 type HandlerFunc func(Context) error
 
+// HandlerFuncR is a handler func which returns the value to respond with. See MethodFuncR
+type HandlerFuncR func(Context) (interface{}, error)
+
 // HandlerProviderFunc is a prerequesite function that is used to generate handlers
 // this is valuable to use like a factory
 type HandlerProviderFunc func(Context) (Handler, error)
@@ -25,11 +34,74 @@ type HandlerProviderFunc func(Context) (Handler, error)
 // ErrorHandlerFunc is a func that handles errors returned by middlewares or handlers
 type ErrorHandlerFunc func(Context, error)
 
-// Handler is an http Handler
+// Handler is an http Handler. Handlers which have a Response field do not need to write
+// the response themselves. When Handle returns without writing a body the Response is
+// written with Context.Negotiate which makes Handle testable as a pure function.
+//
+// Fields are bound by name: the Query, URLParams, Header, and Body fields of a handler are
+// bound from the request without needing a tag. Query and URLParams must be structs. A
+// Header field is only bound when it is a struct so that existing fields such as a Header
+// http.Header which the handler sets itself are left alone. Tag a field with `boar:"-"`
+// when it has one of these names but should not be bound.
+//
+// Example:
+//
+//	type GetUserHandler struct {
+//		URLParams struct {
+//			ID int `url:"id"`
+//		}
+//		Response User
+//	}
+//
+//	func (h *GetUserHandler) Handle(c boar.Context) (err error) {
+//		h.Response, err = h.users.Find(c.Context(), h.URLParams.ID)
+//		return err
+//	}
 type Handler interface {
 	Handle(Context) error
 }
 
+// BeforeBinder can be implemented by Handlers to run before the request is bound to their
+// Query, URLParams, Header, and Body fields. It is useful for setting defaults of fields
+// which are not present in the request. Binding and Handle are skipped when it returns an
+// error
+type BeforeBinder interface {
+	BeforeBind(Context) error
+}
+
+// AfterBinder can be implemented by Handlers to run after the request has been bound and
+// validated but before Handle. It is useful for loading resources referenced by the
+// request such as the entity of a URL parameter. Handle is skipped when it returns an
+// error
+type AfterBinder interface {
+	AfterBind(Context) error
+}
+
+// QueryBinder can be implemented by Handlers to bind their Query field without reflection.
+// It is usually generated by cmd/boarbind. The Query field is validated after it is bound
+// just as it is for the reflective binder
+type QueryBinder interface {
+	BindQuery(q url.Values) error
+}
+
+// URLParamsBinder can be implemented by Handlers to bind their URLParams field without
+// reflection. It is usually generated by cmd/boarbind. See QueryBinder
+type URLParamsBinder interface {
+	BindURLParams(params httprouter.Params) error
+}
+
+// Deadliner can be implemented by Handlers to limit the time they take. The context of
+// the request is given a timeout of the returned duration before the request is bound so
+// that the timeout policy is kept next to the handler that needs it. Durations which are
+// not positive are ignored.
+//
+// Example:
+//
+//	func (*ReportHandler) Deadline() time.Duration { return 30 * time.Second }
+type Deadliner interface {
+	Deadline() time.Duration
+}
+
 var defaultErrorHandler = func(c Context, err error) {
 	if err == nil {
 		return
@@ -38,12 +110,16 @@ var defaultErrorHandler = func(c Context, err error) {
 	httperr, ok := err.(HTTPError)
 	if !ok {
 		httperr = NewHTTPError(http.StatusInternalServerError, err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			httperr = NewHTTPError(http.StatusServiceUnavailable, err)
+		}
 	}
 
 	if c.Response().Len() == 0 {
-		werr := c.WriteJSON(httperr.Status(), httperr)
+		writeErrorHeaders(c, httperr)
+		werr := writeError(c, exposeError(c, httperr))
 		if werr != nil {
-			log.Printf("ERROR: unable to serialize JSON to response: %s", werr)
+			c.Logger().Error("unable to write error to response", "error", werr)
 		}
 	}
 
@@ -51,18 +127,8 @@ var defaultErrorHandler = func(c Context, err error) {
 }
 
 // PanicMiddleware recovers from panics happening in http handlers and returns the error
-// to be received by the normal middleware chain
-var PanicMiddleware Middleware = func(next HandlerFunc) HandlerFunc {
-	return func(c Context) (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				err = NewPanicError(r, debug.Stack())
-			}
-		}()
-		err = next(c)
-		return
-	}
-}
+// to be received by the normal middleware chain. See RecoverWithConfig for options
+var PanicMiddleware = RecoverWithConfig(RecoverConfig{})
 
 // NewRouterWithBase allows you to create a new http router with the provided
 //  httprouter.Router instead of the default httprouter.New()
@@ -89,12 +155,73 @@ func NewRouter() *Router {
 
 // Router is an http router
 type Router struct {
+	// middlewareVersion is incremented whenever a middleware is added so that the chains
+	// of routes registered before it are composed again. It is the first field so that it
+	// is 64-bit aligned for atomic operations on 32-bit platforms
+	middlewareVersion uint64
+
 	base        *httprouter.Router
+	parent      *Router
+	prefix      string
 	middlewares []Middleware
+	before      []Middleware
+	after       []Middleware
+
+	trailingSlash     TrailingSlash
+	trailingSlashCode int
+	errorHooks        []func(Context, error)
+	contextFactory    ContextFactory
+	routes            []*Route
+	dependencies      []reflect.Value
+	bases             []basePopulator
+
 	// ErrorHandler is a middleware that handles writing errors back to the client when an error
 	// an error occurs in the handler. It is the first middleware executed therefore It should
-	// always return the error that it handled
+	// always return the error that it handled. Routers created with Group or With use the
+	// ErrorHandler of their parent when it is nil or does not write a response
 	ErrorHandler ErrorHandlerFunc
+	// ValidateResponses validates values written with Context.WriteJSON against their
+	// validate tags before they are written. It is intended for development mode to
+	// catch handlers that write responses violating their own contract. Violations are
+	// logged and returned as errors from WriteJSON
+	ValidateResponses bool
+	// Renderer renders templates for Context.Render
+	Renderer Renderer
+	// DeferTimeout is the timeout of the context given to funcs of Context.Defer.
+	// DefaultDeferTimeout is used when it is zero
+	DeferTimeout time.Duration
+	// ResponseStatus is the status code used when a handler's Response field is written for
+	// it. StatusOK is used when it is zero. See Handler
+	ResponseStatus int
+	// ErrorMode controls how much of the cause of server errors is written to clients by
+	// the default ErrorHandler. See ErrorModeProduction and ErrorModeDebug
+	ErrorMode ErrorMode
+	// ErrorTemplate is the name of the template rendered with Renderer and an ErrorPage by
+	// the default ErrorHandler for clients which prefer text/html. Errors are written as
+	// JSON, XML, or plain text according to the Accept header otherwise
+	ErrorTemplate string
+	// ErrorTemplates are the names of templates rendered in place of ErrorTemplate for
+	// errors with a specific status such as "404" or a status class such as "5xx". An exact
+	// status takes precedence over its class which takes precedence over ErrorTemplate
+	ErrorTemplates map[string]string
+	// Upgrader upgrades requests to websocket connections for Context.Upgrade
+	Upgrader Upgrader
+	// Codec encodes and decodes JSON. encoding/json is used when it is nil
+	Codec Codec
+	// Logger logs errors of the Router, such as responses which could not be written and
+	// recovered panics, and is returned by Context.Logger. The standard logger is used
+	// when it is nil. *slog.Logger satisfies Logger
+	Logger Logger
+	// PoolContexts reuses the Contexts of requests, and their response buffers, to reduce
+	// allocations. Handlers and middlewares must not use a Context after they return
+	// unless it is copied with Detach. Contexts with funcs given to Defer are released
+	// once the funcs complete. It has no effect with a ContextFactory
+	PoolContexts bool
+	// TrustedProxies are the networks of proxies whose X-Forwarded-For, X-Real-IP, and
+	// Forwarded headers are trusted by Context.ClientIP. Headers are ignored when the
+	// request was not sent by a trusted proxy to prevent clients spoofing their IP.
+	// See ParseCIDRs
+	TrustedProxies []*net.IPNet
 }
 
 // RealRouter returns the httprouter.Router used for actual serving
@@ -103,20 +230,172 @@ func (rtr *Router) RealRouter() *httprouter.Router {
 }
 
 func (rtr *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if rtr.root().redirectTrailingSlash(w, r) {
+		return
+	}
 	rtr.RealRouter().ServeHTTP(w, r)
 }
 
+// Group creates a Router for the routes under prefix. Routes added to the group execute
+// the middlewares of rtr followed by mw and any middlewares later added to the group with
+// Use. Settings such as Renderer, Codec, and TrustedProxies are read from the Router
+// created with NewRouter
+//
+// Example:
+//
+//	api := rtr.Group("/api", middleware.BodyLimit(1<<20))
+//	api.Post("/users", createUser)
+//
+//	uploads := rtr.Group("/uploads", middleware.BodyLimit(100<<20))
+//	uploads.Post("/", createUpload)
+func (rtr *Router) Group(prefix string, mw ...Middleware) *Router {
+	child := &Router{
+		base:        rtr.base,
+		parent:      rtr,
+		prefix:      rtr.prefix + strings.TrimSuffix(prefix, "/"),
+		middlewares: make([]Middleware, 0, len(mw)),
+	}
+	child.Use(mw...)
+	return child
+}
+
+// With creates a Router for routes which need additional middlewares without a path
+// prefix. See Group
+//
+// Example:
+//
+//	rtr.With(middleware.BodyLimit(100<<20)).Post("/uploads", createUpload)
+func (rtr *Router) With(mw ...Middleware) *Router {
+	return rtr.Group("", mw...)
+}
+
+// WithErrorHandler creates a Router for routes which handle errors with h rather than the
+// ErrorHandler of rtr. The ErrorHandler of rtr still handles errors returned by its own
+// middlewares, and errors which h does not write a response for. See Group
+//
+// Example:
+//
+//	web := rtr.Group("/app")
+//	web.ErrorHandler = htmlErrorHandler
+//
+//	rtr.WithErrorHandler(problemErrorHandler).Get("/api/users", listUsers)
+func (rtr *Router) WithErrorHandler(h ErrorHandlerFunc) *Router {
+	child := rtr.Group("")
+	child.ErrorHandler = h
+	return child
+}
+
+// root returns the Router created with NewRouter which holds the settings shared by
+// all of its groups
+func (rtr *Router) root() *Router {
+	for rtr.parent != nil {
+		rtr = rtr.parent
+	}
+	return rtr
+}
+
+// statusClientClosedRequest is the non-standard status used by nginx for requests whose
+// client closed the connection before the response was written
+const statusClientClosedRequest = 499
+
+// clientClosed reports whether err was caused by the client closing the connection, which
+// cancels the context of the request served by the Router
+func clientClosed(c Context, err error) bool {
+	if !errors.Is(err, context.Canceled) {
+		return false
+	}
+	rc := requestContextOf(c)
+	return rc != nil && rc.clientCtx != nil && rc.clientCtx.Err() == context.Canceled
+}
+
+// handleError calls the ErrorHandler of rtr. Errors which it does not write a response
+// for are handled by the ErrorHandler of its parent
+func (rtr *Router) handleError(c Context, err error) {
+	if rtr.ErrorHandler != nil {
+		rtr.ErrorHandler(c, err)
+	}
+	if rtr.parent == nil {
+		if rtr.ErrorHandler == nil {
+			defaultErrorHandler(c, err)
+		}
+		return
+	}
+	if rtr.ErrorHandler == nil || c.Response().Len() == 0 {
+		rtr.parent.handleError(c, err)
+	}
+}
+
 // Method is a path handler that uses a factory to generate the handler
 // this is particularly useful for filling contextual information into a struct
-// before passing it along to handle the request
-func (rtr *Router) Method(method string, path string, createHandler HandlerProviderFunc) {
-	rtr.RealRouter().Handle(method, path, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		c := newContext(r, w, ps)
-		defer c.Response().Flush()
-
-		wrappedHandler := rtr.withMiddlewares(requestParserMiddleware(createHandler))
-		wrappedHandler(c)
-	})
+// before passing it along to handle the request. The returned Route can be annotated
+// with documentation
+func (rtr *Router) Method(method string, path string, createHandler HandlerProviderFunc) *Route {
+	pattern := rtr.prefix + path
+	handler := rtr.newChain(requestParserMiddleware(createHandler))
+	serve := func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		rtr.serveRoute(w, r, ps, pattern, handler)
+	}
+	rtr.RealRouter().Handle(method, pattern, serve)
+	route := rtr.root().addRoute(method, pattern)
+	route.router = rtr
+	route.serve = serve
+	route.handler = funcName(createHandler)
+	return route
+}
+
+// serveRoute serves a request for the route pattern with the composed handler
+func (rtr *Router) serveRoute(w http.ResponseWriter, r *http.Request, ps httprouter.Params, pattern string, handler *chain) {
+	c := rtr.newContext(r, w, ps)
+	if rc := requestContextOf(c); rc != nil {
+		rc.pattern = pattern
+		rc.clientCtx = r.Context()
+	}
+	defer unwrapDecidedPanic()
+
+	// the response is sent before reporting so that hooks and deferred funcs do not
+	// delay the client
+	err := serve(c, handler.get())
+	if err != nil && !clientClosed(c, err) {
+		rtr.reportError(c, err)
+	}
+	rtr.runDeferred(c)
+}
+
+// serve calls h and flushes the response of c even when h panics
+func serve(c Context, h HandlerFunc) error {
+	defer c.Response().Flush()
+	return h(c)
+}
+
+// OnError adds a hook which is called with every error returned by the handlers and
+// middlewares of rtr, including recovered panics, after the response has been written.
+// Hooks are intended for reporting errors to services such as Sentry or Rollbar without
+// replacing the ErrorHandler. Hooks of a Router are also called for the routes of its
+// groups. Errors caused by the client closing the connection are neither handled nor
+// reported, the response status is set to 499 for logging instead.
+//
+// Example:
+//
+//	rtr.OnError(func(c boar.Context, err error) {
+//		if herr, ok := err.(boar.HTTPError); !ok || herr.Status() >= 500 {
+//			sentry.CaptureException(err)
+//		}
+//	})
+func (rtr *Router) OnError(fn func(Context, error)) {
+	if fn == nil {
+		panic("cannot add a nil OnError hook")
+	}
+	rtr.errorHooks = append(rtr.errorHooks, fn)
+}
+
+// reportError calls the OnError hooks of rtr and its parents, starting with the root
+func (rtr *Router) reportError(c Context, err error) {
+	if rtr.parent != nil {
+		rtr.parent.reportError(c, err)
+	}
+	for _, hook := range rtr.errorHooks {
+		hook(c, err)
+	}
 }
 
 // requestParserMiddleware provides the handler with request objects populated by request data such
@@ -128,51 +407,121 @@ func requestParserMiddleware(createHandler HandlerProviderFunc) HandlerFunc {
 			return err
 		}
 		if handler == nil {
-			log.Panicf("nil handler provided for %q %q", c.Request().Method, c.Request().URL.Path)
+			msg := fmt.Sprintf("nil handler provided for %q %q", c.Request().Method, c.Request().URL.Path)
+			c.Logger().Error(msg)
+			panic(msg)
+		}
+
+		if d, ok := handler.(Deadliner); ok {
+			if timeout := d.Deadline(); timeout > 0 {
+				cancel := c.WithTimeout(timeout)
+				defer cancel()
+			}
 		}
 
 		handlerValue := reflect.Indirect(reflect.ValueOf(handler))
 
-		if err := setQuery(handlerValue, c.Request().URL.Query()); err != nil {
+		if err := populateBases(handlerValue, c); err != nil {
 			return err
 		}
-
-		if err := setURLParams(handlerValue, c.URLParams()); err != nil {
-			if _, ok := err.(*ValidationError); ok {
-				return ErrNotFound
+		if b, ok := handler.(BeforeBinder); ok {
+			if err := b.BeforeBind(c); err != nil {
+				return err
 			}
+		}
+		if err := bindRequest(handlerValue, c); err != nil {
 			return err
 		}
-
-		if err := setBody(handlerValue, c); err != nil {
+		if b, ok := handler.(AfterBinder); ok {
+			if err := b.AfterBind(c); err != nil {
+				return err
+			}
+		}
+		// don't begin handling requests which were canceled by the client or a
+		// middleware timeout while the request was being read
+		if err := c.Context().Err(); err != nil {
+			return err
+		}
+		if err := handler.Handle(c); err != nil {
 			return err
 		}
-		return handler.Handle(c)
+		return writeResponse(handlerValue, c)
 	}
 }
 
+// ServeHandler handles c with h exactly as a Router handles a route: bases registered with
+// PopulateBase are filled, BeforeBind and AfterBind are called around binding the request,
+// the Deadline of a Deadliner is applied, and the Response field is written after Handle
+// returns. Errors are returned rather than written so they can be handled by the caller.
+// Bases are only filled when c was created by a Router, see Router.NewContext
+func ServeHandler(c Context, h Handler) error {
+	return requestParserMiddleware(func(Context) (Handler, error) {
+		return h, nil
+	})(c)
+}
+
 // MethodFunc sets a HandlerFunc for a url with the given method. It is used for
 // simple handlers that do not require any building. This is not a recommended
 // for common use cases
-func (rtr *Router) MethodFunc(method string, path string, h HandlerFunc) {
-	rtr.Method(method, path, func(Context) (Handler, error) {
+func (rtr *Router) MethodFunc(method string, path string, h HandlerFunc) *Route {
+	route := rtr.Method(method, path, func(Context) (Handler, error) {
 		return &simpleHandler{handle: h}, nil
 	})
+	route.handler = funcName(h)
+	return route
+}
+
+// MethodFuncR sets a HandlerFuncR for a url with the given method. The value returned by h
+// is written with Context.Negotiate and the Router's ResponseStatus unless h writes the
+// response itself. Nil values are not written. It is intended for quick endpoints where
+// defining a Handler is overkill.
+//
+// Example:
+//
+//	rtr.MethodFuncR(http.MethodGet, "/version", func(boar.Context) (interface{}, error) {
+//		return boar.JSON{"version": version}, nil
+//	})
+func (rtr *Router) MethodFuncR(method string, path string, h HandlerFuncR) *Route {
+	route := rtr.Method(method, path, func(Context) (Handler, error) {
+		return &valueHandler{handle: h}, nil
+	})
+	route.handler = funcName(h)
+	return route
 }
 
 // Use injects a middleware into the http requests. They are executed in the
 // order in which they are added.
 func (rtr *Router) Use(mw ...Middleware) {
+	rtr.middlewares = rtr.appendMiddlewares(rtr.middlewares, mw)
+}
+
+// UseBefore injects middlewares which are executed before every middleware added with Use,
+// regardless of the order in which they are registered. It is intended for middlewares
+// such as recovery and request IDs which must always be outermost
+func (rtr *Router) UseBefore(mw ...Middleware) {
+	rtr.before = rtr.appendMiddlewares(rtr.before, mw)
+}
+
+// UseAfter injects middlewares which are executed after every middleware added with Use
+// and immediately before the handler, regardless of the order in which they are registered
+func (rtr *Router) UseAfter(mw ...Middleware) {
+	rtr.after = rtr.appendMiddlewares(rtr.after, mw)
+}
+
+func (rtr *Router) appendMiddlewares(dst []Middleware, mw []Middleware) []Middleware {
 	if len(mw) == 0 {
-		return
+		return dst
 	}
 
 	for i, m := range mw {
 		if m == nil {
-			log.Panicf("cannot use nil middleware at position %d: ", i)
+			msg := fmt.Sprintf("cannot use nil middleware at position %d", i)
+			rtr.logger().Error(msg)
+			panic(msg)
 		}
 	}
-	rtr.middlewares = append(rtr.middlewares, mw...)
+	atomic.AddUint64(&rtr.root().middlewareVersion, 1)
+	return append(dst, mw...)
 }
 
 // errorHandlerWrap wrapps rtr.ErrorHandler in a middleware so that the error handler
@@ -180,61 +529,117 @@ func (rtr *Router) Use(mw ...Middleware) {
 func (rtr *Router) errorHandlerWrap(next HandlerFunc) HandlerFunc {
 	return func(c Context) error {
 		err := next(c)
+		if err != nil && clientClosed(c, err) {
+			// nobody is waiting for the response so the error is not handled. The status
+			// is recorded for logging middleware
+			if c.Response().Status() == 0 {
+				c.Response().WriteHeader(statusClientClosedRequest)
+			}
+			return err
+		}
 		if err != nil {
-			rtr.ErrorHandler(c, err)
+			rtr.handleError(c, err)
 		}
 		return err
 	}
 }
 
+// withMiddlewares wraps next with the middlewares of rtr and its parents. Each phase is
+// wrapped in turn, from after to before, so that phases take precedence over
+// registration order and the middlewares of parents wrap those of their groups
 func (rtr *Router) withMiddlewares(next HandlerFunc) HandlerFunc {
 	fn := rtr.errorHandlerWrap(next)
-	for _, mw := range rtr.middlewares {
-		fn = rtr.errorHandlerWrap(mw(fn))
+	for _, phase := range []func(*Router) []Middleware{
+		func(r *Router) []Middleware { return r.after },
+		func(r *Router) []Middleware { return r.middlewares },
+		func(r *Router) []Middleware { return r.before },
+	} {
+		for r := rtr; r != nil; r = r.parent {
+			for _, mw := range phase(r) {
+				fn = r.errorHandlerWrap(mw(fn))
+			}
+		}
 	}
 	return fn
 }
 
+// chain is a route's handler wrapped with the middlewares of its Router. It is composed
+// when the route is registered so that requests do not wrap every middleware again, and
+// composed again by the first request after middlewares are added
+type chain struct {
+	rtr      *Router
+	root     *Router
+	handler  HandlerFunc
+	composed atomic.Value // composedChain
+}
+
+type composedChain struct {
+	version uint64
+	fn      HandlerFunc
+}
+
+func (rtr *Router) newChain(handler HandlerFunc) *chain {
+	ch := &chain{rtr: rtr, root: rtr.root(), handler: handler}
+	ch.compose()
+	return ch
+}
+
+func (ch *chain) compose() HandlerFunc {
+	version := atomic.LoadUint64(&ch.root.middlewareVersion)
+	fn := ch.rtr.withMiddlewares(ch.handler)
+	ch.composed.Store(composedChain{version: version, fn: fn})
+	return fn
+}
+
+// get returns the composed chain
+func (ch *chain) get() HandlerFunc {
+	cc := ch.composed.Load().(composedChain)
+	if cc.version != atomic.LoadUint64(&ch.root.middlewareVersion) {
+		return ch.compose()
+	}
+	return cc.fn
+}
+
 // Head is a handler that acceps HEAD requests
-func (rtr *Router) Head(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodHead, path, h)
+func (rtr *Router) Head(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodHead, path, h)
 }
 
 // Trace is a handler that accepts only TRACE requests
-func (rtr *Router) Trace(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodTrace, path, h)
+func (rtr *Router) Trace(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodTrace, path, h)
 }
 
 // Delete is a handler that accepts only DELETE requests
-func (rtr *Router) Delete(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodDelete, path, h)
+func (rtr *Router) Delete(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodDelete, path, h)
 }
 
 // Options is a handler that accepts only OPTIONS requests
 // It is not recommended to use this as the router automatically
 // handles OPTIONS requests by default
-func (rtr *Router) Options(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodOptions, path, h)
+func (rtr *Router) Options(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodOptions, path, h)
 }
 
 // Get is a handler that accepts only GET requests
-func (rtr *Router) Get(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodGet, path, h)
+func (rtr *Router) Get(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodGet, path, h)
 }
 
 // Put is a handler that accepts only PUT requests
-func (rtr *Router) Put(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodPut, path, h)
+func (rtr *Router) Put(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodPut, path, h)
 }
 
 // Post is a handler that accepts only POST requests
-func (rtr *Router) Post(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodPost, path, h)
+func (rtr *Router) Post(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodPost, path, h)
 }
 
 // Patch is a handler that accepts only PATCH requests
-func (rtr *Router) Patch(path string, h HandlerProviderFunc) {
-	rtr.Method(http.MethodPatch, path, h)
+func (rtr *Router) Patch(path string, h HandlerProviderFunc) *Route {
+	return rtr.Method(http.MethodPatch, path, h)
 }
 
 type simpleHandler struct {
@@ -244,3 +649,13 @@ type simpleHandler struct {
 func (h *simpleHandler) Handle(c Context) error {
 	return h.handle(c)
 }
+
+type valueHandler struct {
+	handle   HandlerFuncR
+	Response interface{}
+}
+
+func (h *valueHandler) Handle(c Context) (err error) {
+	h.Response, err = h.handle(c)
+	return err
+}
@@ -155,3 +155,37 @@ func BenchmarkHTTPHandlerBaseWithBodyAndQueryString(b *testing.B) {
 		handler(w, r)
 	}
 }
+
+func BenchmarkBoarContexts(b *testing.B)       { benchmarkContexts(b, false) }
+func BenchmarkBoarPooledContexts(b *testing.B) { benchmarkContexts(b, true) }
+
+func benchmarkContexts(b *testing.B, pool bool) {
+	rtr := NewRouter()
+	rtr.PoolContexts = pool
+	rtr.Get("/", func(Context) (Handler, error) {
+		return &benchBodyHandler{handler: func(Context) error { return nil }}, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rtr.ServeHTTP(rec, req)
+	}
+}
+
+func BenchmarkBoarLargeResponse(b *testing.B) {
+	body := bytes.Repeat([]byte("a"), 16<<10)
+	rtr := NewRouter()
+	rtr.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.Write(http.StatusOK, "text/plain", body)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rec := httptest.NewRecorder()
+		rec.Body = bytes.NewBuffer(make([]byte, 0, len(body)))
+		rtr.ServeHTTP(rec, req)
+	}
+}
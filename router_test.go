@@ -2,6 +2,7 @@ package boar
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -13,6 +14,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/julienschmidt/httprouter"
@@ -38,6 +40,7 @@ func TestDefaultErrorHandlerWritesExistingHTTPErrorIfNotAlreadyWritten(t *testin
 
 	status := http.StatusBadRequest
 	err := NewHTTPErrorStatus(status)
+	mc.EXPECT().Accepts(contentTypeJSON, contentTypeHTML, contentTypeXML, "text/xml", contentTypeText).Return(contentTypeJSON)
 	mc.EXPECT().WriteJSON(gomock.Any(), gomock.Any()).Return(nil).Do(func(st int, er error) {
 		assert.Equal(t, status, st)
 		assert.Equal(t, err, er)
@@ -54,7 +57,9 @@ func TestDefaultErrorHandlerPrintsErrIfWriteJSONFails(t *testing.T) {
 	err := NewHTTPErrorStatus(status)
 	writeErr := errors.New("something went wrong")
 
+	mc.EXPECT().Accepts(contentTypeJSON, contentTypeHTML, contentTypeXML, "text/xml", contentTypeText).Return("")
 	mc.EXPECT().WriteJSON(gomock.Any(), gomock.Any()).Return(writeErr)
+	mc.EXPECT().Logger().Return(NewStdLogger(nil))
 
 	mr := NewMockResponseWriter(ctrl)
 	mr.EXPECT().Len().Return(0)
@@ -232,6 +237,230 @@ func TestRequestParserMiddlewareReturnsErrorWhenSetBodyFails(t *testing.T) {
 	assert.Error(t, err)
 }
 
+type responseHandler struct {
+	handle   HandlerFunc
+	Response *userResponse
+}
+
+type userResponse struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func (h *responseHandler) Handle(c Context) error {
+	if h.handle != nil {
+		return h.handle(c)
+	}
+	h.Response = &userResponse{Name: "boar"}
+	return nil
+}
+
+func serveResponseHandler(r *Router, h *responseHandler, accept string) *httptest.ResponseRecorder {
+	r.Get("/", func(Context) (Handler, error) {
+		return h, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", accept)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequestParserMiddlewareWritesResponseField(t *testing.T) {
+	rec := serveResponseHandler(NewRouter(), &responseHandler{}, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"boar"}`, rec.Body.String())
+
+	rec = serveResponseHandler(NewRouter(), &responseHandler{}, contentTypeXML)
+	assert.Contains(t, rec.Header().Get("Content-Type"), contentTypeXML)
+	assert.Contains(t, rec.Body.String(), "<name>boar</name>")
+}
+
+func TestRequestParserMiddlewareWritesResponseFieldWithStatus(t *testing.T) {
+	r := NewRouter()
+	r.ResponseStatus = http.StatusAccepted
+	rec := serveResponseHandler(r, &responseHandler{}, "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+type valueResponseHandler struct {
+	handle   HandlerFunc
+	Response userResponse
+}
+
+func (h *valueResponseHandler) Handle(c Context) error {
+	return h.handle(c)
+}
+
+func TestRequestParserMiddlewareDoesNotWriteResponseFieldAfterStatus(t *testing.T) {
+	tests := map[string]struct {
+		handle HandlerFunc
+		code   int
+	}{
+		"no content": {handle: func(c Context) error { return c.NoContent() }, code: http.StatusNoContent},
+		"status":     {handle: func(c Context) error { return c.WriteStatus(http.StatusCreated) }, code: http.StatusCreated},
+		"redirect": {
+			handle: func(c Context) error { return c.Redirect(http.StatusFound, "/login") },
+			code:   http.StatusFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewRouter()
+			r.Get("/", func(Context) (Handler, error) {
+				return &valueResponseHandler{handle: tt.handle}, nil
+			})
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Empty(t, rec.Body.String())
+		})
+	}
+}
+
+func TestRequestParserMiddlewareDoesNotWriteResponseFieldWhenHandlerWrites(t *testing.T) {
+	rec := serveResponseHandler(NewRouter(), &responseHandler{handle: func(c Context) error {
+		return c.WriteString(http.StatusOK, "written")
+	}}, "")
+	assert.Equal(t, "written", rec.Body.String())
+
+	rec = serveResponseHandler(NewRouter(), &responseHandler{handle: func(c Context) error {
+		return nil
+	}}, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+type bindHooksHandler struct {
+	calls     []string
+	beforeErr error
+	afterErr  error
+	Query     struct {
+		Page int `query:"page"`
+	}
+}
+
+func (h *bindHooksHandler) BeforeBind(Context) error {
+	h.calls = append(h.calls, fmt.Sprintf("before %d", h.Query.Page))
+	h.Query.Page = 1
+	return h.beforeErr
+}
+
+func (h *bindHooksHandler) AfterBind(Context) error {
+	h.calls = append(h.calls, fmt.Sprintf("after %d", h.Query.Page))
+	return h.afterErr
+}
+
+func (h *bindHooksHandler) Handle(Context) error {
+	h.calls = append(h.calls, fmt.Sprintf("handle %d", h.Query.Page))
+	return nil
+}
+
+func TestRequestParserMiddlewareCallsBindHooks(t *testing.T) {
+	tests := []struct {
+		url     string
+		handler *bindHooksHandler
+		calls   []string
+		status  int
+	}{
+		{"/?page=3", &bindHooksHandler{}, []string{"before 0", "after 3", "handle 3"}, http.StatusOK},
+		{"/", &bindHooksHandler{}, []string{"before 0", "after 1", "handle 1"}, http.StatusOK},
+		{"/", &bindHooksHandler{beforeErr: ErrForbidden}, []string{"before 0"}, http.StatusForbidden},
+		{"/", &bindHooksHandler{afterErr: ErrEntityNotFound}, []string{"before 0", "after 1"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.Get("/", func(Context) (Handler, error) {
+			return tt.handler, nil
+		})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+		assert.Equal(t, tt.calls, tt.handler.calls)
+		assert.Equal(t, tt.status, rec.Code)
+	}
+}
+
+type deadlineHandler struct {
+	deadline time.Duration
+}
+
+func (h *deadlineHandler) Deadline() time.Duration { return h.deadline }
+
+func (h *deadlineHandler) Handle(c Context) error {
+	deadline, ok := c.Context().Deadline()
+	if !ok {
+		return c.WriteString(http.StatusOK, "none")
+	}
+	return c.WriteString(http.StatusOK, time.Until(deadline).Round(time.Minute).String())
+}
+
+func TestRequestParserMiddlewareAppliesHandlerDeadline(t *testing.T) {
+	tests := []struct {
+		deadline time.Duration
+		body     string
+	}{
+		{time.Hour, "1h0m0s"},
+		{0, "none"},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.Get("/", func(Context) (Handler, error) {
+			return &deadlineHandler{deadline: tt.deadline}, nil
+		})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, tt.body, rec.Body.String())
+	}
+}
+
+func TestMethodFuncRShouldWriteReturnedValue(t *testing.T) {
+	tests := []struct {
+		handle HandlerFuncR
+		accept string
+		code   int
+		body   string
+	}{
+		{func(Context) (interface{}, error) { return JSON{"name": "boar"}, nil }, "", http.StatusOK, `{"name":"boar"}`},
+		{func(Context) (interface{}, error) { return &userResponse{Name: "boar"}, nil }, contentTypeXML, http.StatusOK, "<userResponse><name>boar</name></userResponse>"},
+		{func(Context) (interface{}, error) { return nil, nil }, "", http.StatusOK, ""},
+		{func(Context) (interface{}, error) { return JSON{}, ErrEntityNotFound }, "", http.StatusNotFound, `{"error":"entity not found"}`},
+		{func(c Context) (interface{}, error) { return JSON{}, c.WriteString(http.StatusAccepted, "queued") }, "", http.StatusAccepted, "queued"},
+	}
+	for _, tt := range tests {
+		r := NewRouter()
+		r.MethodFuncR(http.MethodGet, "/", tt.handle)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.body)
+		assert.True(t, strings.HasSuffix(strings.TrimSpace(rec.Body.String()), tt.body), rec.Body.String())
+	}
+}
+
+func TestRoutePatternShouldBeTheRegisteredPath(t *testing.T) {
+	var pattern string
+	r := NewRouter()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			err := next(c)
+			pattern = c.RoutePattern()
+			return err
+		}
+	})
+	r.Group("/api").MethodFunc(http.MethodGet, "/users/:id", func(Context) error {
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/users/123", nil))
+	assert.Equal(t, "/api/users/:id", pattern)
+
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	assert.Empty(t, c.RoutePattern())
+}
+
 type nopHandler struct{}
 
 func (*nopHandler) Handle(Context) error {
@@ -298,7 +527,7 @@ func TestRealRouterReturnsUnderlyingRouter(t *testing.T) {
 func TestShouldCreateMethodHandlers(t *testing.T) {
 	r := NewRouter()
 
-	items := map[string]func(string, HandlerProviderFunc){
+	items := map[string]func(string, HandlerProviderFunc) *Route{
 		http.MethodGet:     r.Get,
 		http.MethodDelete:  r.Delete,
 		http.MethodHead:    r.Head,
@@ -509,6 +738,7 @@ func TestHandleCallsErrorHandlerBeforeMiddleware(t *testing.T) {
 
 func TestPanicHandlerSets500StatusCode(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	rec := httptest.NewRecorder()
@@ -529,6 +759,7 @@ func TestPanicHandlerSets500StatusCode(t *testing.T) {
 
 func TestPanicHandlerPreservesPanicMessage(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	rec := httptest.NewRecorder()
@@ -552,6 +783,7 @@ func TestPanicHandlerPreservesPanicMessage(t *testing.T) {
 
 func TestPanicHandlerPreservesErrorWhenNoPanic(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	rec := httptest.NewRecorder()
@@ -571,6 +803,7 @@ func TestPanicHandlerPreservesErrorWhenNoPanic(t *testing.T) {
 
 func TestPanicHandlerConvertsPanicStringsToHTTPError(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	done := &sync.WaitGroup{}
@@ -600,6 +833,7 @@ func TestPanicHandlerConvertsPanicStringsToHTTPError(t *testing.T) {
 
 func TestNotFoundHandlerDoesNotPrintBody(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	done := &sync.WaitGroup{}
@@ -624,6 +858,7 @@ func TestNotFoundHandlerDoesNotPrintBody(t *testing.T) {
 
 func TestMethodNodAllowedHandlerDoesNotPrintBody(t *testing.T) {
 	r := NewRouter()
+	r.Logger = NopLogger
 	r.Use(PanicMiddleware)
 
 	done := &sync.WaitGroup{}
@@ -645,3 +880,252 @@ func TestMethodNodAllowedHandlerDoesNotPrintBody(t *testing.T) {
 
 	assert.Empty(t, body)
 }
+
+func TestDefaultErrorHandlerWritesServiceUnavailableForDeadlineExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mc := NewMockContext(ctrl)
+
+	mc.EXPECT().Accepts(contentTypeJSON, contentTypeHTML, contentTypeXML, "text/xml", contentTypeText).Return(contentTypeJSON)
+	mc.EXPECT().WriteJSON(http.StatusServiceUnavailable, gomock.Any()).Return(nil)
+	mr := NewMockResponseWriter(ctrl)
+	mr.EXPECT().Len().Return(0)
+	mc.EXPECT().Response().Return(mr)
+
+	defaultErrorHandler(mc, context.DeadlineExceeded)
+}
+
+func TestRouterShouldNotHandleErrorsOfClosedClients(t *testing.T) {
+	r := NewRouter()
+	var handled, reported bool
+	r.ErrorHandler = func(Context, error) { handled = true }
+	r.OnError(func(Context, error) { reported = true })
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.Ctx().Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+	assert.False(t, handled)
+	assert.False(t, reported)
+	assert.Equal(t, statusClientClosedRequest, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestRouterShouldHandleCancellationsOfHandlers(t *testing.T) {
+	r := NewRouter()
+	var reported bool
+	r.OnError(func(Context, error) { reported = true })
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		c.WithCancel()()
+		return c.Ctx().Err()
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.True(t, reported)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestGroupShouldPrefixRoutes(t *testing.T) {
+	r := NewRouter()
+	r.Group("/api").Group("/v1/").MethodFunc(http.MethodGet, "/users", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGroupShouldExecuteParentMiddlewaresFirst(t *testing.T) {
+	items := make([]string, 0, 3)
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c Context) error {
+				items = append(items, name)
+				return next(c)
+			}
+		}
+	}
+
+	r := NewRouter()
+	g := r.Group("/api", record("group"))
+	r.Use(record("root"))
+	g.With(record("route")).MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Equal(t, []string{"root", "group", "route"}, items)
+
+	items = items[:0]
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, []string{"root"}, items)
+}
+
+func TestGroupShouldInheritErrorHandler(t *testing.T) {
+	var handled error
+	r := NewRouter()
+	r.ErrorHandler = func(c Context, err error) {
+		handled = err
+	}
+	r.Group("/api").MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return ErrGone
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Equal(t, ErrGone, handled)
+}
+
+func TestGroupShouldUseRootSettings(t *testing.T) {
+	var rtr *Router
+	r := NewRouter()
+	r.Group("/api").MethodFunc(http.MethodGet, "/", func(c Context) error {
+		rtr = c.(*requestContext).router
+		return nil
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Equal(t, r, rtr)
+}
+
+func TestUseBeforeAndUseAfterShouldTakePrecedenceOverRegistrationOrder(t *testing.T) {
+	items := make([]string, 0, 5)
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(c Context) error {
+				items = append(items, name)
+				return next(c)
+			}
+		}
+	}
+
+	r := NewRouter()
+	r.UseAfter(record("after"))
+	r.Use(record("app"))
+	g := r.Group("/api", record("group"))
+	g.UseBefore(record("group before"))
+	r.UseBefore(record("recover"))
+	g.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Equal(t, []string{"recover", "group before", "app", "group", "after"}, items)
+}
+
+func TestUseBeforeShouldPanicIfNilMiddleware(t *testing.T) {
+	r := NewRouter()
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+	assert.Panics(t, func() {
+		r.UseBefore(nil)
+	})
+}
+
+func TestOnErrorShouldReportErrorsAfterTheResponseIsWritten(t *testing.T) {
+	var reported []string
+	r := NewRouter()
+	r.Logger = NopLogger
+	r.Use(PanicMiddleware)
+	r.OnError(func(c Context, err error) {
+		assert.Equal(t, 0, c.Response().(*BufferedResponseWriter).body.Len(), "response should be flushed")
+		reported = append(reported, "root: "+err.Error())
+	})
+	api := r.Group("/api")
+	api.OnError(func(c Context, err error) {
+		reported = append(reported, "api: "+err.(HTTPError).Cause().Error())
+	})
+	api.MethodFunc(http.MethodGet, "/panic", func(Context) error {
+		panic("something broke")
+	})
+	r.MethodFunc(http.MethodGet, "/gone", func(Context) error {
+		return ErrGone
+	})
+	r.MethodFunc(http.MethodGet, "/ok", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	for _, path := range []string{"/api/panic", "/gone", "/ok"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Len(t, reported, 3)
+	assert.Contains(t, reported[0], "root: something broke")
+	assert.Equal(t, "api: something broke", reported[1])
+	assert.Equal(t, "root: "+ErrGone.Error(), reported[2])
+}
+
+func TestGroupAndRouteErrorHandlersShouldOverrideRouterErrorHandler(t *testing.T) {
+	html := func(c Context, err error) {
+		c.WriteString(http.StatusTeapot, "<h1>html</h1>")
+	}
+	silent := func(c Context, err error) {}
+
+	r := NewRouter()
+	web := r.Group("/web")
+	web.ErrorHandler = html
+	failing := func(Context) error { return ErrGone }
+	web.MethodFunc(http.MethodGet, "/page", failing)
+	web.WithErrorHandler(silent).MethodFunc(http.MethodGet, "/silent", failing)
+	r.Group("/api").MethodFunc(http.MethodGet, "/users", failing)
+	r.WithErrorHandler(html).MethodFunc(http.MethodGet, "/route", failing)
+
+	tests := map[string]int{
+		"/web/page":   http.StatusTeapot,
+		"/web/silent": http.StatusTeapot,
+		"/api/users":  http.StatusGone,
+		"/route":      http.StatusTeapot,
+	}
+	for path, status := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, status, rec.Code, path)
+	}
+}
+
+func TestMethodShouldComposeMiddlewaresOnce(t *testing.T) {
+	r := NewRouter()
+	composed := 0
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		composed++
+		return next
+	})
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteString(http.StatusOK, "ok")
+	})
+
+	for i := 0; i < 3; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	assert.Equal(t, 1, composed)
+}
+
+func TestMethodShouldComposeMiddlewaresAddedAfterRegistration(t *testing.T) {
+	r := NewRouter()
+	api := r.Group("/api")
+	api.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteString(http.StatusOK, "ok")
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/", nil))
+
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			c.Response().Header().Set("X-Late", "true")
+			return next(c)
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/", nil))
+	assert.Equal(t, "true", rec.Header().Get("X-Late"))
+}
@@ -0,0 +1,269 @@
+package boar
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the $schema of the documents created by JSONSchema
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schema is a JSON Schema document. It contains the subset of JSON Schema which can be
+// derived from Go types and their validate tags
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// JSONSchema creates the JSON Schema of the type of v, which may be a value or a pointer,
+// as it is encoded by encoding/json. Properties are named by their json tags and
+// constrained by their validate tags: required, min, max, len, gt, gte, lt, lte, oneof,
+// and the email, url, uri, uuid, and datetime formats. Tags after dive apply to the items
+// of slices and maps. It allows clients to be generated and contracts to be tested
+// without a full OpenAPI document. See BodySchema
+func JSONSchema(v interface{}) *Schema {
+	s := schemaOf(reflect.TypeOf(v), map[reflect.Type]bool{})
+	s.Schema = JSONSchemaDraft
+	return s
+}
+
+// BodySchema creates the JSON Schema of the Body field of handler. It returns false when
+// handler is not a struct, or a pointer to one, with a Body field
+//
+// Example:
+//
+//	schema, _ := boar.BodySchema(&CreateUserHandler{})
+//	json.NewEncoder(os.Stdout).Encode(schema)
+func BodySchema(handler interface{}) (*Schema, bool) {
+	typ := reflect.TypeOf(handler)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, false
+	}
+	sf, ok := typ.FieldByName(bodyField)
+	if !ok || hasTagOption(sf, tagNoBind) {
+		return nil, false
+	}
+	s := schemaOf(sf.Type, map[reflect.Type]bool{})
+	s.Schema = JSONSchemaDraft
+	return s, true
+}
+
+// schemaOf creates the schema of typ. seen holds the structs being walked so that
+// recursive types end with an empty schema
+func schemaOf(typ reflect.Type, seen map[reflect.Type]bool) *Schema {
+	if typ == nil {
+		return &Schema{}
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case typ == rawMessageType:
+		return &Schema{}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaOf(typ.Elem(), seen)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(typ.Elem(), seen)}
+	case reflect.Struct:
+		if seen[typ] {
+			return &Schema{}
+		}
+		seen[typ] = true
+		defer delete(seen, typ)
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addProperties(s, typ, seen)
+		return s
+	}
+	return &Schema{}
+}
+
+// addProperties adds the exported fields of typ to s. Embedded structs without a json
+// name are flattened as they are by encoding/json
+func addProperties(s *Schema, typ reflect.Type, seen map[reflect.Type]bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addProperties(s, ft, seen)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		prop := schemaOf(sf.Type, seen)
+		if applyValidateTags(prop, sf.Tag.Get("validate")) {
+			s.Required = append(s.Required, jsonName(sf))
+		}
+		s.Properties[jsonName(sf)] = prop
+	}
+}
+
+// applyValidateTags constrains s with the validate tags of a field and reports whether
+// the field is required
+func applyValidateTags(s *Schema, tags string) (required bool) {
+	if tags == "" || tags == "-" {
+		return false
+	}
+	target := s
+	for _, tag := range strings.Split(tags, ",") {
+		name, param := tag, ""
+		if i := strings.IndexByte(tag, '='); i >= 0 {
+			name, param = tag[:i], tag[i+1:]
+		}
+		switch name {
+		case "required":
+			required = required || target == s
+		case "dive":
+			if target.Items != nil {
+				target = target.Items
+			} else if target.AdditionalProperties != nil {
+				target = target.AdditionalProperties
+			}
+		case "min", "gte":
+			target.setMin(param, false)
+		case "max", "lte":
+			target.setMax(param, false)
+		case "gt":
+			target.setMin(param, true)
+		case "lt":
+			target.setMax(param, true)
+		case "len":
+			target.setMin(param, false)
+			target.setMax(param, false)
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				target.Enum = append(target.Enum, target.enumValue(v))
+			}
+		case "email":
+			target.Format = "email"
+		case "url", "uri":
+			target.Format = "uri"
+		case "uuid", "uuid4":
+			target.Format = "uuid"
+		case "datetime":
+			target.Format = "date-time"
+		}
+	}
+	return required
+}
+
+// setMin sets the lower bound of s to param as a length, count, or value by its type
+func (s *Schema) setMin(param string, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch s.Type {
+	case "string":
+		i := int(n)
+		if exclusive {
+			i++
+		}
+		s.MinLength = &i
+	case "array":
+		i := int(n)
+		if exclusive {
+			i++
+		}
+		s.MinItems = &i
+	case "integer", "number":
+		if exclusive {
+			s.ExclusiveMinimum = &n
+		} else {
+			s.Minimum = &n
+		}
+	}
+}
+
+// setMax sets the upper bound of s to param as a length, count, or value by its type
+func (s *Schema) setMax(param string, exclusive bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch s.Type {
+	case "string":
+		i := int(n)
+		if exclusive {
+			i--
+		}
+		s.MaxLength = &i
+	case "array":
+		i := int(n)
+		if exclusive {
+			i--
+		}
+		s.MaxItems = &i
+	case "integer", "number":
+		if exclusive {
+			s.ExclusiveMaximum = &n
+		} else {
+			s.Maximum = &n
+		}
+	}
+}
+
+// enumValue converts a oneof value to the type of s
+func (s *Schema) enumValue(v string) interface{} {
+	switch s.Type {
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
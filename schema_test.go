@@ -0,0 +1,73 @@
+package boar
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type schemaNode struct {
+	Name     string        `json:"name"`
+	Children []*schemaNode `json:"children"`
+}
+
+type schemaUser struct {
+	schemaAudit
+	Name     string            `json:"name" validate:"required,min=3,max=50"`
+	Email    string            `json:"email,omitempty" validate:"omitempty,email"`
+	Age      int               `json:"age" validate:"gte=0,lt=150"`
+	Role     string            `json:"role" validate:"oneof=admin user"`
+	Tags     []string          `json:"tags" validate:"max=5,dive,min=1"`
+	Labels   map[string]string `json:"labels"`
+	Avatar   []byte            `json:"avatar"`
+	Tree     *schemaNode       `json:"tree"`
+	Internal string            `json:"-"`
+	secret   string
+}
+
+func TestJSONSchemaShouldDescribeStructs(t *testing.T) {
+	b, err := json.Marshal(JSONSchema(&schemaUser{}))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"created_at": {"type": "string", "format": "date-time"},
+			"name": {"type": "string", "minLength": 3, "maxLength": 50},
+			"email": {"type": "string", "format": "email"},
+			"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"tags": {"type": "array", "maxItems": 5, "items": {"type": "string", "minLength": 1}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"avatar": {"type": "string", "format": "byte"},
+			"tree": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {}}
+				}
+			}
+		}
+	}`, string(b))
+}
+
+func TestBodySchemaShouldDescribeBodyField(t *testing.T) {
+	s, ok := BodySchema(&bodyHandler{})
+	require.True(t, ok)
+	assert.Equal(t, []string{"Age"}, s.Required)
+	assert.Equal(t, "integer", s.Properties["Age"].Type)
+
+	_, ok = BodySchema(&nopHandler{})
+	assert.False(t, ok)
+	_, ok = BodySchema("not a handler")
+	assert.False(t, ok)
+}
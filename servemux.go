@@ -0,0 +1,125 @@
+package boar
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Handle adds a route for a pattern in the style of http.ServeMux, such as
+// "GET /users/{id}". Wildcards are translated to URL params so {id} is read with
+// Context.URLParams().ByName("id") and {path...} matches the rest of the path like
+// *path, including its leading slash. The method is required and host patterns and {$} are not supported since
+// routes never match more than their path. See Method
+//
+// Example:
+//
+//	rtr.Handle("GET /users/{id}", func(boar.Context) (boar.Handler, error) {
+//		return &GetUserHandler{db: db}, nil
+//	})
+func (rtr *Router) Handle(pattern string, createHandler HandlerProviderFunc) *Route {
+	method, path := parseServeMuxPattern(pattern)
+	return rtr.Method(method, path, createHandler)
+}
+
+// HandleFunc adds a route for a pattern in the style of http.ServeMux, such as
+// "GET /users/{id}", which is served by h. The wildcards are also set as path values of
+// the request so handlers ported from http.ServeMux can keep using Request.PathValue.
+// See Handle
+//
+// Example:
+//
+//	rtr.HandleFunc("GET /users/{id}", func(c boar.Context) error {
+//		return c.WriteJSON(http.StatusOK, users.Get(c.Request().PathValue("id")))
+//	})
+func (rtr *Router) HandleFunc(pattern string, h HandlerFunc) *Route {
+	method, path := parseServeMuxPattern(pattern)
+	route := rtr.MethodFunc(method, path, func(c Context) error {
+		r := c.Request()
+		for _, p := range c.URLParams() {
+			// http.ServeMux does not begin the values of {path...} with a slash
+			r.SetPathValue(p.Key, strings.TrimPrefix(p.Value, "/"))
+		}
+		return h(c)
+	})
+	route.handler = funcName(h)
+	return route
+}
+
+// parseServeMuxPattern returns the method and the httprouter path of an http.ServeMux
+// pattern. It panics for invalid or unsupported patterns just as registering them would
+func parseServeMuxPattern(pattern string) (string, string) {
+	fields := strings.Fields(pattern)
+	if len(fields) != 2 {
+		panic(fmt.Sprintf("pattern %q must be a method and a path", pattern))
+	}
+	method, path := fields[0], fields[1]
+	if !strings.HasPrefix(path, "/") {
+		panic(fmt.Sprintf("pattern %q must have a path beginning with / and no host", pattern))
+	}
+
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if !strings.ContainsAny(seg, "{}") {
+			continue
+		}
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") || len(seg) < 3 {
+			panic(fmt.Sprintf("pattern %q: wildcards must be a full path segment", pattern))
+		}
+		name := seg[1 : len(seg)-1]
+		switch {
+		case name == "$":
+			panic(fmt.Sprintf("pattern %q: {$} is not supported", pattern))
+		case strings.HasSuffix(name, "..."):
+			if i != len(segments)-1 {
+				panic(fmt.Sprintf("pattern %q: {%s} must be the last segment", pattern, name))
+			}
+			segments[i] = "*" + strings.TrimSuffix(name, "...")
+		default:
+			segments[i] = ":" + name
+		}
+	}
+	return method, strings.Join(segments, "/")
+}
+
+// serveMuxPattern returns the http.ServeMux pattern which matches the same requests as
+// the route
+func serveMuxPattern(method, path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			segments[i] = "{" + seg[1:] + "}"
+		case strings.HasPrefix(seg, "*"):
+			segments[i] = "{" + seg[1:] + "...}"
+		}
+	}
+	path = strings.Join(segments, "/")
+	// paths ending with a slash match every path below them unless they end with {$}
+	if strings.HasSuffix(path, "/") {
+		path += "{$}"
+	}
+	return method + " " + path
+}
+
+// RegisterOn adds every route of rtr, and of every Router created from the same NewRouter,
+// to mux with the equivalent http.ServeMux pattern. This runs boar handlers, with their
+// middlewares and error handling, on top of the standard library's router so that
+// applications can move between the two one route at a time. Routes added to rtr after
+// RegisterOn are not added to mux. RegisterOn panics when a pattern conflicts with one
+// already registered on mux.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("GET /healthz", healthz)
+//	rtr.RegisterOn(mux)
+//	http.ListenAndServe(":8080", mux)
+func (rtr *Router) RegisterOn(mux *http.ServeMux) {
+	for _, route := range rtr.root().routes {
+		serve := route.serve
+		mux.HandleFunc(serveMuxPattern(route.info.Method, route.info.Path), func(w http.ResponseWriter, r *http.Request) {
+			serve(w, r, patternParams(r))
+		})
+	}
+}
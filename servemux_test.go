@@ -0,0 +1,127 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		path    string
+	}{
+		{"GET /users", http.MethodGet, "/users"},
+		{"GET /users/{id}", http.MethodGet, "/users/:id"},
+		{"POST  /users/{id}/files/{path...}", http.MethodPost, "/users/:id/files/*path"},
+		{"DELETE /", http.MethodDelete, "/"},
+	}
+	for _, tt := range tests {
+		method, path := parseServeMuxPattern(tt.pattern)
+		assert.Equal(t, tt.method, method, tt.pattern)
+		assert.Equal(t, tt.path, path, tt.pattern)
+	}
+}
+
+func TestParseServeMuxPatternPanicsForUnsupportedPatterns(t *testing.T) {
+	for _, pattern := range []string{
+		"/users",
+		"GET example.com/users",
+		"GET /users/{id",
+		"GET /users/x{id}",
+		"GET /users/{}",
+		"GET /users/{$}",
+		"GET /files/{path...}/edit",
+	} {
+		assert.Panics(t, func() { parseServeMuxPattern(pattern) }, pattern)
+	}
+}
+
+func TestServeMuxPattern(t *testing.T) {
+	assert.Equal(t, "GET /users/{id}", serveMuxPattern(http.MethodGet, "/users/:id"))
+	assert.Equal(t, "GET /files/{path...}", serveMuxPattern(http.MethodGet, "/files/*path"))
+	assert.Equal(t, "GET /users/{$}", serveMuxPattern(http.MethodGet, "/users/"))
+	assert.Equal(t, "GET /{$}", serveMuxPattern(http.MethodGet, "/"))
+}
+
+func TestHandleFuncTranslatesWildcards(t *testing.T) {
+	r := NewRouter()
+	route := r.HandleFunc("GET /users/{id}/files/{path...}", func(c Context) error {
+		return c.WriteString(http.StatusOK, c.URLParams().ByName("id")+" "+
+			c.Request().PathValue("id")+" "+c.Request().PathValue("path"))
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42/files/a/b.txt", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "42 42 a/b.txt", rec.Body.String())
+	assert.Equal(t, "/users/:id/files/*path", route.Info().Path)
+	assert.Contains(t, route.handler, "TestHandleFuncTranslatesWildcards")
+}
+
+func TestHandleTranslatesWildcards(t *testing.T) {
+	r := NewRouter()
+	r.Handle("GET /users/{id}", func(Context) (Handler, error) {
+		return &simpleHandler{handle: func(c Context) error {
+			return c.WriteString(http.StatusOK, c.URLParams().ByName("id"))
+		}}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Equal(t, "42", rec.Body.String())
+}
+
+func TestRegisterOnServesRoutesWithServeMux(t *testing.T) {
+	r := NewRouter()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			c.Response().Header().Set("x-pattern", c.RoutePattern())
+			return next(c)
+		}
+	})
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		return c.WriteString(http.StatusOK, "home")
+	})
+	r.MethodFunc(http.MethodGet, "/users/:id", func(c Context) error {
+		return c.WriteString(http.StatusOK, "user "+c.URLParams().ByName("id"))
+	})
+	r.MethodFunc(http.MethodGet, "/files/*path", func(c Context) error {
+		return c.WriteString(http.StatusOK, c.URLParams().ByName("path"))
+	})
+	r.Group("/api").MethodFunc(http.MethodDelete, "/users/:id", func(Context) error {
+		return ErrForbidden
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	r.RegisterOn(mux)
+
+	tests := []struct {
+		method  string
+		path    string
+		code    int
+		body    string
+		pattern string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "home", "/"},
+		{http.MethodGet, "/users/42", http.StatusOK, "user 42", "/users/:id"},
+		{http.MethodGet, "/files/a/b.txt", http.StatusOK, "/a/b.txt", "/files/*path"},
+		{http.MethodDelete, "/api/users/42", http.StatusForbidden, `{"error":"Forbidden"}` + "\n", "/api/users/:id"},
+		{http.MethodGet, "/healthz", http.StatusOK, "ok", ""},
+		{http.MethodGet, "/missing", http.StatusNotFound, "404 page not found\n", ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.code, rec.Code, tt.path)
+		assert.Equal(t, tt.body, rec.Body.String(), tt.path)
+		assert.Equal(t, tt.pattern, rec.Header().Get("x-pattern"), tt.path)
+	}
+}
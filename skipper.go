@@ -0,0 +1,90 @@
+package boar
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Skipper reports whether a middleware should be bypassed for a request
+type Skipper func(Context) bool
+
+// Skip creates a middleware which executes mw unless skip returns true for the request.
+// It allows any middleware to be bypassed for routes such as health checks, static
+// assets, or websockets without creating separate routers.
+//
+// Example:
+//
+//	rtr.Use(boar.Skip(middleware.Gzip(), boar.SkipAny(
+//		boar.SkipPaths("/healthz", "/static/*"),
+//		boar.SkipWebSockets,
+//	)))
+func Skip(mw Middleware, skip Skipper) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		wrapped := mw(next)
+		return func(c Context) error {
+			if skip(c) {
+				return next(c)
+			}
+			return wrapped(c)
+		}
+	}
+}
+
+// SkipPaths creates a Skipper for requests whose path matches one of patterns. Patterns
+// use the syntax of path.Match so that /static/* matches every file in /static
+func SkipPaths(patterns ...string) Skipper {
+	return func(c Context) bool {
+		p := c.Request().URL.Path
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipPathPrefixes creates a Skipper for requests whose path begins with one of prefixes
+func SkipPathPrefixes(prefixes ...string) Skipper {
+	return func(c Context) bool {
+		p := c.Request().URL.Path
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(p, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipMethods creates a Skipper for requests with one of methods
+func SkipMethods(methods ...string) Skipper {
+	return func(c Context) bool {
+		m := c.Request().Method
+		for _, method := range methods {
+			if strings.EqualFold(m, method) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipWebSockets is a Skipper for websocket upgrade requests
+func SkipWebSockets(c Context) bool {
+	r := c.Request()
+	return r.Method == http.MethodGet && strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// SkipAny creates a Skipper which skips requests matched by any of skippers
+func SkipAny(skippers ...Skipper) Skipper {
+	return func(c Context) bool {
+		for _, skip := range skippers {
+			if skip(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipShouldBypassMiddlewareWhenSkipped(t *testing.T) {
+	var executed []string
+	mw := func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			executed = append(executed, c.Request().URL.Path)
+			return next(c)
+		}
+	}
+
+	r := NewRouter()
+	r.Use(Skip(mw, SkipPaths("/healthz")))
+	r.MethodFunc(http.MethodGet, "/healthz", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+	r.MethodFunc(http.MethodGet, "/users", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users", nil))
+
+	assert.Equal(t, []string{"/users"}, executed)
+}
+
+func TestSkippers(t *testing.T) {
+	ws := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	ws.Header.Set("Upgrade", "WebSocket")
+
+	tests := []struct {
+		name string
+		skip Skipper
+		req  *http.Request
+		want bool
+	}{
+		{"paths exact", SkipPaths("/healthz"), httptest.NewRequest(http.MethodGet, "/healthz", nil), true},
+		{"paths glob", SkipPaths("/static/*"), httptest.NewRequest(http.MethodGet, "/static/app.js", nil), true},
+		{"paths glob nested", SkipPaths("/static/*"), httptest.NewRequest(http.MethodGet, "/static/js/app.js", nil), false},
+		{"paths miss", SkipPaths("/healthz"), httptest.NewRequest(http.MethodGet, "/users", nil), false},
+		{"prefixes", SkipPathPrefixes("/static/"), httptest.NewRequest(http.MethodGet, "/static/js/app.js", nil), true},
+		{"prefixes miss", SkipPathPrefixes("/static/"), httptest.NewRequest(http.MethodGet, "/users", nil), false},
+		{"methods", SkipMethods(http.MethodOptions), httptest.NewRequest(http.MethodOptions, "/", nil), true},
+		{"methods miss", SkipMethods(http.MethodOptions), httptest.NewRequest(http.MethodGet, "/", nil), false},
+		{"websockets", SkipWebSockets, ws, true},
+		{"websockets miss", SkipWebSockets, httptest.NewRequest(http.MethodGet, "/ws", nil), false},
+		{"any", SkipAny(SkipMethods(http.MethodHead), SkipPaths("/healthz")), httptest.NewRequest(http.MethodGet, "/healthz", nil), true},
+		{"any miss", SkipAny(SkipMethods(http.MethodHead), SkipPaths("/healthz")), httptest.NewRequest(http.MethodGet, "/users", nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.skip(NewContext(tt.req, httptest.NewRecorder(), nil)))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package boar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+var errIsDirectory = errors.New("is a directory")
+
+// SPA serves the single page application in fsys under prefix. Requests for files of fsys
+// are served with the file and every other GET or HEAD request under prefix is served
+// with index so that the application can handle its own routes with the history API.
+// Routes of rtr take precedence over the application so the two can share a Router.
+// Unknown paths are not served with index when they have a file extension, since they
+// are missing assets, or when they are under the first path segment of a route of rtr,
+// since they are unknown API endpoints. The middlewares of rtr are executed for every
+// request served by the application. SPA panics when index is not a file of fsys.
+//
+// Example:
+//
+//	//go:embed dist
+//	var dist embed.FS
+//	...
+//	rtr.Get("/api/users", listUsers)
+//	app, _ := fs.Sub(dist, "dist")
+//	rtr.SPA("/", app, "index.html")
+func (rtr *Router) SPA(prefix string, fsys fs.FS, index string) {
+	if fsys == nil {
+		panic("SPA requires a file system")
+	}
+	if info, err := fs.Stat(fsys, index); err != nil || info.IsDir() {
+		panic(fmt.Sprintf("SPA index %q is not a file", index))
+	}
+
+	prefix = rtr.prefix + strings.TrimSuffix(prefix, "/")
+	pattern := prefix + "/*filepath"
+	handler := rtr.newChain(func(c Context) error {
+		return serveSPA(c, fsys, prefix, index)
+	})
+
+	// the application is served when no route matches because httprouter does not allow
+	// a catch-all route to share its path with other routes
+	notFound := rtr.RealRouter().NotFound
+	rtr.RealRouter().NotFound = func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+			underPrefix(r.URL.Path, prefix) && !rtr.isRoutePath(r.URL.Path, prefix) {
+			rtr.serveRoute(w, r, nil, pattern, handler)
+			return
+		}
+		if notFound != nil {
+			notFound(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	}
+}
+
+// underPrefix reports whether p is prefix or a path below it
+func underPrefix(p, prefix string) bool {
+	return prefix == "" || p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// isRoutePath reports whether the first path segment of p below prefix is the first
+// static segment of a route below prefix
+func (rtr *Router) isRoutePath(p, prefix string) bool {
+	segment := firstSegment(strings.TrimPrefix(p, prefix))
+	if segment == "" {
+		return false
+	}
+	for _, route := range rtr.root().routes {
+		if !underPrefix(route.info.Path, prefix) {
+			continue
+		}
+		if firstSegment(strings.TrimPrefix(route.info.Path, prefix)) == segment {
+			return true
+		}
+	}
+	return false
+}
+
+// firstSegment returns the first segment of p or an empty string when it is a parameter
+func firstSegment(p string) string {
+	p = strings.TrimPrefix(p, "/")
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		p = p[:i]
+	}
+	if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+		return ""
+	}
+	return p
+}
+
+// serveSPA serves the file of fsys requested by c or index when there is no such file
+func serveSPA(c Context, fsys fs.FS, prefix, index string) error {
+	name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(c.Request().URL.Path, prefix)), "/")
+	if name != "" {
+		err := serveFS(c, fsys, name)
+		if err == nil || (!errors.Is(err, fs.ErrNotExist) && err != errIsDirectory) {
+			return err
+		}
+		if path.Ext(name) != "" {
+			return ErrNotFound
+		}
+	}
+	// the index must be revalidated so that clients load new deployments
+	c.Response().Header().Set("cache-control", "no-cache")
+	return serveFS(c, fsys, index)
+}
+
+// serveFS serves the named file of fsys with http.ServeContent
+func serveFS(c Context, fsys fs.FS, name string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return errIsDirectory
+	}
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		b, err := ioutil.ReadAll(f)
+		if err != nil {
+			return fmt.Errorf("could not read file %q: %+v", name, err)
+		}
+		rs = bytes.NewReader(b)
+	}
+	// ServeContent sniffs the content when the content type has not been set
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		c.Response().Header().Set("content-type", ct)
+	}
+	http.ServeContent(c.Response(), c.Request(), name, info.ModTime(), rs)
+	return nil
+}
@@ -0,0 +1,102 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var spaFS = fstest.MapFS{
+	"index.html":     {Data: []byte("<html>app</html>")},
+	"assets/app.js":  {Data: []byte("console.log('app')")},
+	"assets/app.css": {Data: []byte("body{}")},
+}
+
+func serveSPARequest(r *Router, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestSPAServesAssetsAndFallsBackToIndex(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/api/users", func(c Context) error {
+		return c.WriteJSON(http.StatusOK, []string{"brett"})
+	})
+	r.SPA("/", spaFS, "index.html")
+
+	tests := []struct {
+		method      string
+		path        string
+		code        int
+		contentType string
+		body        string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "text/html; charset=utf-8", "<html>app</html>"},
+		{http.MethodGet, "/assets/app.js", http.StatusOK, "text/javascript; charset=utf-8", "console.log('app')"},
+		{http.MethodGet, "/assets/app.css", http.StatusOK, "text/css; charset=utf-8", "body{}"},
+		{http.MethodGet, "/users/42/edit", http.StatusOK, "text/html; charset=utf-8", "<html>app</html>"},
+		{http.MethodGet, "/assets", http.StatusOK, "text/html; charset=utf-8", "<html>app</html>"},
+		{http.MethodGet, "/../index.html", http.StatusOK, "text/html; charset=utf-8", "<html>app</html>"},
+		{http.MethodHead, "/settings", http.StatusOK, "text/html; charset=utf-8", ""},
+		{http.MethodGet, "/api/users", http.StatusOK, "application/json", `["brett"]` + "\n"},
+		{http.MethodGet, "/api/missing", http.StatusNotFound, "", ""},
+		{http.MethodGet, "/assets/missing.js", http.StatusNotFound, "application/json", `{"error":"Not Found"}` + "\n"},
+		{http.MethodPost, "/settings", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		rec := serveSPARequest(r, tt.method, tt.path)
+		assert.Equal(t, tt.code, rec.Code, tt.path)
+		assert.Equal(t, tt.contentType, rec.Header().Get("content-type"), tt.path)
+		assert.Equal(t, tt.body, rec.Body.String(), tt.path)
+	}
+}
+
+func TestSPAIndexIsRevalidated(t *testing.T) {
+	r := NewRouter()
+	r.SPA("/", spaFS, "index.html")
+
+	assert.Equal(t, "no-cache", serveSPARequest(r, http.MethodGet, "/users").Header().Get("cache-control"))
+	assert.Empty(t, serveSPARequest(r, http.MethodGet, "/assets/app.js").Header().Get("cache-control"))
+}
+
+func TestSPAServesUnderPrefix(t *testing.T) {
+	r := NewRouter()
+	r.Group("/app").SPA("/", spaFS, "index.html")
+
+	assert.Equal(t, "<html>app</html>", serveSPARequest(r, http.MethodGet, "/app").Body.String())
+	assert.Equal(t, "<html>app</html>", serveSPARequest(r, http.MethodGet, "/app/users/42").Body.String())
+	assert.Equal(t, "body{}", serveSPARequest(r, http.MethodGet, "/app/assets/app.css").Body.String())
+	assert.Equal(t, http.StatusNotFound, serveSPARequest(r, http.MethodGet, "/other").Code)
+	assert.Equal(t, http.StatusNotFound, serveSPARequest(r, http.MethodGet, "/application").Code)
+}
+
+func TestSPAExecutesMiddlewares(t *testing.T) {
+	r := NewRouter()
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c Context) error {
+			c.Response().Header().Set("x-middleware", c.RoutePattern())
+			return next(c)
+		}
+	})
+	r.SPA("/", spaFS, "index.html")
+
+	assert.Equal(t, "/*filepath", serveSPARequest(r, http.MethodGet, "/users").Header().Get("x-middleware"))
+}
+
+func TestSPAPanicsWithoutIndex(t *testing.T) {
+	assert.Panics(t, func() { NewRouter().SPA("/", spaFS, "missing.html") })
+	assert.Panics(t, func() { NewRouter().SPA("/", spaFS, "assets") })
+	assert.Panics(t, func() { NewRouter().SPA("/", nil, "index.html") })
+}
+
+func TestFirstSegment(t *testing.T) {
+	assert.Equal(t, "api", firstSegment("/api/users"))
+	assert.Equal(t, "api", firstSegment("api"))
+	assert.Equal(t, "", firstSegment("/:id"))
+	assert.Equal(t, "", firstSegment("/*filepath"))
+	assert.Equal(t, "", firstSegment("/"))
+}
@@ -0,0 +1,137 @@
+package boar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSEKeepAliveInterval is how often an EventStream sends a comment to the client to
+// keep the connection from being closed by proxies while no events are sent
+var SSEKeepAliveInterval = 15 * time.Second
+
+// EventStream writes server-sent events to the client. It must be closed when the handler
+// is finished sending events
+type EventStream struct {
+	w     ResponseWriter
+	ctx   context.Context
+	codec Codec
+	m     sync.Mutex
+	stop  chan struct{}
+	once  sync.Once
+}
+
+func (r *requestContext) SSE() (*EventStream, error) {
+	h := r.response.Header()
+	h.Set("content-type", "text/event-stream")
+	h.Set("cache-control", "no-cache")
+	h.Set("connection", "keep-alive")
+	r.response.WriteHeader(http.StatusOK)
+	if err := r.response.Flush(); err != nil {
+		return nil, fmt.Errorf("could not start event stream: %+v", err)
+	}
+
+	es := &EventStream{
+		w:     r.response,
+		ctx:   r.Context(),
+		codec: r.codec(),
+		stop:  make(chan struct{}),
+	}
+	go es.keepAlive(SSEKeepAliveInterval)
+	return es, nil
+}
+
+// sseLineBreaks normalizes the line breaks of event data, which may be CRLF, CR, or LF
+var sseLineBreaks = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// Send sends an event to the client. event and id are omitted when empty and an error is
+// returned if either contains a line break since it would end the field early. data is
+// sent as is when it is a string or []byte, otherwise it is encoded as JSON. Each line of
+// data is sent as a separate data field. An error is returned if the client has disconnected
+func (e *EventStream) Send(event, id string, data interface{}) error {
+	if strings.ContainsAny(id, "\r\n") {
+		return fmt.Errorf("event id must not contain line breaks: %q", id)
+	}
+	if strings.ContainsAny(event, "\r\n") {
+		return fmt.Errorf("event name must not contain line breaks: %q", event)
+	}
+
+	var payload string
+	switch d := data.(type) {
+	case string:
+		payload = d
+	case []byte:
+		payload = string(d)
+	default:
+		b, err := marshal(e.codec, d)
+		if err != nil {
+			return fmt.Errorf("could not encode event data: %+v", err)
+		}
+		payload = string(b)
+	}
+
+	var msg bytes.Buffer
+	if id != "" {
+		fmt.Fprintf(&msg, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&msg, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(sseLineBreaks.Replace(payload), "\n") {
+		fmt.Fprintf(&msg, "data: %s\n", line)
+	}
+	msg.WriteString("\n")
+	return e.write(msg.String())
+}
+
+// Done is closed when the client disconnects
+func (e *EventStream) Done() <-chan struct{} {
+	return e.ctx.Done()
+}
+
+// Close stops sending keep-alive comments. No events can be sent after Close
+func (e *EventStream) Close() {
+	e.once.Do(func() {
+		e.m.Lock()
+		defer e.m.Unlock()
+		close(e.stop)
+	})
+}
+
+func (e *EventStream) write(s string) error {
+	e.m.Lock()
+	defer e.m.Unlock()
+	select {
+	case <-e.stop:
+		return io.ErrClosedPipe
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	default:
+	}
+	if _, err := io.WriteString(e.w, s); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+func (e *EventStream) keepAlive(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := e.write(": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-e.stop:
+			return
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
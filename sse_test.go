@@ -0,0 +1,106 @@
+package boar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSESendsHeadersAndEvents(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	require.NoError(t, es.Send("greeting", "1", "hello\nworld"))
+	require.NoError(t, es.Send("", "", JSON{"a": 1}))
+	es.Close()
+
+	assert.Equal(t, "text/event-stream", w.Header().Get("content-type"))
+	assert.Equal(t, "no-cache", w.Header().Get("cache-control"))
+	assert.Equal(t, "id: 1\nevent: greeting\ndata: hello\ndata: world\n\ndata: {\"a\":1}\n\n", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func TestSSESendsKeepAliveComments(t *testing.T) {
+	defer func(d time.Duration) { SSEKeepAliveInterval = d }(SSEKeepAliveInterval)
+	SSEKeepAliveInterval = time.Millisecond
+
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	es.Close()
+
+	assert.True(t, strings.HasPrefix(w.Body.String(), ": keep-alive\n\n"))
+}
+
+func TestSSESendReturnsErrorWhenClientDisconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	c := newContext(req, httptest.NewRecorder(), nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	defer es.Close()
+
+	cancel()
+	<-es.Done()
+	assert.Equal(t, context.Canceled, es.Send("", "", "hello"))
+}
+
+func TestSSESendReturnsErrorWhenClosed(t *testing.T) {
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	es.Close()
+	es.Close()
+
+	assert.Error(t, es.Send("", "", "hello"))
+}
+
+func TestSSESendSplitsDataOnEveryLineBreak(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	require.NoError(t, es.Send("", "", "a\r\nb\rc\nd"))
+	require.NoError(t, es.Send("", "", []byte("e\r\n\rf")))
+	es.Close()
+
+	assert.Equal(t, "data: a\ndata: b\ndata: c\ndata: d\n\ndata: e\ndata: \ndata: f\n\n", w.Body.String())
+}
+
+func TestSSESendRejectsLineBreaksInIDAndEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	es, err := c.SSE()
+	require.NoError(t, err)
+	defer es.Close()
+
+	tests := []struct {
+		event, id string
+	}{
+		{id: "1\ndata: injected"},
+		{id: "1\r"},
+		{id: "1\r\n"},
+		{event: "greeting\nid: 2"},
+		{event: "greeting\r"},
+		{event: "\r\ngreeting"},
+	}
+	for _, test := range tests {
+		assert.Error(t, es.Send(test.event, test.id, "hello"), "event %q id %q", test.event, test.id)
+	}
+	assert.Empty(t, w.Body.String())
+}
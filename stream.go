@@ -0,0 +1,163 @@
+package boar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// StreamFlushInterval is how often Context.Stream flushes copied data to the client
+var StreamFlushInterval = time.Second
+
+// streamBufferSize is the size of the chunks copied by Context.Stream
+const streamBufferSize = 32 * 1024
+
+func (r *requestContext) Stream(status int, contentType string, rdr io.Reader) error {
+	r.response.Header().Set("content-type", contentType)
+	r.response.WriteHeader(status)
+	// flushing sends the headers and any buffered body. Everything written afterwards
+	// goes directly to the client
+	if err := r.response.Flush(); err != nil {
+		return fmt.Errorf("could not start stream: %+v", err)
+	}
+
+	buf := make([]byte, streamBufferSize)
+	lastFlush := time.Now()
+	for {
+		n, err := rdr.Read(buf)
+		if n > 0 {
+			if _, werr := r.response.Write(buf[:n]); werr != nil {
+				return fmt.Errorf("could not write stream: %+v", werr)
+			}
+			if time.Since(lastFlush) >= StreamFlushInterval {
+				r.response.Flush()
+				lastFlush = time.Now()
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not read stream: %+v", err)
+		}
+	}
+	return r.response.Flush()
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func (r *requestContext) WriteJSONStream(status int, v interface{}) error {
+	r.response.Header().Set("content-type", "application/json")
+	r.response.WriteHeader(status)
+	if err := r.response.Flush(); err != nil {
+		return fmt.Errorf("could not start stream: %+v", err)
+	}
+
+	val := reflect.ValueOf(v)
+	if !streamable(val) {
+		if err := r.codec().Encode(r.response, v); err != nil {
+			return fmt.Errorf("could not encode JSON response: %+v", err)
+		}
+		return r.response.Flush()
+	}
+
+	var buf bytes.Buffer
+	lastFlush := time.Now()
+	write := func(i int, elem reflect.Value) error {
+		buf.Reset()
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := r.codec().Encode(&buf, elem.Interface()); err != nil {
+			return fmt.Errorf("could not encode JSON response: %+v", err)
+		}
+		if _, err := r.response.Write(bytes.TrimRight(buf.Bytes(), "\n")); err != nil {
+			return fmt.Errorf("could not write stream: %+v", err)
+		}
+		if time.Since(lastFlush) >= StreamFlushInterval {
+			r.response.Flush()
+			lastFlush = time.Now()
+		}
+		return nil
+	}
+
+	if _, err := io.WriteString(r.response, "["); err != nil {
+		return fmt.Errorf("could not write stream: %+v", err)
+	}
+	if val.Kind() == reflect.Chan {
+		done := r.Context().Done()
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: val},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+		}
+		for i := 0; ; i++ {
+			chosen, elem, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return r.Context().Err()
+			}
+			if !ok {
+				break
+			}
+			if err := write(i, elem); err != nil {
+				return err
+			}
+		}
+	} else {
+		for i := 0; i < val.Len(); i++ {
+			if err := write(i, val.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := io.WriteString(r.response, "]\n"); err != nil {
+		return fmt.Errorf("could not write stream: %+v", err)
+	}
+	return r.response.Flush()
+}
+
+// streamable reports whether WriteJSONStream can encode the elements of v one at a time
+// as a JSON array
+func streamable(v reflect.Value) bool {
+	if !v.IsValid() || v.Type().Implements(jsonMarshalerType) {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		return !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Chan:
+		return v.Type().ChanDir()&reflect.RecvDir != 0 && !v.IsNil()
+	}
+	return false
+}
+
+var errCannotUnbuffer = errors.New("the response writer does not support unbuffering")
+
+func (r *requestContext) Unbuffer() error {
+	bw, ok := r.response.(*BufferedResponseWriter)
+	if !ok {
+		return errCannotUnbuffer
+	}
+	bw.Unbuffer()
+	return nil
+}
+
+// Unbuffered is a Middleware which switches responses to streaming mode before the next
+// handler is executed. It can wrap a single route to stream its responses.
+//
+// Example:
+//
+//	rtr.MethodFunc(http.MethodGet, "/export", boar.Unbuffered(exportHandler))
+func Unbuffered(next HandlerFunc) HandlerFunc {
+	return func(c Context) error {
+		if err := c.Unbuffer(); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,178 @@
+package boar
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamWritesDirectlyToTheClient(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(nil, w, nil)
+
+	data := strings.Repeat("a", streamBufferSize*3)
+	require.NoError(t, c.Stream(http.StatusOK, "text/plain", strings.NewReader(data)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain", w.Header().Get("content-type"))
+	assert.Equal(t, data, w.Body.String())
+	assert.Equal(t, len(data), c.Response().Len())
+	assert.True(t, w.Flushed)
+}
+
+func TestStreamFlushesPeriodically(t *testing.T) {
+	defer func(d time.Duration) { StreamFlushInterval = d }(StreamFlushInterval)
+	StreamFlushInterval = 0
+
+	w := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+	c := newContext(nil, w, nil)
+
+	data := strings.Repeat("a", streamBufferSize*2)
+	require.NoError(t, c.Stream(http.StatusOK, "text/plain", strings.NewReader(data)))
+	assert.Equal(t, 3, w.flushes)
+}
+
+func TestStreamReturnsReadErrors(t *testing.T) {
+	c := newContext(nil, httptest.NewRecorder(), nil)
+	err := c.Stream(http.StatusOK, "text/plain", io.MultiReader(bytes.NewBufferString("a"), errReader{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), io.ErrClosedPipe.Error())
+}
+
+func TestErrorHandlerDoesNotWriteAfterStream(t *testing.T) {
+	r := NewRouter()
+	r.MethodFunc(http.MethodGet, "/", func(c Context) error {
+		c.Stream(http.StatusOK, "text/plain", bytes.NewBufferString("hello"))
+		return ErrForbidden
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hello", rec.Body.String())
+}
+
+type flushCounter struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (f *flushCounter) Flush() {
+	f.flushes++
+	f.ResponseRecorder.Flush()
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestUnbufferedSendsHeadersAndWritesImmediately(t *testing.T) {
+	w := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	handler := Unbuffered(func(c Context) error {
+		c.Response().Header().Set("content-type", "text/csv")
+		c.Response().WriteHeader(http.StatusAccepted)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.True(t, w.Flushed)
+
+		io.WriteString(c.Response(), "a,b\n")
+		assert.Equal(t, "a,b\n", w.Body.String())
+		return nil
+	})
+	require.NoError(t, handler(c))
+
+	assert.Equal(t, "text/csv", w.Header().Get("content-type"))
+	assert.Equal(t, 2, w.flushes)
+}
+
+func TestUnbufferSendsAlreadyBufferedData(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	io.WriteString(c.Response(), "hello ")
+	require.NoError(t, c.Unbuffer())
+	assert.Empty(t, w.Body.String())
+
+	io.WriteString(c.Response(), "world")
+	assert.Equal(t, "hello world", w.Body.String())
+}
+
+func TestWriteJSONStreamEncodesSlicesAsArrays(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	users := []map[string]int{{"id": 1}, {"id": 2}}
+	require.NoError(t, c.WriteJSONStream(http.StatusOK, users))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("content-type"))
+	assert.JSONEq(t, `[{"id":1},{"id":2}]`, w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func TestWriteJSONStreamWritesElementsAsTheyAreEncoded(t *testing.T) {
+	defer func(d time.Duration) { StreamFlushInterval = d }(StreamFlushInterval)
+	StreamFlushInterval = 0
+
+	w := &flushCounter{ResponseRecorder: httptest.NewRecorder()}
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	require.NoError(t, c.WriteJSONStream(http.StatusOK, []int{1, 2, 3}))
+	assert.Equal(t, "[1,2,3]\n", w.Body.String())
+	// once per element and once at the end
+	assert.Equal(t, 4, w.flushes)
+}
+
+func TestWriteJSONStreamReadsChannelsUntilClosed(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+	require.NoError(t, c.WriteJSONStream(http.StatusOK, ch))
+	assert.Equal(t, `["a","b"]`+"\n", w.Body.String())
+}
+
+func TestWriteJSONStreamStopsReadingChannelsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	c := newContext(req, httptest.NewRecorder(), nil)
+
+	cancel()
+	err := c.WriteJSONStream(http.StatusOK, make(chan int))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWriteJSONStreamEncodesOtherValuesWhole(t *testing.T) {
+	tests := map[string]struct {
+		v    interface{}
+		body string
+	}{
+		"struct": {v: struct{ Name string }{"brett"}, body: `{"Name":"brett"}`},
+		"bytes":  {v: []byte("hi"), body: `"aGk="`},
+		"nil":    {v: []int(nil), body: `null`},
+		"map":    {v: map[string]int{"a": 1}, body: `{"a":1}`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+
+			require.NoError(t, c.WriteJSONStream(http.StatusCreated, tt.v))
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package boar
+
+import (
+	"net/http"
+	"strings"
+)
+
+// TrailingSlash is a policy for the trailing slash of request paths
+type TrailingSlash int
+
+const (
+	// TrailingSlashRoute redirects requests to the route registered with or without a
+	// trailing slash. It is the default behavior of httprouter
+	TrailingSlashRoute TrailingSlash = iota
+	// TrailingSlashStrip redirects every request path ending with a slash to the path
+	// without it
+	TrailingSlashStrip
+	// TrailingSlashAppend redirects every request path not ending with a slash to the
+	// path with one
+	TrailingSlashAppend
+)
+
+// SetTrailingSlash sets the policy used to normalize the trailing slash of request paths.
+// Requests are redirected with code, or with http.StatusMovedPermanently for GET and HEAD
+// and http.StatusPermanentRedirect for other methods when code is zero. Routes should be
+// registered in the normalized form because the redirects of httprouter are disabled for
+// TrailingSlashStrip and TrailingSlashAppend to prevent redirect loops.
+//
+// Example:
+//
+//	rtr.SetTrailingSlash(boar.TrailingSlashStrip, 0)
+func (rtr *Router) SetTrailingSlash(policy TrailingSlash, code int) {
+	root := rtr.root()
+	root.trailingSlash = policy
+	root.trailingSlashCode = code
+	root.base.RedirectTrailingSlash = policy == TrailingSlashRoute
+}
+
+// redirectTrailingSlash redirects r when its path does not match the TrailingSlash policy
+// and reports whether it was redirected
+func (rtr *Router) redirectTrailingSlash(w http.ResponseWriter, r *http.Request) bool {
+	p := r.URL.Path
+	if p == "/" || p == "" {
+		return false
+	}
+
+	u := *r.URL
+	switch rtr.trailingSlash {
+	case TrailingSlashStrip:
+		if !strings.HasSuffix(p, "/") {
+			return false
+		}
+		u.Path = strings.TrimRight(p, "/")
+		u.RawPath = strings.TrimRight(u.RawPath, "/")
+	case TrailingSlashAppend:
+		if strings.HasSuffix(p, "/") {
+			return false
+		}
+		u.Path = p + "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	default:
+		return false
+	}
+	// collapse leading slashes so that paths such as //example.com/ are not redirected
+	// to another host
+	u.Path = "/" + strings.TrimLeft(u.Path, "/")
+	if u.RawPath != "" {
+		u.RawPath = "/" + strings.TrimLeft(u.RawPath, "/")
+	}
+
+	code := rtr.trailingSlashCode
+	if code == 0 {
+		code = http.StatusMovedPermanently
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			code = http.StatusPermanentRedirect
+		}
+	}
+	http.Redirect(w, r, u.RequestURI(), code)
+	return true
+}
@@ -0,0 +1,61 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTrailingSlash(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   TrailingSlash
+		code     int
+		method   string
+		target   string
+		status   int
+		location string
+	}{
+		{"strip", TrailingSlashStrip, 0, http.MethodGet, "/users/?page=2", http.StatusMovedPermanently, "/users?page=2"},
+		{"strip post", TrailingSlashStrip, 0, http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users"},
+		{"strip code", TrailingSlashStrip, http.StatusFound, http.MethodGet, "/users/", http.StatusFound, "/users"},
+		{"strip normalized", TrailingSlashStrip, 0, http.MethodGet, "/users", http.StatusOK, ""},
+		{"strip root", TrailingSlashStrip, 0, http.MethodGet, "/", http.StatusOK, ""},
+		{"strip host", TrailingSlashStrip, 0, http.MethodGet, "//example.com/", http.StatusMovedPermanently, "/example.com"},
+		{"append", TrailingSlashAppend, 0, http.MethodGet, "/users?page=2", http.StatusMovedPermanently, "/users/?page=2"},
+		{"append normalized", TrailingSlashAppend, 0, http.MethodGet, "/users/", http.StatusOK, ""},
+		{"route", TrailingSlashRoute, 0, http.MethodGet, "/users", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRouter()
+			r.SetTrailingSlash(tt.policy, tt.code)
+			ok := func(c Context) error { return c.WriteStatus(http.StatusOK) }
+			r.MethodFunc(http.MethodGet, "/", ok)
+			if tt.policy == TrailingSlashAppend {
+				r.MethodFunc(http.MethodGet, "/users/", ok)
+			} else {
+				r.MethodFunc(http.MethodGet, "/users", ok)
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.location, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func TestSetTrailingSlashShouldDisableRouteRedirects(t *testing.T) {
+	r := NewRouter()
+	r.SetTrailingSlash(TrailingSlashStrip, 0)
+	r.MethodFunc(http.MethodGet, "/users/", func(c Context) error {
+		return c.WriteStatus(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,50 @@
+package boar
+
+import (
+	"strings"
+)
+
+func (r *requestContext) BaseURL() string {
+	return r.scheme() + "://" + r.host()
+}
+
+func (r *requestContext) FullURL() string {
+	return r.BaseURL() + r.request.URL.RequestURI()
+}
+
+// scheme returns the scheme used by the client. Proxy headers are only used when the
+// request was sent by a trusted proxy
+func (r *requestContext) scheme() string {
+	if r.fromTrustedProxy() {
+		if protos := forwardedParam(r.request.Header.Get("forwarded"), "proto"); len(protos) > 0 {
+			return strings.ToLower(protos[0])
+		}
+		if proto := firstHeaderValue(r.request.Header.Get("x-forwarded-proto")); proto != "" {
+			return strings.ToLower(proto)
+		}
+	}
+	if r.request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// host returns the host requested by the client. Proxy headers are only used when the
+// request was sent by a trusted proxy
+func (r *requestContext) host() string {
+	if r.fromTrustedProxy() {
+		if hosts := forwardedParam(r.request.Header.Get("forwarded"), "host"); len(hosts) > 0 {
+			return hosts[0]
+		}
+		if host := firstHeaderValue(r.request.Header.Get("x-forwarded-host")); host != "" {
+			return host
+		}
+	}
+	return r.request.Host
+}
+
+// firstHeaderValue returns the first value of a comma separated header which has been
+// appended to by several proxies
+func firstHeaderValue(header string) string {
+	return strings.TrimSpace(strings.Split(header, ",")[0])
+}
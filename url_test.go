@@ -0,0 +1,61 @@
+package boar
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func urlContext(t *testing.T, target string, headers map[string]string, trusted ...string) *requestContext {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = "10.0.0.2:5000"
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	c := newContext(req, httptest.NewRecorder(), nil)
+	c.router = NewRouter()
+	proxies, err := ParseCIDRs(trusted...)
+	require.NoError(t, err)
+	c.router.TrustedProxies = proxies
+	return c
+}
+
+func TestFullURLShouldUseTheRequestHost(t *testing.T) {
+	c := urlContext(t, "http://api.example.com/users?page=2", nil)
+	assert.Equal(t, "http://api.example.com", c.BaseURL())
+	assert.Equal(t, "http://api.example.com/users?page=2", c.FullURL())
+}
+
+func TestBaseURLShouldUseHTTPSForTLSRequests(t *testing.T) {
+	c := urlContext(t, "http://api.example.com/", nil)
+	c.request.TLS = &tls.ConnectionState{}
+	assert.Equal(t, "https://api.example.com", c.BaseURL())
+}
+
+func TestBaseURLShouldIgnoreHeadersFromUntrustedRemotes(t *testing.T) {
+	c := urlContext(t, "http://internal:8080/", map[string]string{
+		"X-Forwarded-Proto": "https",
+		"X-Forwarded-Host":  "evil.example.com",
+	})
+	assert.Equal(t, "http://internal:8080", c.BaseURL())
+}
+
+func TestBaseURLShouldUseXForwardedHeadersFromTrustedProxies(t *testing.T) {
+	c := urlContext(t, "http://internal:8080/", map[string]string{
+		"X-Forwarded-Proto": "https, http",
+		"X-Forwarded-Host":  "example.com",
+	}, "10.0.0.0/8")
+	assert.Equal(t, "https://example.com", c.BaseURL())
+}
+
+func TestBaseURLShouldPreferForwardedHeader(t *testing.T) {
+	c := urlContext(t, "http://internal:8080/a", map[string]string{
+		"Forwarded":         `for=192.0.2.60;proto=https;host="example.org"`,
+		"X-Forwarded-Proto": "http",
+	}, "10.0.0.0/8")
+	assert.Equal(t, "https://example.org/a", c.FullURL())
+}
@@ -0,0 +1,356 @@
+package boar
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/go-playground/validator.v9"
+)
+
+// StructValidator validates Query, URLParams, and Body structs after they have been bound
+// from the request. Adapters are provided for validator.v9 (the default) and
+// github.com/asaskevich/govalidator.
+type StructValidator interface {
+	// ValidateStruct returns an error describing why v is invalid or nil when v is valid
+	ValidateStruct(v interface{}) error
+}
+
+// Validator is the StructValidator used for all request and response validation. It can be
+// replaced with another adapter, or a custom implementation, before the Router is served.
+//
+// Example:
+//
+//	v := validator.New()
+//	v.RegisterValidation("is-awesome", isAwesome)
+//	boar.Validator = boar.NewPlaygroundValidator(v)
+var Validator StructValidator = NewPlaygroundValidator(validator.New())
+
+// MethodStructValidator is a StructValidator which supports rules scoped to the HTTP method
+// of the request. This allows create and update handlers to share a single Body struct.
+// The validator.v9 adapter reads method specific rules from tags named validate_<method>
+// which replace the validate tag for requests with that method.
+//
+// Example:
+//
+//	type userBody struct {
+//	    Email string `json:"email" validate:"omitempty,email" validate_post:"required,email"`
+//	}
+type MethodStructValidator interface {
+	StructValidator
+	// ValidateStructForMethod validates v using the rules for the given HTTP method
+	ValidateStructForMethod(method string, v interface{}) error
+}
+
+// fieldErrorValidator is implemented by adapters that can report the struct field of
+// each validation failure
+type fieldErrorValidator interface {
+	fieldErrors(typ reflect.Type, err error) []FieldError
+}
+
+const methodTagPrefix = "validate_"
+
+// ValidationSkipper can be implemented by Query, URLParams, or Body structs to bind
+// request data without running the validator. This is useful for endpoints that perform
+// their own staged validation. Validation can also be skipped by tagging the handler
+// field with `boar:"novalidate"`
+//
+// Example:
+//
+//	type CreateUserHandler struct {
+//	    Body createUserBody `boar:"novalidate"`
+//	}
+type ValidationSkipper interface {
+	SkipValidation() bool
+}
+
+// NewPlaygroundValidator creates a StructValidator backed by gopkg.in/go-playground/validator.v9
+// which validates the `validate` struct tag
+func NewPlaygroundValidator(v *validator.Validate) StructValidator {
+	return &playgroundValidator{v: v}
+}
+
+type playgroundValidator struct {
+	v *validator.Validate
+}
+
+func (p *playgroundValidator) ValidateStruct(v interface{}) error {
+	return p.v.Struct(v)
+}
+
+func (p *playgroundValidator) ValidateStructForMethod(method string, v interface{}) error {
+	variants := methodVariants(reflect.TypeOf(v), methodTagPrefix+strings.ToLower(method))
+	if len(variants) == 0 {
+		return p.v.Struct(v)
+	}
+
+	skip := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		skip[variant.namespace] = true
+	}
+
+	err := p.v.StructFiltered(v, func(ns []byte) bool {
+		return skip[string(ns)]
+	})
+	errs, ok := err.(validator.ValidationErrors)
+	if err != nil && !ok {
+		return err
+	}
+
+	fes := fieldErrorList(errs)
+	val := reflect.Indirect(reflect.ValueOf(v))
+	for _, variant := range variants {
+		verr := p.v.Var(val.FieldByIndex(variant.index).Interface(), variant.tag)
+		ves, ok := verr.(validator.ValidationErrors)
+		if verr != nil && !ok {
+			return verr
+		}
+		for _, fe := range ves {
+			fes = append(fes, &namedFieldError{FieldError: fe, namespace: variant.namespace, name: variant.name})
+		}
+	}
+	if len(fes) == 0 {
+		return nil
+	}
+	return fes
+}
+
+// fieldErrorList is used in place of validator.ValidationErrors when the errors were not
+// all created by the validator itself
+type fieldErrorList []validator.FieldError
+
+func (l fieldErrorList) Error() string {
+	s := make([]string, len(l))
+	for i, fe := range l {
+		s[i] = fmt.Sprint(fe)
+	}
+	return strings.Join(s, "\n")
+}
+
+type methodVariant struct {
+	namespace string
+	index     []int
+	name      string
+	tag       string
+}
+
+// methodVariants finds the fields of typ which have a method specific validation tag
+func methodVariants(typ reflect.Type, tagName string) []methodVariant {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	prefix := ""
+	if typ.Name() != "" {
+		prefix = typ.Name() + "."
+	}
+	var variants []methodVariant
+	var walk func(reflect.Type, string, []int)
+	walk = func(typ reflect.Type, ns string, index []int) {
+		for i := 0; i < typ.NumField(); i++ {
+			sf := typ.Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			idx := append(append([]int{}, index...), i)
+			if tag, ok := sf.Tag.Lookup(tagName); ok {
+				variants = append(variants, methodVariant{
+					namespace: ns + sf.Name,
+					index:     idx,
+					name:      sf.Name,
+					tag:       tag,
+				})
+				continue
+			}
+			if sf.Type.Kind() == reflect.Struct {
+				walk(sf.Type, ns+sf.Name+".", idx)
+			}
+		}
+	}
+	if typ.Kind() == reflect.Struct {
+		walk(typ, prefix, nil)
+	}
+	return variants
+}
+
+// namedFieldError names a FieldError that was created by validating a single field
+// with (*validator.Validate).Var
+type namedFieldError struct {
+	validator.FieldError
+	namespace string
+	name      string
+}
+
+func (n *namedFieldError) Namespace() string       { return n.namespace }
+func (n *namedFieldError) StructNamespace() string { return n.namespace }
+func (n *namedFieldError) Field() string           { return n.name }
+func (n *namedFieldError) StructField() string     { return n.name }
+
+func (n *namedFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", n.namespace, n.name, n.Tag())
+}
+
+func (p *playgroundValidator) fieldErrors(typ reflect.Type, err error) []FieldError {
+	var ves []validator.FieldError
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		ves = e
+	case fieldErrorList:
+		ves = e
+	default:
+		return nil
+	}
+	fields := make([]FieldError, len(ves))
+	for i, fe := range ves {
+		fields[i] = FieldError{
+			Field:       jsonFieldPath(typ, fe.StructNamespace()),
+			StructField: structFieldPath(typ, fe.StructNamespace()),
+			Tag:         fe.Tag(),
+			Param:       fe.Param(),
+			Message:     validationMessage(fe),
+		}
+	}
+	return fields
+}
+
+// validateField validates the field of the handler unless validation has been disabled
+// with the novalidate tag or ValidationSkipper
+func validateField(handler reflect.Value, method, fieldName string, field reflect.Value) error {
+	v := field.Addr().Interface()
+	if skipper, ok := v.(ValidationSkipper); ok && skipper.SkipValidation() {
+		return nil
+	}
+	if layoutOf(handler.Type()).fields[fieldName].noValidate {
+		return nil
+	}
+	return validateMethod(method, fieldName, v)
+}
+
+func validate(fieldName string, v interface{}) error {
+	return validateMethod("", fieldName, v)
+}
+
+// validateMethod validates v with the rules of the given HTTP method when the Validator
+// supports them
+func validateMethod(method, fieldName string, v interface{}) error {
+	var err error
+	if mv, ok := Validator.(MethodStructValidator); ok && method != "" {
+		err = mv.ValidateStructForMethod(method, v)
+	} else {
+		err = Validator.ValidateStruct(v)
+	}
+	if err != nil {
+		verr := NewValidationErrors(fieldName, []error{err})
+		if fv, ok := Validator.(fieldErrorValidator); ok {
+			verr.fields = fv.fieldErrors(reflect.TypeOf(v), err)
+		}
+		return verr
+	}
+	return nil
+}
+
+// validateResponse validates a response value, or each element of a response slice,
+// against its validate tags
+func validateResponse(v interface{}) error {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	switch val.Kind() {
+	case reflect.Struct:
+		return Validator.ValidateStruct(val.Interface())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := validateResponse(val.Index(i).Interface()); err != nil {
+				return fmt.Errorf("[%d]: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// structFieldPath returns the path of the struct field with the namespace of the validator
+// without the leading type name
+func structFieldPath(typ reflect.Type, namespace string) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if i := strings.IndexByte(namespace, '.'); i >= 0 && typ.Name() != "" {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// jsonFieldPath converts a validator struct namespace such as MyStruct.Address.ZipCode
+// into the path of json names used for the same field, e.g. address.zip_code
+func jsonFieldPath(typ reflect.Type, namespace string) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	parts := strings.Split(namespace, ".")
+	// the namespace of named types begins with the type name
+	if typ.Name() != "" {
+		parts = parts[1:]
+	}
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		name, index := part, ""
+		if j := strings.IndexByte(part, '['); j >= 0 {
+			name, index = part[:j], part[j:]
+		}
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice ||
+			typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			names[i] = part
+			continue
+		}
+		sf, ok := typ.FieldByName(name)
+		if !ok {
+			names[i] = part
+			continue
+		}
+		names[i] = jsonName(sf) + index
+		typ = sf.Type
+	}
+	return strings.Join(names, ".")
+}
+
+func jsonName(sf reflect.StructField) string {
+	name := strings.Split(sf.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+// validationMessage creates a human readable message for a failed validation
+func validationMessage(fe validator.FieldError) string {
+	var unit string
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "url", "uri":
+		return "must be a valid URL"
+	case "len":
+		return fmt.Sprintf("must be %s%s long", fe.Param(), unit)
+	case "min", "gte":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit)
+	case "max", "lte":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s%s", fe.Param(), unit)
+	case "lt":
+		return fmt.Sprintf("must be less than %s%s", fe.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", fe.Param())
+	default:
+		return fmt.Sprintf("failed on the %q validation", fe.Tag())
+	}
+}
@@ -0,0 +1,88 @@
+package boar
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeValidator struct {
+	err error
+}
+
+func (f fakeValidator) ValidateStruct(interface{}) error { return f.err }
+
+func TestValidateUsesConfiguredValidator(t *testing.T) {
+	expected := errors.New("invalid")
+	defer func(v StructValidator) { Validator = v }(Validator)
+	Validator = fakeValidator{err: expected}
+
+	var handler struct {
+		Query struct {
+			Name string
+		}
+	}
+	err := setQuery(reflect.Indirect(reflect.ValueOf(&handler)), url.Values{})
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, []error{expected}, err.(*ValidationError).Errors)
+	assert.Empty(t, err.(*ValidationError).fields)
+}
+
+func TestPlaygroundValidatorReportsFieldErrors(t *testing.T) {
+	var body struct {
+		Name string `json:"name" validate:"min=3"`
+	}
+	body.Name = "a"
+
+	err := validate(bodyField, &body)
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, []FieldError{{Field: "name", StructField: "Name", Tag: "min", Param: "3", Message: "must be at least 3 characters"}},
+		err.(*ValidationError).fields)
+}
+
+func TestValidateResponseValidatesSliceElements(t *testing.T) {
+	type item struct {
+		Name string `validate:"required"`
+	}
+
+	assert.NoError(t, validateResponse([]item{{Name: "a"}}))
+	assert.Error(t, validateResponse([]item{{Name: "a"}, {}}))
+	assert.NoError(t, validateResponse(JSON{"name": ""}))
+}
+
+func TestValidateMethodUsesMethodSpecificTags(t *testing.T) {
+	type userBody struct {
+		Email string `json:"email" validate:"omitempty,email" validate_post:"required,email"`
+		Name  string `json:"name" validate:"max=3"`
+	}
+
+	body := userBody{Name: "abcd"}
+
+	err := validateMethod("PATCH", bodyField, &body)
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, []FieldError{{Field: "name", StructField: "Name", Tag: "max", Param: "3", Message: "must be at most 3 characters"}},
+		err.(*ValidationError).fields)
+
+	err = validateMethod("POST", bodyField, &body)
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, []FieldError{
+		{Field: "name", StructField: "Name", Tag: "max", Param: "3", Message: "must be at most 3 characters"},
+		{Field: "email", StructField: "Email", Tag: "required", Message: "is required"},
+	}, err.(*ValidationError).fields)
+	assert.Contains(t, err.Error(), "'userBody.Email'")
+}
+
+func TestValidateMethodAllowsValidMethodSpecificFields(t *testing.T) {
+	var body struct {
+		Address struct {
+			Zip string `validate:"required" validate_patch:"omitempty,len=5"`
+		}
+	}
+
+	assert.Error(t, validateMethod("POST", bodyField, &body))
+	assert.NoError(t, validateMethod("PATCH", bodyField, &body))
+}
@@ -0,0 +1,56 @@
+package boar
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	errNoUpgrader      = errors.New("no Upgrader has been set on the Router")
+	errCannotUpgrade   = errors.New("the response writer does not support connection upgrades")
+	errAlreadyUpgraded = errors.New("the connection has already been upgraded")
+)
+
+// WebSocket is a websocket connection. It is satisfied by *websocket.Conn from
+// github.com/gorilla/websocket
+type WebSocket interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// Upgrader upgrades an HTTP request to a websocket connection. Failed handshakes should be
+// returned as errors, preferably HTTPErrors, rather than written to w so that they are
+// handled by the Router's ErrorHandler
+type Upgrader interface {
+	Upgrade(w http.ResponseWriter, r *http.Request) (WebSocket, error)
+}
+
+// UpgraderFunc is a func which implements Upgrader
+type UpgraderFunc func(w http.ResponseWriter, r *http.Request) (WebSocket, error)
+
+// Upgrade calls fn(w, r)
+func (fn UpgraderFunc) Upgrade(w http.ResponseWriter, r *http.Request) (WebSocket, error) {
+	return fn(w, r)
+}
+
+func (r *requestContext) Upgrade() (WebSocket, error) {
+	if r.router == nil || r.router.Upgrader == nil {
+		return nil, errNoUpgrader
+	}
+	bw, ok := r.response.(*BufferedResponseWriter)
+	if !ok {
+		return nil, errCannotUpgrade
+	}
+	if bw.isHijacked() {
+		return nil, errAlreadyUpgraded
+	}
+
+	ws, err := r.router.Upgrader.Upgrade(bw.base, r.request)
+	if err != nil {
+		return nil, err
+	}
+	// the connection now belongs to the websocket so nothing can be flushed to it
+	bw.setHijacked()
+	return ws, nil
+}
@@ -0,0 +1,27 @@
+package boar
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// NewGorillaUpgrader creates an Upgrader from a github.com/gorilla/websocket Upgrader.
+// Handshake errors are returned as HTTPErrors instead of being written by u.Error
+func NewGorillaUpgrader(u websocket.Upgrader) Upgrader {
+	return UpgraderFunc(func(w http.ResponseWriter, r *http.Request) (WebSocket, error) {
+		var herr error
+		up := u
+		up.Error = func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+			herr = NewHTTPError(status, reason)
+		}
+		conn, err := up.Upgrade(w, r, nil)
+		if herr != nil {
+			return nil, herr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	})
+}
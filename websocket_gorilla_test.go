@@ -0,0 +1,58 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGorillaUpgraderShouldEcho(t *testing.T) {
+	r := NewRouter()
+	r.Upgrader = NewGorillaUpgrader(websocket.Upgrader{})
+	r.MethodFunc(http.MethodGet, "/ws", func(c Context) error {
+		ws, err := c.Upgrade()
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+		mt, msg, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		return ws.WriteMessage(mt, msg)
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	mt, msg, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, websocket.TextMessage, mt)
+	assert.Equal(t, "hello", string(msg))
+}
+
+func TestGorillaUpgraderShouldReturnHandshakeErrors(t *testing.T) {
+	r := NewRouter()
+	r.Upgrader = NewGorillaUpgrader(websocket.Upgrader{})
+	var herr error
+	r.MethodFunc(http.MethodGet, "/ws", func(c Context) error {
+		_, herr = c.Upgrade()
+		return herr
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	_, ok := herr.(HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
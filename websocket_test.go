@@ -0,0 +1,58 @@
+package boar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWebSocket struct{}
+
+func (fakeWebSocket) ReadMessage() (int, []byte, error) { return 0, nil, nil }
+func (fakeWebSocket) WriteMessage(int, []byte) error    { return nil }
+func (fakeWebSocket) Close() error                      { return nil }
+
+func TestUpgradeReturnsErrorWithoutUpgrader(t *testing.T) {
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder(), nil)
+	_, err := c.Upgrade()
+	assert.Equal(t, errNoUpgrader, err)
+}
+
+func TestUpgradeUsesBaseWriterAndStopsFlushing(t *testing.T) {
+	w := httptest.NewRecorder()
+	c := newContext(httptest.NewRequest(http.MethodGet, "/", nil), w, nil)
+	c.router = NewRouter()
+	c.router.Upgrader = UpgraderFunc(func(bw http.ResponseWriter, r *http.Request) (WebSocket, error) {
+		assert.Equal(t, w, bw)
+		return fakeWebSocket{}, nil
+	})
+
+	ws, err := c.Upgrade()
+	require.NoError(t, err)
+	assert.NotNil(t, ws)
+
+	require.NoError(t, c.Response().Flush())
+	assert.False(t, w.Flushed)
+	assert.Empty(t, w.Body.String())
+
+	_, err = c.Upgrade()
+	assert.Equal(t, errAlreadyUpgraded, err)
+}
+
+func TestUpgradeErrorsFlowThroughErrorHandler(t *testing.T) {
+	r := NewRouter()
+	r.Upgrader = UpgraderFunc(func(http.ResponseWriter, *http.Request) (WebSocket, error) {
+		return nil, NewHTTPErrorStatus(http.StatusBadRequest)
+	})
+	r.MethodFunc(http.MethodGet, "/ws", func(c Context) error {
+		_, err := c.Upgrade()
+		return err
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}